@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/config"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+)
+
+// adminCommand runs one of the admin subcommands. grant makes a user an
+// admin, which is how the first admin of a deployment is created.
+func adminCommand(cfg *config.Config, args []string) error {
+	if len(args) != 2 || args[0] != "grant" {
+		return fmt.Errorf("usage: admin grant EMAIL")
+	}
+	email := args[1]
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, cfg.Database.URL())
+	if err != nil {
+		return fmt.Errorf("unable to create connection pool: %w", err)
+	}
+	defer pool.Close()
+
+	userRepo := repository.NewUserRepository(pool)
+	user, err := userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user.Role == domain.RoleAdmin {
+		fmt.Printf("%s is already an admin\n", user.Email)
+		return nil
+	}
+
+	if _, err := userRepo.UpdateRole(ctx, user.ID, domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	// No actor: the change was made from the command line
+	err = repository.NewAuditEventRepository(pool).RecordEvent(ctx, &domain.AuditEvent{
+		UserID:    user.ID,
+		Action:    domain.AuditRoleChanged,
+		Details:   map[string]string{"old_role": user.Role, "new_role": domain.RoleAdmin},
+		UserAgent: "team-task-hub admin grant",
+	})
+	if err != nil {
+		slog.Error("failed to record audit event", "error", err)
+	}
+
+	fmt.Printf("%s is now an admin\n", user.Email)
+	return nil
+}
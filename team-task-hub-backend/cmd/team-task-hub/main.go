@@ -2,90 +2,147 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/jackc/pgx/v5"
 	"github.com/joho/godotenv"
 	"github.com/launchventures/team-task-hub-backend/internal/app"
 	"github.com/launchventures/team-task-hub-backend/internal/config"
+	"github.com/launchventures/team-task-hub-backend/internal/logging"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
 )
 
+const usage = `Usage:
+  team-task-hub [serve] [-auto-migrate=true|false]
+  team-task-hub migrate up
+  team-task-hub migrate down [N]
+  team-task-hub migrate to N
+  team-task-hub migrate status
+  team-task-hub migrate force N
+  team-task-hub admin grant EMAIL
+`
+
 func main() {
 	godotenv.Load()
 
-	cfg := config.New()
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logging.New(os.Stdout, cfg.Log.Level))
+	slog.Info("configuration loaded", "environment", cfg.Environment)
 
-	dbURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-		cfg.Database.SSLMode,
-	)
+	// With no subcommand the binary serves, as it always has
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
 
-	if err := runMigrations(dbURL); err != nil {
-		// Log but don't fail - migrations might already be run
-		log.Printf("Warning: Migration skipped: %v\n", err)
+	switch command {
+	case "serve":
+		err = serveCommand(cfg, args)
+	case "migrate":
+		err = migrateCommand(cfg, args)
+	case "admin":
+		err = adminCommand(cfg, args)
+	case "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
 	}
 
-	log.Println("Initializing application...")
-	application, err := app.New(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize application: %v\n", err)
+		slog.Error("command failed", "command", command, "error", err)
+		os.Exit(1)
 	}
-	log.Println("Application initialized successfully")
-	defer application.Close()
+}
+
+// serveCommand optionally applies pending migrations, refuses to start on a
+// dirty or outdated schema, and then serves
+func serveCommand(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	autoMigrate := flags.Bool("auto-migrate", cfg.Database.AutoMigrate, "apply pending migrations before serving")
+	flags.Parse(args)
 
-	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	log.Printf("Server starting on %s\n", addr)
-	if err := http.ListenAndServe(addr, application.Router); err != nil {
-		log.Fatalf("Server error: %v\n", err)
+	if err := prepareSchema(cfg, *autoMigrate); err != nil {
+		return err
 	}
-}
 
-func runMigrations(dbURL string) error {
-	// First, verify database connectivity with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	return serve(cfg)
+}
 
-	conn, err := pgx.Connect(ctx, dbURL)
+// serve runs the HTTP server until SIGINT or SIGTERM, then stops accepting
+// connections, lets in-flight requests drain, and shuts down the background
+// workers, the database pool and the tracer in that order
+func serve(cfg *config.Config) error {
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return fmt.Errorf("failed to set up tracing: %w", err)
 	}
-	defer conn.Close(ctx)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Warn("failed to flush traces", "error", err)
+		}
+	}()
 
-	// If connected, run migrations
-	m, err := migrate.New("file://migrations", dbURL)
+	slog.Info("initializing application")
+	application, err := app.New(cfg)
 	if err != nil {
-		return fmt.Errorf("migration instance creation failed: %w", err)
+		return fmt.Errorf("failed to initialize application: %w", err)
 	}
-	defer m.Close()
-
-	// Check if database is dirty and try to fix it
-	v, dirty, err := m.Version()
-	if dirty {
-		log.Printf("Database is dirty at version %d, attempting to force version 0...", v)
-		if err := m.Force(0); err != nil {
-			log.Printf("Force failed, attempting to proceed anyway: %v", err)
-		}
+	slog.Info("application initialized")
+	defer application.Close()
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
+		Handler:           application.Router,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
 	}
 
-	if err = m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("migration up failed: %w", err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", "addr", server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server error: %w", err)
+	case <-ctx.Done():
 	}
 
-	version, dirty, err := m.Version()
-	if err != nil {
-		return fmt.Errorf("getting migration version failed: %w", err)
+	// A second signal now terminates immediately
+	stop()
+	slog.Info("shutting down, draining in-flight requests", "timeout", cfg.Server.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		// Closing the remaining connections cancels their request contexts,
+		// which aborts any queries still running for them
+		slog.Warn("drain timed out, closing remaining connections", "error", err)
+		server.Close()
 	}
 
-	log.Printf("Database migration completed. Current version: %d (dirty: %v)\n", version, dirty)
+	slog.Info("server stopped")
 	return nil
 }
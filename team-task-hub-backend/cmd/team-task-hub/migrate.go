@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/launchventures/team-task-hub-backend/internal/config"
+	"github.com/launchventures/team-task-hub-backend/internal/migration"
+)
+
+// migrateCommand runs one of the migrate subcommands
+func migrateCommand(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate subcommand (up, down, to, status or force)")
+	}
+
+	runner, err := migration.NewRunner(cfg.Database.URL(), cfg.Database.MigrationsPath)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+
+	subcommand, args := args[0], args[1:]
+	switch subcommand {
+	case "up":
+		err = runner.Up()
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("down takes a positive number of steps, got %q", args[0])
+			}
+		}
+		err = runner.Down(steps)
+	case "to":
+		if len(args) != 1 {
+			return fmt.Errorf("to takes a target version")
+		}
+		version, parseErr := strconv.ParseUint(args[0], 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		err = runner.To(uint(version))
+	case "force":
+		if len(args) != 1 {
+			return fmt.Errorf("force takes the version to record")
+		}
+		version, parseErr := strconv.Atoi(args[0])
+		if parseErr != nil || version < -1 {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		err = runner.Force(version)
+	case "status":
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q", subcommand)
+	}
+	if err != nil {
+		return fmt.Errorf("migrate %s failed: %w", subcommand, err)
+	}
+
+	status, err := runner.Status()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version: %d\ndirty: %t\nlatest: %d\n", status.Version, status.Dirty, status.Latest)
+	return nil
+}
+
+// prepareSchema applies pending migrations when autoMigrate is set, then
+// checks the schema is clean and at least as new as this build expects
+func prepareSchema(cfg *config.Config, autoMigrate bool) error {
+	runner, err := migration.NewRunner(cfg.Database.URL(), cfg.Database.MigrationsPath)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+
+	if autoMigrate {
+		if err := runner.Up(); err != nil {
+			return fmt.Errorf("automatic migration failed: %w", err)
+		}
+	}
+
+	status, err := runner.Status()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case status.Dirty:
+		return fmt.Errorf("database schema is dirty at version %d; repair it and record the result with `migrate force N`", status.Version)
+	case status.Version < status.Latest:
+		return fmt.Errorf("database schema is at version %d but this build needs %d; run `migrate up`", status.Version, status.Latest)
+	case status.Version > status.Latest:
+		slog.Warn("database schema is newer than this build", "version", status.Version, "latest", status.Latest)
+	default:
+		slog.Info("database schema is up to date", "version", status.Version)
+	}
+
+	return nil
+}
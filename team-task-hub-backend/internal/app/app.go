@@ -4,16 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/launchventures/team-task-hub-backend/internal/config"
+	"github.com/launchventures/team-task-hub-backend/internal/cors"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
 	"github.com/launchventures/team-task-hub-backend/internal/handler"
+	"github.com/launchventures/team-task-hub-backend/internal/health"
+	"github.com/launchventures/team-task-hub-backend/internal/keys"
+	"github.com/launchventures/team-task-hub-backend/internal/mail"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
 	appMiddleware "github.com/launchventures/team-task-hub-backend/internal/middleware"
+	"github.com/launchventures/team-task-hub-backend/internal/migration"
+	"github.com/launchventures/team-task-hub-backend/internal/oidc"
+	"github.com/launchventures/team-task-hub-backend/internal/ratelimit"
 	"github.com/launchventures/team-task-hub-backend/internal/repository"
 	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+	"github.com/launchventures/team-task-hub-backend/internal/worker"
 )
 
 // App represents the application
@@ -21,19 +35,35 @@ type App struct {
 	DB     *pgxpool.Pool
 	Config *config.Config
 	Router *chi.Mux
+
+	recurrenceWorker  *worker.RecurrenceWorker
+	keyRotationWorker *worker.KeyRotationWorker
+	keyRing           *keys.Ring
+	sealer            *keys.Sealer
+	schemaVersion     uint
+	rateLimitStore    ratelimit.Store
 }
 
 func New(cfg *config.Config) (*App, error) {
-	dbURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-		cfg.Database.SSLMode,
-	)
-
-	pool, err := pgxpool.New(context.Background(), dbURL)
+	poolConfig, err := pgxpool.ParseConfig(cfg.Database.URL())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse database config: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+	if cfg.Database.Pool.MaxConns > 0 {
+		poolConfig.MaxConns = cfg.Database.Pool.MaxConns
+	}
+	if cfg.Database.Pool.MinConns > 0 {
+		poolConfig.MinConns = cfg.Database.Pool.MinConns
+	}
+	if cfg.Database.Pool.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.Database.Pool.MaxConnLifetime
+	}
+	if cfg.Database.Pool.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.Database.Pool.MaxConnIdleTime
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
@@ -43,23 +73,121 @@ func New(cfg *config.Config) (*App, error) {
 		return nil, fmt.Errorf("unable to ping database: %w", err)
 	}
 
+	// The schema version this build expects, checked by the readiness probe
+	schemaVersion, err := migration.LatestVersion(cfg.Database.MigrationsPath)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
 	app := &App{
-		DB:     pool,
-		Config: cfg,
-		Router: chi.NewRouter(),
+		DB:            pool,
+		Config:        cfg,
+		Router:        chi.NewRouter(),
+		schemaVersion: schemaVersion,
 	}
 
+	if app.sealer, err = keys.NewSealer(cfg.JWT.KeyEncryptionKey); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	if err := app.configureJWT(); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	if err := app.encryptTOTPSecrets(); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	if cfg.RateLimit.Enabled {
+		if cfg.RateLimit.Store == "postgres" {
+			app.rateLimitStore = ratelimit.NewPostgresStore(pool)
+		} else {
+			app.rateLimitStore = ratelimit.NewMemoryStore()
+		}
+	}
+	metrics.RegisterPool(pool)
+
 	app.setupRoutes()
+	if cfg.Features.RecurrenceWorker {
+		app.recurrenceWorker.Start()
+	}
+	if app.keyRotationWorker != nil {
+		app.keyRotationWorker.Start()
+	}
 	return app, nil
 }
 
+// configureJWT sets up token signing. With RS256 or EdDSA the signing keys
+// are loaded, or the first one created, before serving, so tokens can be
+// issued from the first request.
+func (a *App) configureJWT() error {
+	cfg := a.Config.JWT
+	opts := utils.JWTOptions{
+		Expiration: cfg.Expiration,
+		Issuer:     cfg.Issuer,
+		Audience:   cfg.Audience,
+	}
+
+	if cfg.Algorithm == "HS256" {
+		opts.Keys = utils.NewHMACKeySet(cfg.Secret)
+		utils.ConfigureJWT(opts)
+		return nil
+	}
+
+	// Superseded keys are kept until the longest-lived token they signed has
+	// expired, plus a minute for clock differences between instances
+	retention := max(cfg.Expiration, a.Config.Auth.TwoFactor.ChallengeTTL) + time.Minute
+
+	a.keyRing = keys.NewRing(cfg.Algorithm)
+	signingKeyService := service.NewSigningKeyService(repository.NewSigningKeyRepository(a.DB), a.keyRing, a.sealer, service.KeyRotationPolicy{
+		Algorithm:  cfg.Algorithm,
+		Interval:   cfg.Rotation.Interval,
+		PrePublish: cfg.Rotation.PrePublish,
+		Retention:  retention,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := signingKeyService.RotateKeys(ctx); err != nil {
+		return fmt.Errorf("unable to load signing keys: %w", err)
+	}
+	a.keyRotationWorker = worker.NewKeyRotationWorker(signingKeyService, cfg.Rotation.RefreshInterval)
+
+	opts.Keys = a.keyRing
+	if cfg.AcceptLegacyHS256 {
+		opts.LegacySecret = cfg.Secret
+	}
+	utils.ConfigureJWT(opts)
+	return nil
+}
+
+// encryptTOTPSecrets encrypts TOTP secrets left in plain text by builds from
+// before they were sealed
+func (a *App) encryptTOTPSecrets() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	count, err := service.EncryptPlainTOTPSecrets(ctx, repository.NewTwoFactorRepository(a.DB), a.sealer)
+	if err != nil {
+		return fmt.Errorf("unable to encrypt two-factor secrets: %w", err)
+	}
+	if count > 0 {
+		slog.Info("encrypted two-factor secrets stored in plain text", "count", count)
+	}
+	return nil
+}
+
 func (a *App) setupRoutes() {
 	// Global middleware - order matters!
-	a.Router.Use(appMiddleware.ErrorMiddleware)   // Error handling and panic recovery
-	a.Router.Use(middleware.Logger)               // Chi's built-in logger
-	a.Router.Use(appMiddleware.LoggingMiddleware) // Custom request/response logging
-	a.Router.Use(middleware.Recoverer)            // Chi's built-in recoverer
-	a.Router.Use(corsMiddleware)                  // CORS support
+	a.Router.Use(tracing.Middleware)                                             // Server span per request, continuing W3C trace context
+	a.Router.Use(appMiddleware.RequestIDMiddleware)                              // Request ID in context and response headers
+	a.Router.Use(appMiddleware.ClientInfoMiddleware(a.Config.Server.TrustProxy)) // Client IP and user agent in context
+	a.Router.Use(metrics.Middleware)                                             // Request counts and latency per route
+	a.Router.Use(appMiddleware.LoggingMiddleware)                                // One structured log line per request
+	a.Router.Use(appMiddleware.ErrorMiddleware)                                  // Error handling and panic recovery
+	a.Router.Use(middleware.Recoverer)                                           // Chi's built-in recoverer
+	a.Router.Use(cors.New(a.Config.CORS).Handler)                                // CORS policy and preflight responses
 
 	// Health check (public endpoint)
 	a.Router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -68,90 +196,305 @@ func (a *App) setupRoutes() {
 		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 	})
 
+	// Prometheus metrics (optionally protected by METRICS_TOKEN)
+	if a.Config.Features.Metrics {
+		a.Router.Handle("/metrics", metrics.Handler(a.Config.Metrics.Token))
+	}
+
+	// Public keys for verifying our tokens (RS256 and EdDSA only)
+	if a.keyRing != nil {
+		a.Router.Get("/.well-known/jwks.json", handler.NewJWKSHandler(a.keyRing).JWKS)
+	}
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(a.DB)
 	projectRepo := repository.NewProjectRepository(a.DB)
 	taskRepo := repository.NewTaskRepository(a.DB)
 	commentRepo := repository.NewCommentRepository(a.DB)
+	recurrenceRepo := repository.NewRecurrenceRepository(a.DB)
+	templateRepo := repository.NewTemplateRepository(a.DB)
+	checklistRepo := repository.NewChecklistRepository(a.DB)
+	timeEntryRepo := repository.NewTimeEntryRepository(a.DB)
+	sprintRepo := repository.NewSprintRepository(a.DB)
+	wipLimitRepo := repository.NewWIPLimitRepository(a.DB)
+	reportRepo := repository.NewReportRepository(a.DB)
+	loginEventRepo := repository.NewLoginEventRepository(a.DB)
+	twoFactorRepo := repository.NewTwoFactorRepository(a.DB)
+	identityRepo := repository.NewIdentityRepository(a.DB)
+	accessTokenRepo := repository.NewAccessTokenRepository(a.DB)
+	auditEventRepo := repository.NewAuditEventRepository(a.DB)
+	emailChangeRepo := repository.NewEmailChangeRepository(a.DB)
 
 	// Initialize services
-	userService := service.NewUserService(userRepo)
-	projectService := service.NewProjectService(projectRepo)
-	taskService := service.NewTaskService(taskRepo)
+	lockout, twoFactor := a.Config.Auth.Lockout, a.Config.Auth.TwoFactor
+	loginPolicy := service.LoginPolicy{
+		MaxAttempts:      lockout.MaxAttempts,
+		LockDuration:     lockout.Duration,
+		MaxLockDuration:  lockout.MaxDuration,
+		IPMaxAttempts:    lockout.IPMaxAttempts,
+		IPWindow:         lockout.IPWindow,
+		RequireTwoFactor: twoFactor.Required,
+		ChallengeTTL:     twoFactor.ChallengeTTL,
+	}
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, userRepo, loginEventRepo, loginPolicy, a.sealer, twoFactor.Issuer)
+	userService := service.NewUserService(userRepo, loginEventRepo, twoFactorService, loginPolicy)
+	mailer := mail.NewSender(a.Config.Mail)
+	accountService := service.NewAccountService(userRepo, loginEventRepo, auditEventRepo, emailChangeRepo, mailer, loginPolicy, service.EmailChangePolicy{
+		ConfirmURL: a.Config.Auth.EmailChange.ConfirmURL,
+		TokenTTL:   a.Config.Auth.EmailChange.TokenTTL,
+	})
+	adminService := service.NewAdminService(userRepo, auditEventRepo, mailer)
+	accessTokenService := service.NewAccessTokenService(accessTokenRepo, service.AccessTokenPolicy{
+		MaxLifetime: a.Config.Auth.AccessTokens.MaxLifetime,
+		MaxPerUser:  a.Config.Auth.AccessTokens.MaxPerUser,
+	})
+	projectService := service.NewProjectService(projectRepo, templateRepo)
+	wipLimitService := service.NewWIPLimitService(wipLimitRepo, projectRepo)
+	recurrenceService := service.NewRecurrenceService(recurrenceRepo, taskRepo, wipLimitService)
+	taskService := service.NewTaskService(taskRepo, recurrenceService, wipLimitService)
 	commentService := service.NewCommentService(commentRepo)
+	templateService := service.NewTemplateService(templateRepo, projectRepo, taskRepo, checklistRepo, taskService)
+	checklistService := service.NewChecklistService(checklistRepo, taskRepo)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepo, taskRepo)
+	sprintService := service.NewSprintService(sprintRepo, projectRepo, taskRepo)
+	reportService := service.NewReportService(reportRepo, projectRepo)
+	oidcConfig := a.Config.Auth.OIDC
+	oidcService := service.NewOIDCService(oidc.NewProvider(oidcConfig), identityRepo, userRepo, loginEventRepo, loginPolicy, oidcConfig.AllowedDomains, oidcConfig.StateTTL)
+
+	// Initialize background workers
+	a.recurrenceWorker = worker.NewRecurrenceWorker(recurrenceService, a.Config.Worker.RecurrenceInterval)
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userService)
+	accountHandler := handler.NewAccountHandler(accountService)
+	adminHandler := handler.NewAdminHandler(adminService)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	accessTokenHandler := handler.NewAccessTokenHandler(accessTokenService)
+	oidcHandler := handler.NewOIDCHandler(oidcService, oidcConfig.FrontendURL, oidcConfig.RedirectURL, oidcConfig.StateTTL)
 	projectHandler := handler.NewProjectHandler(projectService)
 	taskHandler := handler.NewTaskHandler(taskService)
 	commentHandler := handler.NewCommentHandler(commentService)
+	recurrenceHandler := handler.NewRecurrenceHandler(recurrenceService)
+	templateHandler := handler.NewTemplateHandler(templateService)
+	checklistHandler := handler.NewChecklistHandler(checklistService)
+	timeEntryHandler := handler.NewTimeEntryHandler(timeEntryService)
+	sprintHandler := handler.NewSprintHandler(sprintService)
+	wipLimitHandler := handler.NewWIPLimitHandler(wipLimitService)
+	reportHandler := handler.NewReportHandler(reportService)
+	checks := []health.Check{
+		health.DatabaseCheck(a.DB),
+		health.MigrationCheck(a.DB, a.schemaVersion),
+	}
+	if a.Config.Features.RecurrenceWorker {
+		checks = append(checks, health.WorkerCheck("recurrence_worker", a.recurrenceWorker.Check))
+	}
+	if a.keyRotationWorker != nil {
+		checks = append(checks, health.WorkerCheck("key_rotation_worker", a.keyRotationWorker.Check))
+	}
+	healthHandler := handler.NewHealthHandler(health.NewChecker(checks...))
 
-	// Public auth routes (no authentication required)
-	a.Router.Post("/api/auth/signup", userHandler.SignUp)
-	a.Router.Post("/api/auth/login", userHandler.Login)
+	// Kubernetes probes (public endpoints)
+	a.Router.Get("/livez", healthHandler.Livez)
+	a.Router.Get("/readyz", healthHandler.Readyz)
 
-	// Protected routes (authentication required)
+	// Public auth routes (no authentication required, limited per client IP)
 	a.Router.Group(func(r chi.Router) {
-		r.Use(appMiddleware.AuthMiddleware)
+		r.Use(a.rateLimit("public", a.Config.RateLimit.Public, appMiddleware.ClientIPKey)...)
+
+		if a.Config.Features.Signup {
+			r.Post("/api/auth/signup", userHandler.SignUp)
+		}
+		r.Post("/api/auth/login", userHandler.Login)
+		r.Post("/api/auth/login/2fa", userHandler.VerifyTwoFactor)
+		r.Post("/api/auth/login/2fa/enroll", userHandler.StartTwoFactorEnrollment)
+		if oidcConfig.Enabled {
+			r.Get("/api/auth/oidc/login", oidcHandler.Login)
+			r.Get("/api/auth/oidc/callback", oidcHandler.Callback)
+		}
+	})
+
+	// Protected routes (authentication required, limited per user). Each
+	// group states what personal access tokens need to use it.
+	a.Router.Group(func(r chi.Router) {
+		r.Use(appMiddleware.AuthMiddleware(accessTokenService, userService))
+		r.Use(a.rateLimit("authenticated", a.Config.RateLimit.Authenticated, appMiddleware.UserKey)...)
+
+		// Account routes (session tokens only)
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.SessionOnly)
+
+			// User routes
+			r.Put("/api/auth/me", userHandler.UpdateProfile)
+			r.Get("/api/auth/me/logins", userHandler.ListLoginEvents)
+			r.Get("/api/auth/me/audit", accountHandler.ListAuditEvents)
+
+			// Password and email change routes
+			r.Post("/api/auth/password", accountHandler.ChangePassword)
+			r.Post("/api/auth/email", accountHandler.RequestEmailChange)
+			r.Post("/api/auth/email/confirm", accountHandler.ConfirmEmailChange)
+
+			// Two-factor authentication routes
+			r.Get("/api/auth/2fa", twoFactorHandler.GetStatus)
+			r.Post("/api/auth/2fa/enroll", twoFactorHandler.Enroll)
+			r.Post("/api/auth/2fa/confirm", twoFactorHandler.Confirm)
+			r.Post("/api/auth/2fa/disable", twoFactorHandler.Disable)
+			r.Post("/api/auth/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+
+			// Personal access token routes
+			r.Get("/api/auth/tokens", accessTokenHandler.ListTokens)
+			r.Post("/api/auth/tokens", accessTokenHandler.CreateToken)
+			r.Delete("/api/auth/tokens/{token_id}", accessTokenHandler.RevokeToken)
+		})
+
+		// Admin routes (session tokens of admins only)
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.SessionOnly)
+			r.Use(appMiddleware.RequireAdmin(adminService))
+
+			r.Get("/api/admin/users", adminHandler.ListUsers)
+			r.Get("/api/admin/users/{user_id}", adminHandler.GetUser)
+			r.Get("/api/admin/users/{user_id}/audit", adminHandler.ListAuditEvents)
+			r.Put("/api/admin/users/{user_id}/role", adminHandler.SetRole)
+			r.Put("/api/admin/users/{user_id}/two-factor", adminHandler.SetTwoFactorRequired)
+			r.Post("/api/admin/users/{user_id}/deactivate", adminHandler.DeactivateUser)
+			r.Post("/api/admin/users/{user_id}/reactivate", adminHandler.ReactivateUser)
+			r.Post("/api/admin/users/{user_id}/password", adminHandler.ResetPassword)
+			r.Post("/api/admin/users/{user_id}/transfer", adminHandler.TransferWork)
+		})
+
+		// Directory routes (read only)
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireScope(domain.ScopeRead))
 
-		// User routes
-		r.Get("/api/auth/me", userHandler.GetProfile)
-		r.Put("/api/auth/me", userHandler.UpdateProfile)
-		r.Get("/api/users", userHandler.ListUsers)
+			// User routes
+			r.Get("/api/auth/me", userHandler.GetProfile)
+			r.Get("/api/users", userHandler.ListUsers)
+		})
 
 		// Project routes
-		r.Post("/api/projects", projectHandler.CreateProject)
-		r.Get("/api/projects", projectHandler.ListProjects)
-		r.Get("/api/projects/{project_id}", projectHandler.GetProject)
-		r.Put("/api/projects/{project_id}", projectHandler.UpdateProject)
-		r.Delete("/api/projects/{project_id}", projectHandler.DeleteProject)
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireScope(domain.ScopeProjectsWrite))
+
+			// Project routes
+			r.Post("/api/projects", projectHandler.CreateProject)
+			r.Get("/api/projects", projectHandler.ListProjects)
+			r.Get("/api/projects/{project_id}", projectHandler.GetProject)
+			r.Put("/api/projects/{project_id}", projectHandler.UpdateProject)
+			r.Delete("/api/projects/{project_id}", projectHandler.DeleteProject)
+			r.Post("/api/projects/{project_id}/template", templateHandler.CreateProjectTemplateFromProject)
+
+			// WIP limit routes
+			r.Get("/api/projects/{project_id}/wip-limits", wipLimitHandler.GetLimits)
+			r.Put("/api/projects/{project_id}/wip-limits", wipLimitHandler.SetLimits)
+			r.Delete("/api/projects/{project_id}/wip-limits", wipLimitHandler.DeleteLimits)
+			r.Get("/api/projects/{project_id}/board/counts", wipLimitHandler.GetBoardCounts)
+
+			// Sprint routes
+			r.Post("/api/projects/{project_id}/sprints", sprintHandler.CreateSprint)
+			r.Get("/api/projects/{project_id}/sprints", sprintHandler.ListSprints)
+			r.Get("/api/sprints/{sprint_id}", sprintHandler.GetSprint)
+			r.Put("/api/sprints/{sprint_id}", sprintHandler.UpdateSprint)
+			r.Delete("/api/sprints/{sprint_id}", sprintHandler.DeleteSprint)
+			r.Post("/api/sprints/{sprint_id}/start", sprintHandler.StartSprint)
+			r.Post("/api/sprints/{sprint_id}/complete", sprintHandler.CompleteSprint)
+			r.Get("/api/sprints/{sprint_id}/burndown", sprintHandler.Burndown)
+
+			// Report routes
+			r.Get("/api/projects/{project_id}/stats", reportHandler.ProjectStats)
+			r.Get("/api/reports/workload", reportHandler.Workload)
+
+			// Template routes
+			r.Post("/api/templates/tasks", templateHandler.CreateTaskTemplate)
+			r.Get("/api/templates/tasks", templateHandler.ListTaskTemplates)
+			r.Get("/api/templates/tasks/{template_id}", templateHandler.GetTaskTemplate)
+			r.Delete("/api/templates/tasks/{template_id}", templateHandler.DeleteTaskTemplate)
+			r.Post("/api/templates/projects", templateHandler.CreateProjectTemplate)
+			r.Get("/api/templates/projects", templateHandler.ListProjectTemplates)
+			r.Get("/api/templates/projects/{template_id}", templateHandler.GetProjectTemplate)
+			r.Delete("/api/templates/projects/{template_id}", templateHandler.DeleteProjectTemplate)
+		})
 
 		// Task routes
-		r.Post("/api/projects/{project_id}/tasks", taskHandler.CreateTask)
-		r.Get("/api/projects/{project_id}/tasks", taskHandler.ListTasks)
-		r.Get("/api/tasks/assigned", taskHandler.ListAssignedTasks)
-		r.Get("/api/tasks/{task_id}", taskHandler.GetTask)
-		r.Put("/api/projects/{project_id}/tasks/{task_id}", taskHandler.UpdateTask)
-		r.Put("/api/tasks/{task_id}", taskHandler.UpdateTask)
-		r.Patch("/api/projects/{project_id}/tasks/{task_id}/status", taskHandler.UpdateTaskStatus)
-		r.Patch("/api/tasks/{task_id}/status", taskHandler.UpdateTaskStatus)
-		r.Patch("/api/tasks/{task_id}/priority", taskHandler.UpdateTaskPriority)
-		r.Patch("/api/tasks/{task_id}/assignee", taskHandler.UpdateTaskAssignee)
-		r.Post("/api/projects/{project_id}/tasks/{task_id}/assign", taskHandler.AssignTask)
-		r.Post("/api/tasks/{task_id}/assign", taskHandler.AssignTask)
-		r.Delete("/api/projects/{project_id}/tasks/{task_id}", taskHandler.DeleteTask)
-		r.Delete("/api/tasks/{task_id}", taskHandler.DeleteTask)
-
-		// Comment routes
-		r.Post("/api/projects/{project_id}/tasks/{task_id}/comments", commentHandler.CreateComment)
-		r.Post("/api/tasks/{task_id}/comments", commentHandler.CreateComment)
-		r.Get("/api/projects/{project_id}/tasks/{task_id}/comments", commentHandler.ListComments)
-		r.Get("/api/tasks/{task_id}/comments", commentHandler.ListComments)
-		r.Get("/api/comments/recent", commentHandler.ListRecentComments)
-		r.Put("/api/projects/{project_id}/tasks/{task_id}/comments/{comment_id}", commentHandler.UpdateComment)
-		r.Put("/api/comments/{comment_id}", commentHandler.UpdateComment)
-		r.Delete("/api/projects/{project_id}/tasks/{task_id}/comments/{comment_id}", commentHandler.DeleteComment)
-		r.Delete("/api/comments/{comment_id}", commentHandler.DeleteComment)
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.RequireScope(domain.ScopeTasksWrite))
+
+			// Task routes
+			r.Post("/api/projects/{project_id}/tasks", taskHandler.CreateTask)
+			r.Get("/api/projects/{project_id}/tasks", taskHandler.ListTasks)
+			r.Get("/api/tasks/assigned", taskHandler.ListAssignedTasks)
+			r.Get("/api/tasks/{task_id}", taskHandler.GetTask)
+			r.Put("/api/projects/{project_id}/tasks/{task_id}", taskHandler.UpdateTask)
+			r.Put("/api/tasks/{task_id}", taskHandler.UpdateTask)
+			r.Patch("/api/projects/{project_id}/tasks/{task_id}/status", taskHandler.UpdateTaskStatus)
+			r.Patch("/api/tasks/{task_id}/status", taskHandler.UpdateTaskStatus)
+			r.Patch("/api/tasks/{task_id}/priority", taskHandler.UpdateTaskPriority)
+			r.Patch("/api/tasks/{task_id}/assignee", taskHandler.UpdateTaskAssignee)
+			r.Patch("/api/tasks/{task_id}/estimate", taskHandler.UpdateTaskEstimate)
+			r.Post("/api/tasks/{task_id}/move", taskHandler.MoveTask)
+			r.Post("/api/projects/{project_id}/tasks/{task_id}/assign", taskHandler.AssignTask)
+			r.Post("/api/tasks/{task_id}/assign", taskHandler.AssignTask)
+			r.Delete("/api/projects/{project_id}/tasks/{task_id}", taskHandler.DeleteTask)
+			r.Delete("/api/tasks/{task_id}", taskHandler.DeleteTask)
+			r.Put("/api/tasks/{task_id}/sprint", sprintHandler.SetTaskSprint)
+			r.Post("/api/templates/tasks/{template_id}/instantiate", templateHandler.InstantiateTaskTemplate)
+
+			// Checklist routes
+			r.Get("/api/tasks/{task_id}/checklist", checklistHandler.ListItems)
+			r.Post("/api/tasks/{task_id}/checklist", checklistHandler.AddItem)
+			r.Put("/api/tasks/{task_id}/checklist/order", checklistHandler.ReorderItems)
+			r.Patch("/api/tasks/{task_id}/checklist/{item_id}", checklistHandler.UpdateItem)
+			r.Post("/api/tasks/{task_id}/checklist/{item_id}/toggle", checklistHandler.ToggleItem)
+			r.Delete("/api/tasks/{task_id}/checklist/{item_id}", checklistHandler.DeleteItem)
+
+			// Time tracking routes
+			r.Post("/api/tasks/{task_id}/time-entries", timeEntryHandler.LogTime)
+			r.Get("/api/tasks/{task_id}/time-entries", timeEntryHandler.ListTaskEntries)
+			r.Delete("/api/time-entries/{entry_id}", timeEntryHandler.DeleteEntry)
+			r.Post("/api/tasks/{task_id}/timer/start", timeEntryHandler.StartTimer)
+			r.Post("/api/timer/stop", timeEntryHandler.StopTimer)
+			r.Get("/api/timer", timeEntryHandler.GetRunningTimer)
+			r.Get("/api/reports/timesheet", timeEntryHandler.Timesheet)
+
+			// Recurrence routes
+			r.Get("/api/tasks/{task_id}/recurrence", recurrenceHandler.GetRecurrence)
+			r.Put("/api/tasks/{task_id}/recurrence", recurrenceHandler.SetRecurrence)
+			r.Delete("/api/tasks/{task_id}/recurrence", recurrenceHandler.DeleteRecurrence)
+			r.Get("/api/tasks/{task_id}/recurrence/preview", recurrenceHandler.PreviewRecurrence)
+
+			// Comment routes
+			r.Post("/api/projects/{project_id}/tasks/{task_id}/comments", commentHandler.CreateComment)
+			r.Post("/api/tasks/{task_id}/comments", commentHandler.CreateComment)
+			r.Get("/api/projects/{project_id}/tasks/{task_id}/comments", commentHandler.ListComments)
+			r.Get("/api/tasks/{task_id}/comments", commentHandler.ListComments)
+			r.Get("/api/comments/recent", commentHandler.ListRecentComments)
+			r.Put("/api/projects/{project_id}/tasks/{task_id}/comments/{comment_id}", commentHandler.UpdateComment)
+			r.Put("/api/comments/{comment_id}", commentHandler.UpdateComment)
+			r.Delete("/api/projects/{project_id}/tasks/{task_id}/comments/{comment_id}", commentHandler.DeleteComment)
+			r.Delete("/api/comments/{comment_id}", commentHandler.DeleteComment)
+		})
 	})
 }
 
+// rateLimit returns the middleware limiting a route group by rule, or none
+// when rate limiting is disabled
+func (a *App) rateLimit(group string, rule config.RateLimitRule, key appMiddleware.RateLimitKeyFunc) []func(http.Handler) http.Handler {
+	if a.rateLimitStore == nil {
+		return nil
+	}
+	limit := ratelimit.Limit{PerMinute: rule.RequestsPerMinute, Burst: rule.Burst}
+	return []func(http.Handler) http.Handler{
+		appMiddleware.RateLimitMiddleware(a.rateLimitStore, group, limit, key),
+	}
+}
+
+// Close stops the background workers and then closes the database pool, so
+// a worker pass in progress finishes before its connections go away. The
+// HTTP server must already have been shut down.
 func (a *App) Close() error {
+	a.recurrenceWorker.Stop()
+	if a.keyRotationWorker != nil {
+		a.keyRotationWorker.Stop()
+	}
 	a.DB.Close()
 	return nil
 }
-
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "3600")
-
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	})
-}
@@ -1,51 +1,483 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+// Configuration is layered: built-in defaults, then an optional YAML file
+// named by CONFIG_FILE, then environment variables. The result is validated
+// before the application starts.
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
+	// Environment is development or production. Production refuses to start
+	// with default secrets.
+	Environment string `yaml:"environment"`
+
+	Database  DatabaseConfig  `yaml:"database"`
+	Server    ServerConfig    `yaml:"server"`
+	JWT       JWTConfig       `yaml:"jwt"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Mail      MailConfig      `yaml:"mail"`
+	CORS      CORSConfig      `yaml:"cors"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Features  FeaturesConfig  `yaml:"features"`
+	Worker    WorkerConfig    `yaml:"worker"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Log       LogConfig       `yaml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing"`
 }
 
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"sslmode"`
+
+	// MigrationsPath is the directory holding the SQL migrations
+	MigrationsPath string `yaml:"migrations_path"`
+	// AutoMigrate applies pending migrations when the server starts
+	AutoMigrate bool `yaml:"auto_migrate"`
+
+	Pool PoolConfig `yaml:"pool"`
+}
+
+// URL returns the connection URL for the database
+func (c DatabaseConfig) URL() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", c.User, c.Password, c.Host, c.Port, c.Name, c.SSLMode)
+}
+
+// PoolConfig sizes the pgx connection pool; zero values keep pgx's defaults
+type PoolConfig struct {
+	MaxConns        int32         `yaml:"max_conns"`
+	MinConns        int32         `yaml:"min_conns"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time"`
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
+	Port string `yaml:"port"`
+	Host string `yaml:"host"`
+
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	// ShutdownTimeout bounds how long in-flight requests may drain on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	// TrustProxy takes the client IP from X-Forwarded-For; only enable it
+	// behind a proxy that sets the header
+	TrustProxy bool `yaml:"trust_proxy"`
+}
+
+type JWTConfig struct {
+	// Algorithm signs tokens: RS256 or EdDSA with rotating keys published at
+	// /.well-known/jwks.json, or HS256 with Secret
+	Algorithm string `yaml:"algorithm"`
+	Secret    string `yaml:"secret"`
+	// Expiration is how long issued access tokens stay valid
+	Expiration time.Duration `yaml:"expiration"`
+	// Issuer and Audience are set as the iss and aud claims and required
+	// when validating
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// AcceptLegacyHS256 keeps accepting tokens signed with Secret after
+	// switching to RS256 or EdDSA; turn it off once they have expired
+	AcceptLegacyHS256 bool              `yaml:"accept_legacy_hs256"`
+	Rotation          KeyRotationConfig `yaml:"rotation"`
+	// KeyEncryptionKey is the base64 encoded 32-byte AES key that RS256 and
+	// EdDSA private keys and TOTP secrets are encrypted with in the database
+	KeyEncryptionKey string `yaml:"key_encryption_key"`
+}
+
+// JWKSMaxAge is how long clients may cache /.well-known/jwks.json
+const JWKSMaxAge = 5 * time.Minute
+
+// UsesSecret reports whether tokens are signed or still accepted with Secret
+func (c JWTConfig) UsesSecret() bool {
+	return c.Algorithm == "HS256" || c.AcceptLegacyHS256
+}
+
+// KeyRotationConfig schedules signing key rotation for RS256 and EdDSA
+type KeyRotationConfig struct {
+	// Interval is how long each key signs new tokens
+	Interval time.Duration `yaml:"interval"`
+	// PrePublish is how long a new key is in the JWKS before it signs, so
+	// verifiers caching the JWKS know it by then
+	PrePublish time.Duration `yaml:"pre_publish"`
+	// RefreshInterval is how often each instance reloads the keys
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+}
+
+// AuthConfig holds sign-in protection settings
+type AuthConfig struct {
+	Lockout   LockoutConfig   `yaml:"lockout"`
+	TwoFactor TwoFactorConfig `yaml:"two_factor"`
+	OIDC      OIDCConfig      `yaml:"oidc"`
+	// AccessTokens limits the personal access tokens users create for scripts
+	AccessTokens AccessTokenConfig `yaml:"access_tokens"`
+	EmailChange  EmailChangeConfig `yaml:"email_change"`
+}
+
+// EmailChangeConfig controls confirmation of a user's new email address
+type EmailChangeConfig struct {
+	// ConfirmURL is the frontend page the confirmation link opens, with the
+	// token in the URL fragment
+	ConfirmURL string `yaml:"confirm_url"`
+	// TokenTTL is how long the confirmation link stays valid
+	TokenTTL time.Duration `yaml:"token_ttl"`
+}
+
+// MailConfig sets how emails such as confirmation links are sent
+type MailConfig struct {
+	// Driver is log, which only logs that a message was sent and is refused
+	// in production, or smtp
+	Driver string `yaml:"driver"`
+	// From is the sender, e.g. "Team Task Hub <no-reply@example.com>"
+	From string     `yaml:"from"`
+	SMTP SMTPConfig `yaml:"smtp"`
+}
+
+// SMTPConfig is the relay used by the smtp mail driver. STARTTLS is used
+// when the server offers it.
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// AccessTokenConfig limits personal access tokens
+type AccessTokenConfig struct {
+	// MaxLifetime is the longest expiry a token may have, and the expiry of
+	// tokens created without one; zero allows tokens that never expire
+	MaxLifetime time.Duration `yaml:"max_lifetime"`
+	MaxPerUser  int           `yaml:"max_per_user"`
+}
+
+// OIDCConfig enables single sign-on with an OpenID Connect identity provider
+type OIDCConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// IssuerURL is the provider's issuer; its discovery document is read
+	// from IssuerURL/.well-known/openid-configuration
+	IssuerURL    string   `yaml:"issuer_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Scopes       []string `yaml:"scopes"`
+	// RedirectURL is this API's callback, registered with the provider,
+	// e.g. https://api.example.com/api/auth/oidc/callback
+	RedirectURL string `yaml:"redirect_url"`
+	// FrontendURL receives the outcome of a sign-in, with the access token
+	// or an error code in the URL fragment
+	FrontendURL string `yaml:"frontend_url"`
+	// AllowedDomains restricts sign-in to these email domains; empty allows any
+	AllowedDomains []string `yaml:"allowed_domains"`
+	// StateTTL is how long the user has to complete the provider's sign-in
+	StateTTL time.Duration `yaml:"state_ttl"`
+}
+
+// TwoFactorConfig controls TOTP two-factor authentication
+type TwoFactorConfig struct {
+	// Required makes every user enroll before they can sign in, and stops
+	// them turning two-factor off
+	Required bool `yaml:"required"`
+	// Issuer names the account in authenticator apps
+	Issuer string `yaml:"issuer"`
+	// ChallengeTTL is how long a user has to enter their code after their password
+	ChallengeTTL time.Duration `yaml:"challenge_ttl"`
+}
+
+// LockoutConfig throttles password guessing against accounts and from IPs
+type LockoutConfig struct {
+	// MaxAttempts consecutive failed logins lock an account for Duration;
+	// every further MaxAttempts failures double it, up to MaxDuration
+	MaxAttempts int           `yaml:"max_attempts"`
+	Duration    time.Duration `yaml:"duration"`
+	MaxDuration time.Duration `yaml:"max_duration"`
+	// IPMaxAttempts failed logins from one IP within IPWindow block further
+	// attempts from it until older failures age out
+	IPMaxAttempts int           `yaml:"ip_max_attempts"`
+	IPWindow      time.Duration `yaml:"ip_window"`
 }
 
-func New() *Config {
+// CORSConfig is the default cross-origin policy plus per-route overrides
+type CORSConfig struct {
+	CORSPolicy `yaml:",inline"`
+
+	// Routes override parts of the default policy for matching paths; the
+	// longest matching prefix wins
+	Routes []CORSRoute `yaml:"routes"`
+}
+
+type CORSPolicy struct {
+	// AllowedOrigins are exact origins (https://app.example.com), subdomain
+	// patterns (https://*.example.com) or "*" for any origin
+	AllowedOrigins   []string `yaml:"allowed_origins"`
+	AllowedMethods   []string `yaml:"allowed_methods"`
+	AllowedHeaders   []string `yaml:"allowed_headers"`
+	ExposedHeaders   []string `yaml:"exposed_headers"`
+	AllowCredentials bool     `yaml:"allow_credentials"`
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
+// CORSRoute overrides the default policy under PathPrefix. Empty lists, a
+// nil AllowCredentials and a zero MaxAge inherit the default.
+type CORSRoute struct {
+	PathPrefix       string        `yaml:"path_prefix"`
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers"`
+	AllowCredentials *bool         `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
+}
+
+// Policy returns the route's effective policy on top of base
+func (r CORSRoute) Policy(base CORSPolicy) CORSPolicy {
+	policy := base
+	if len(r.AllowedOrigins) > 0 {
+		policy.AllowedOrigins = r.AllowedOrigins
+	}
+	if len(r.AllowedMethods) > 0 {
+		policy.AllowedMethods = r.AllowedMethods
+	}
+	if len(r.AllowedHeaders) > 0 {
+		policy.AllowedHeaders = r.AllowedHeaders
+	}
+	if len(r.ExposedHeaders) > 0 {
+		policy.ExposedHeaders = r.ExposedHeaders
+	}
+	if r.AllowCredentials != nil {
+		policy.AllowCredentials = *r.AllowCredentials
+	}
+	if r.MaxAge > 0 {
+		policy.MaxAge = r.MaxAge
+	}
+	return policy
+}
+
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Store is memory (per process) or postgres (shared across replicas)
+	Store string `yaml:"store"`
+
+	// Public limits unauthenticated routes (signup, login) per client IP
+	Public RateLimitRule `yaml:"public"`
+	// Authenticated limits protected routes per user
+	Authenticated RateLimitRule `yaml:"authenticated"`
+}
+
+// RateLimitRule is a token bucket: Burst requests at once, refilled at
+// RequestsPerMinute
+type RateLimitRule struct {
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+	Burst             int `yaml:"burst"`
+}
+
+// FeaturesConfig switches optional parts of the application on or off
+type FeaturesConfig struct {
+	Signup           bool `yaml:"signup"`
+	RecurrenceWorker bool `yaml:"recurrence_worker"`
+	Metrics          bool `yaml:"metrics"`
+}
+
+type WorkerConfig struct {
+	RecurrenceInterval time.Duration `yaml:"recurrence_interval"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error
+	Level string `yaml:"level"`
+}
+
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the OTLP/HTTP collector base URL, e.g. http://collector:4318;
+	// empty uses the OTLP default
+	Endpoint    string `yaml:"endpoint"`
+	ServiceName string `yaml:"service_name"`
+	// SampleRatio is the fraction of new traces that are recorded
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+type MetricsConfig struct {
+	// Token, when set, must be sent as a bearer token to read /metrics
+	Token string `yaml:"token"`
+}
+
+// Development defaults for secrets. They let the app run locally out of the
+// box and are rejected in production.
+const (
+	defaultDBPassword = "password"
+	defaultJWTSecret  = "your-secret-key-change-in-production"
+	// defaultKeyEncryptionKey is base64 for "dev-key-encryption-key-change-me"
+	defaultKeyEncryptionKey = "ZGV2LWtleS1lbmNyeXB0aW9uLWtleS1jaGFuZ2UtbWU="
+)
+
+// Default returns the built-in configuration
+func Default() *Config {
 	return &Config{
+		Environment: "development",
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "password"),
-			Name:     getEnv("DB_NAME", "team_task_hub"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:     "localhost",
+			Port:     "5432",
+			User:     "postgres",
+			Password: defaultDBPassword,
+			Name:     "team_task_hub",
+			SSLMode:  "disable",
+
+			MigrationsPath: "migrations",
+			AutoMigrate:    true,
 		},
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
+			Port: "8080",
+			Host: "0.0.0.0",
+
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		JWT: JWTConfig{
+			Algorithm:  "RS256",
+			Secret:     defaultJWTSecret,
+			Expiration: 24 * time.Hour,
+			Issuer:     "team-task-hub",
+			Audience:   "team-task-hub",
+			Rotation: KeyRotationConfig{
+				Interval:        30 * 24 * time.Hour,
+				PrePublish:      time.Hour,
+				RefreshInterval: time.Minute,
+			},
+			KeyEncryptionKey: defaultKeyEncryptionKey,
+		},
+		Auth: AuthConfig{
+			Lockout: LockoutConfig{
+				MaxAttempts:   5,
+				Duration:      time.Minute,
+				MaxDuration:   time.Hour,
+				IPMaxAttempts: 20,
+				IPWindow:      15 * time.Minute,
+			},
+			TwoFactor: TwoFactorConfig{
+				Required:     false,
+				Issuer:       "Team Task Hub",
+				ChallengeTTL: 5 * time.Minute,
+			},
+			AccessTokens: AccessTokenConfig{
+				MaxLifetime: 365 * 24 * time.Hour,
+				MaxPerUser:  50,
+			},
+			OIDC: OIDCConfig{
+				Enabled:     false,
+				Scopes:      []string{"openid", "email", "profile"},
+				RedirectURL: "http://localhost:8080/api/auth/oidc/callback",
+				FrontendURL: "http://localhost:3000/auth/callback",
+				StateTTL:    10 * time.Minute,
+			},
+			EmailChange: EmailChangeConfig{
+				ConfirmURL: "http://localhost:3000/confirm-email",
+				TokenTTL:   24 * time.Hour,
+			},
+		},
+		Mail: MailConfig{
+			Driver: "log",
+			From:   "Team Task Hub <no-reply@localhost>",
+			SMTP: SMTPConfig{
+				Port: "587",
+			},
+		},
+		CORS: CORSConfig{
+			CORSPolicy: CORSPolicy{
+				AllowedOrigins:   []string{"http://localhost:3000"},
+				AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+				AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Request-ID", "traceparent", "tracestate"},
+				ExposedHeaders:   []string{"ETag", "X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"},
+				AllowCredentials: true,
+				MaxAge:           time.Hour,
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Store:   "memory",
+			Public: RateLimitRule{
+				RequestsPerMinute: 10,
+				Burst:             5,
+			},
+			Authenticated: RateLimitRule{
+				RequestsPerMinute: 300,
+				Burst:             60,
+			},
+		},
+		Features: FeaturesConfig{
+			Signup:           true,
+			RecurrenceWorker: true,
+			Metrics:          true,
+		},
+		Worker: WorkerConfig{
+			RecurrenceInterval: time.Minute,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "team-task-hub",
+			SampleRatio: 1,
 		},
 	}
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// Load builds the configuration from defaults, the YAML file named by
+// CONFIG_FILE (if any) and environment variables, and validates it
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
 	}
-	return defaultValue
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
+// IsProduction reports whether the app runs in production mode
+func (c *Config) IsProduction() bool {
+	return c.Environment == "production"
+}
 
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
 
+	// Unknown keys are rejected so typos don't silently fall back to defaults
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// applyEnv overrides the configuration with any environment variables that
+// are set. Values that don't parse are reported rather than ignored.
+func (c *Config) applyEnv() error {
+	env := &envReader{}
+
+	env.str("APP_ENV", &c.Environment)
+
+	env.str("DB_HOST", &c.Database.Host)
+	env.str("DB_PORT", &c.Database.Port)
+	env.str("DB_USER", &c.Database.User)
+	env.str("DB_PASSWORD", &c.Database.Password)
+	env.str("DB_NAME", &c.Database.Name)
+	env.str("DB_SSLMODE", &c.Database.SSLMode)
+	env.str("MIGRATIONS_PATH", &c.Database.MigrationsPath)
+	env.boolean("DB_AUTO_MIGRATE", &c.Database.AutoMigrate)
+	env.int32("DB_POOL_MAX_CONNS", &c.Database.Pool.MaxConns)
+	env.int32("DB_POOL_MIN_CONNS", &c.Database.Pool.MinConns)
+	env.duration("DB_POOL_MAX_CONN_LIFETIME", &c.Database.Pool.MaxConnLifetime)
+	env.duration("DB_POOL_MAX_CONN_IDLE_TIME", &c.Database.Pool.MaxConnIdleTime)
+
+	env.str("SERVER_PORT", &c.Server.Port)
+	env.str("SERVER_HOST", &c.Server.Host)
+	env.duration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	env.duration("SERVER_READ_HEADER_TIMEOUT", &c.Server.ReadHeaderTimeout)
+	env.duration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	env.duration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	env.duration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	env.boolean("SERVER_TRUST_PROXY", &c.Server.TrustProxy)
+
+	env.str("JWT_ALGORITHM", &c.JWT.Algorithm)
+	env.str("JWT_SECRET", &c.JWT.Secret)
+	env.duration("JWT_EXPIRATION", &c.JWT.Expiration)
+	env.str("JWT_ISSUER", &c.JWT.Issuer)
+	env.str("JWT_AUDIENCE", &c.JWT.Audience)
+	env.boolean("JWT_ACCEPT_LEGACY_HS256", &c.JWT.AcceptLegacyHS256)
+	env.duration("JWT_ROTATION_INTERVAL", &c.JWT.Rotation.Interval)
+	env.duration("JWT_ROTATION_PRE_PUBLISH", &c.JWT.Rotation.PrePublish)
+	env.duration("JWT_KEY_REFRESH_INTERVAL", &c.JWT.Rotation.RefreshInterval)
+	env.str("JWT_KEY_ENCRYPTION_KEY", &c.JWT.KeyEncryptionKey)
+
+	env.integer("LOCKOUT_MAX_ATTEMPTS", &c.Auth.Lockout.MaxAttempts)
+	env.duration("LOCKOUT_DURATION", &c.Auth.Lockout.Duration)
+	env.duration("LOCKOUT_MAX_DURATION", &c.Auth.Lockout.MaxDuration)
+	env.integer("LOCKOUT_IP_MAX_ATTEMPTS", &c.Auth.Lockout.IPMaxAttempts)
+	env.duration("LOCKOUT_IP_WINDOW", &c.Auth.Lockout.IPWindow)
+	env.boolean("TWO_FACTOR_REQUIRED", &c.Auth.TwoFactor.Required)
+	env.str("TWO_FACTOR_ISSUER", &c.Auth.TwoFactor.Issuer)
+	env.duration("TWO_FACTOR_CHALLENGE_TTL", &c.Auth.TwoFactor.ChallengeTTL)
+
+	env.duration("ACCESS_TOKEN_MAX_LIFETIME", &c.Auth.AccessTokens.MaxLifetime)
+	env.integer("ACCESS_TOKEN_MAX_PER_USER", &c.Auth.AccessTokens.MaxPerUser)
+
+	env.boolean("OIDC_ENABLED", &c.Auth.OIDC.Enabled)
+	env.str("OIDC_ISSUER_URL", &c.Auth.OIDC.IssuerURL)
+	env.str("OIDC_CLIENT_ID", &c.Auth.OIDC.ClientID)
+	env.str("OIDC_CLIENT_SECRET", &c.Auth.OIDC.ClientSecret)
+	env.list("OIDC_SCOPES", &c.Auth.OIDC.Scopes)
+	env.str("OIDC_REDIRECT_URL", &c.Auth.OIDC.RedirectURL)
+	env.str("OIDC_FRONTEND_URL", &c.Auth.OIDC.FrontendURL)
+	env.list("OIDC_ALLOWED_DOMAINS", &c.Auth.OIDC.AllowedDomains)
+	env.duration("OIDC_STATE_TTL", &c.Auth.OIDC.StateTTL)
+
+	env.str("EMAIL_CHANGE_CONFIRM_URL", &c.Auth.EmailChange.ConfirmURL)
+	env.duration("EMAIL_CHANGE_TOKEN_TTL", &c.Auth.EmailChange.TokenTTL)
+
+	env.str("MAIL_DRIVER", &c.Mail.Driver)
+	env.str("MAIL_FROM", &c.Mail.From)
+	env.str("SMTP_HOST", &c.Mail.SMTP.Host)
+	env.str("SMTP_PORT", &c.Mail.SMTP.Port)
+	env.str("SMTP_USERNAME", &c.Mail.SMTP.Username)
+	env.str("SMTP_PASSWORD", &c.Mail.SMTP.Password)
+
+	env.list("CORS_ALLOWED_ORIGINS", &c.CORS.AllowedOrigins)
+	env.list("CORS_ALLOWED_METHODS", &c.CORS.AllowedMethods)
+	env.list("CORS_ALLOWED_HEADERS", &c.CORS.AllowedHeaders)
+	env.list("CORS_EXPOSED_HEADERS", &c.CORS.ExposedHeaders)
+	env.boolean("CORS_ALLOW_CREDENTIALS", &c.CORS.AllowCredentials)
+	env.duration("CORS_MAX_AGE", &c.CORS.MaxAge)
+
+	env.boolean("RATE_LIMIT_ENABLED", &c.RateLimit.Enabled)
+	env.str("RATE_LIMIT_STORE", &c.RateLimit.Store)
+	env.integer("RATE_LIMIT_PUBLIC_REQUESTS_PER_MINUTE", &c.RateLimit.Public.RequestsPerMinute)
+	env.integer("RATE_LIMIT_PUBLIC_BURST", &c.RateLimit.Public.Burst)
+	env.integer("RATE_LIMIT_USER_REQUESTS_PER_MINUTE", &c.RateLimit.Authenticated.RequestsPerMinute)
+	env.integer("RATE_LIMIT_USER_BURST", &c.RateLimit.Authenticated.Burst)
+
+	env.boolean("FEATURE_SIGNUP", &c.Features.Signup)
+	env.boolean("FEATURE_RECURRENCE_WORKER", &c.Features.RecurrenceWorker)
+	env.boolean("FEATURE_METRICS", &c.Features.Metrics)
+
+	env.duration("RECURRENCE_INTERVAL", &c.Worker.RecurrenceInterval)
+
+	env.str("METRICS_TOKEN", &c.Metrics.Token)
+
+	env.str("LOG_LEVEL", &c.Log.Level)
+
+	env.str("OTEL_TRACES_EXPORTER", &c.Tracing.Exporter)
+	env.str("OTEL_EXPORTER_OTLP_ENDPOINT", &c.Tracing.Endpoint)
+	env.str("OTEL_SERVICE_NAME", &c.Tracing.ServiceName)
+	env.float("OTEL_TRACES_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+
+	return errors.Join(env.errs...)
+}
+
+// envReader copies set environment variables into config fields, collecting parse errors
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) lookup(key string) (string, bool) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+func (e *envReader) fail(key, value, kind string) {
+	e.errs = append(e.errs, fmt.Errorf("%s=%q is not a valid %s", key, value, kind))
+}
+
+func (e *envReader) str(key string, dst *string) {
+	if value, ok := e.lookup(key); ok {
+		*dst = value
+	}
+}
+
+func (e *envReader) list(key string, dst *[]string) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
+
+func (e *envReader) boolean(key string, dst *bool) {
+	if value, ok := e.lookup(key); ok {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			e.fail(key, value, "boolean")
+			return
+		}
+		*dst = b
+	}
+}
+
+func (e *envReader) integer(key string, dst *int) {
+	if value, ok := e.lookup(key); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			e.fail(key, value, "integer")
+			return
+		}
+		*dst = n
+	}
+}
+
+func (e *envReader) int32(key string, dst *int32) {
+	if value, ok := e.lookup(key); ok {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			e.fail(key, value, "integer")
+			return
+		}
+		*dst = int32(n)
+	}
+}
+
+func (e *envReader) float(key string, dst *float64) {
+	if value, ok := e.lookup(key); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			e.fail(key, value, "number")
+			return
+		}
+		*dst = f
+	}
+}
+
+func (e *envReader) duration(key string, dst *time.Duration) {
+	if value, ok := e.lookup(key); ok {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			e.fail(key, value, "duration (e.g. 30s, 5m)")
+			return
+		}
+		*dst = d
+	}
+}
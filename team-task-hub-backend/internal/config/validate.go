@@ -0,0 +1,205 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minProductionSecretLength is the shortest JWT secret accepted in production
+const minProductionSecretLength = 32
+
+// Validate checks the configuration and reports every problem at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Environment == "development" || c.Environment == "production",
+		"environment must be development or production, got %q", c.Environment)
+
+	check(c.Database.Host != "", "database.host is required")
+	check(validPort(c.Database.Port), "database.port must be a port number, got %q", c.Database.Port)
+	check(c.Database.User != "", "database.user is required")
+	check(c.Database.Name != "", "database.name is required")
+	check(c.Database.MigrationsPath != "", "database.migrations_path is required")
+	check(c.Database.Pool.MaxConns >= 0 && c.Database.Pool.MinConns >= 0, "database.pool connection counts cannot be negative")
+	check(c.Database.Pool.MaxConns == 0 || c.Database.Pool.MinConns <= c.Database.Pool.MaxConns,
+		"database.pool.min_conns (%d) cannot exceed max_conns (%d)", c.Database.Pool.MinConns, c.Database.Pool.MaxConns)
+
+	check(validPort(c.Server.Port), "server.port must be a port number, got %q", c.Server.Port)
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"jwt.expiration", c.JWT.Expiration},
+		{"auth.two_factor.challenge_ttl", c.Auth.TwoFactor.ChallengeTTL},
+		{"worker.recurrence_interval", c.Worker.RecurrenceInterval},
+	} {
+		check(d.value > 0, "%s must be positive", d.name)
+	}
+
+	check(c.JWT.Issuer != "", "jwt.issuer is required")
+	check(c.JWT.Audience != "", "jwt.audience is required")
+	switch c.JWT.Algorithm {
+	case "HS256":
+		check(c.JWT.Secret != "", "jwt.secret is required")
+	case "RS256", "EdDSA":
+		check(!c.JWT.AcceptLegacyHS256 || c.JWT.Secret != "", "jwt.secret is required with jwt.accept_legacy_hs256")
+		rotation := c.JWT.Rotation
+		check(rotation.Interval > 0, "jwt.rotation.interval must be positive")
+		check(rotation.RefreshInterval > 0, "jwt.rotation.refresh_interval must be positive")
+		check(rotation.PrePublish >= rotation.RefreshInterval+JWKSMaxAge,
+			"jwt.rotation.pre_publish must be at least jwt.rotation.refresh_interval plus %s, how long verifiers may cache the JWKS", JWKSMaxAge)
+		check(rotation.PrePublish < rotation.Interval, "jwt.rotation.pre_publish must be shorter than jwt.rotation.interval")
+	default:
+		check(false, "jwt.algorithm must be RS256, EdDSA or HS256, got %q", c.JWT.Algorithm)
+	}
+	kek, err := base64.StdEncoding.DecodeString(c.JWT.KeyEncryptionKey)
+	check(err == nil && len(kek) == 32, "jwt.key_encryption_key must be 32 bytes, base64 encoded (try `openssl rand -base64 32`)")
+
+	lockout := c.Auth.Lockout
+	check(lockout.MaxAttempts > 0, "auth.lockout.max_attempts must be positive")
+	check(lockout.Duration > 0, "auth.lockout.duration must be positive")
+	check(lockout.MaxDuration >= lockout.Duration, "auth.lockout.max_duration cannot be shorter than auth.lockout.duration")
+	check(lockout.IPMaxAttempts >= 0, "auth.lockout.ip_max_attempts cannot be negative")
+	check(lockout.IPMaxAttempts == 0 || lockout.IPWindow > 0, "auth.lockout.ip_window must be positive")
+	check(c.Auth.TwoFactor.Issuer != "", "auth.two_factor.issuer is required")
+	check(c.Auth.AccessTokens.MaxLifetime >= 0, "auth.access_tokens.max_lifetime cannot be negative")
+	check(c.Auth.AccessTokens.MaxPerUser > 0, "auth.access_tokens.max_per_user must be positive")
+
+	if oidc := c.Auth.OIDC; oidc.Enabled {
+		check(validURL(oidc.IssuerURL), "auth.oidc.issuer_url must be an http(s) URL")
+		check(oidc.ClientID != "", "auth.oidc.client_id is required")
+		check(validURL(oidc.RedirectURL), "auth.oidc.redirect_url must be an http(s) URL")
+		check(validURL(oidc.FrontendURL), "auth.oidc.frontend_url must be an http(s) URL")
+		check(slices.Contains(oidc.Scopes, "openid"), "auth.oidc.scopes must include openid")
+		check(oidc.StateTTL > 0, "auth.oidc.state_ttl must be positive")
+		for _, domain := range oidc.AllowedDomains {
+			check(domain != "" && !strings.ContainsAny(domain, "@/ "), "auth.oidc.allowed_domains entry %q must be a bare domain", domain)
+		}
+	}
+
+	check(validURL(c.Auth.EmailChange.ConfirmURL), "auth.email_change.confirm_url must be an http(s) URL")
+	check(c.Auth.EmailChange.TokenTTL > 0, "auth.email_change.token_ttl must be positive")
+
+	_, err = mail.ParseAddress(c.Mail.From)
+	check(err == nil, "mail.from must be an email address, got %q", c.Mail.From)
+	switch c.Mail.Driver {
+	case "log":
+	case "smtp":
+		check(c.Mail.SMTP.Host != "", "mail.smtp.host is required")
+		check(validPort(c.Mail.SMTP.Port), "mail.smtp.port must be a port number, got %q", c.Mail.SMTP.Port)
+	default:
+		check(false, "mail.driver must be log or smtp, got %q", c.Mail.Driver)
+	}
+
+	errs = append(errs, validateCORSPolicy("cors", c.CORS.CORSPolicy)...)
+	for i, route := range c.CORS.Routes {
+		name := fmt.Sprintf("cors.routes[%d]", i)
+		check(strings.HasPrefix(route.PathPrefix, "/"), "%s.path_prefix must start with /", name)
+		errs = append(errs, validateCORSPolicy(name, route.Policy(c.CORS.CORSPolicy))...)
+	}
+
+	if c.RateLimit.Enabled {
+		check(c.RateLimit.Store == "memory" || c.RateLimit.Store == "postgres",
+			"rate_limit.store must be memory or postgres, got %q", c.RateLimit.Store)
+		for _, rule := range []struct {
+			name string
+			rule RateLimitRule
+		}{
+			{"rate_limit.public", c.RateLimit.Public},
+			{"rate_limit.authenticated", c.RateLimit.Authenticated},
+		} {
+			check(rule.rule.RequestsPerMinute > 0, "%s.requests_per_minute must be positive", rule.name)
+			check(rule.rule.Burst > 0, "%s.burst must be positive", rule.name)
+		}
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		check(false, "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+	}
+
+	switch strings.ToLower(c.Tracing.Exporter) {
+	case "", "none", "stdout", "otlp":
+	default:
+		check(false, "tracing.exporter must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	if c.IsProduction() {
+		if c.JWT.UsesSecret() {
+			check(c.JWT.Secret != defaultJWTSecret, "jwt.secret must be changed from the default in production")
+			check(len(c.JWT.Secret) >= minProductionSecretLength, "jwt.secret must be at least %d characters in production", minProductionSecretLength)
+		}
+		check(c.JWT.KeyEncryptionKey != defaultKeyEncryptionKey, "jwt.key_encryption_key must be set in production")
+		check(c.Database.Password != defaultDBPassword, "database.password must be changed from the default in production")
+		check(c.Mail.Driver != "log", "mail.driver must be smtp in production")
+		for _, origin := range c.CORS.AllowedOrigins {
+			check(origin != "*", "cors.allowed_origins cannot be \"*\" in production")
+		}
+		for i, route := range c.CORS.Routes {
+			for _, origin := range route.AllowedOrigins {
+				check(origin != "*", "cors.routes[%d].allowed_origins cannot be \"*\" in production", i)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n < 65536
+}
+
+func validateCORSPolicy(name string, p CORSPolicy) []error {
+	var errs []error
+	for _, origin := range p.AllowedOrigins {
+		if !validOrigin(origin) {
+			errs = append(errs, fmt.Errorf("%s.allowed_origins entry %q must be \"*\", a scheme://host[:port] origin or a scheme://*.domain pattern", name, origin))
+		}
+		if origin == "*" && p.AllowCredentials {
+			errs = append(errs, fmt.Errorf("%s.allowed_origins cannot be \"*\" when allow_credentials is set", name))
+		}
+	}
+	if p.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("%s.max_age cannot be negative", name))
+	}
+	return errs
+}
+
+func validURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validOrigin accepts "*", an origin, or an origin whose host starts with a
+// "*." subdomain wildcard
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Path == "" &&
+		u.RawQuery == "" && u.Fragment == "" && u.User == nil && !strings.Contains(u.Host, "*")
+}
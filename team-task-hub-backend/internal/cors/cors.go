@@ -0,0 +1,183 @@
+package cors
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/launchventures/team-task-hub-backend/internal/config"
+)
+
+// Middleware applies the configured cross-origin policy. Preflight requests
+// are answered here and never reach the router.
+type Middleware struct {
+	base   *policy
+	routes []route
+}
+
+type route struct {
+	prefix string
+	policy *policy
+}
+
+// policy is a config.CORSPolicy prepared for matching requests
+type policy struct {
+	anyOrigin        bool
+	origins          map[string]bool
+	patterns         []originPattern
+	methods          map[string]bool
+	allowMethods     string
+	allowHeaders     string
+	exposeHeaders    string
+	allowCredentials bool
+	maxAge           string
+}
+
+// originPattern matches scheme://*.domain: any subdomain of domain, but not
+// domain itself
+type originPattern struct {
+	scheme string
+	suffix string
+}
+
+// New prepares the default policy and route overrides from cfg
+func New(cfg config.CORSConfig) *Middleware {
+	m := &Middleware{base: newPolicy(cfg.CORSPolicy)}
+	for _, r := range cfg.Routes {
+		m.routes = append(m.routes, route{
+			prefix: strings.TrimSuffix(r.PathPrefix, "/"),
+			policy: newPolicy(r.Policy(cfg.CORSPolicy)),
+		})
+	}
+
+	// Longest prefix first, so the most specific override wins
+	sort.SliceStable(m.routes, func(i, j int) bool {
+		return len(m.routes[i].prefix) > len(m.routes[j].prefix)
+	})
+	return m
+}
+
+func newPolicy(cfg config.CORSPolicy) *policy {
+	p := &policy{
+		origins:          make(map[string]bool),
+		methods:          make(map[string]bool),
+		allowMethods:     strings.Join(cfg.AllowedMethods, ", "),
+		allowHeaders:     strings.Join(cfg.AllowedHeaders, ", "),
+		exposeHeaders:    strings.Join(cfg.ExposedHeaders, ", "),
+		allowCredentials: cfg.AllowCredentials,
+	}
+	if cfg.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	}
+
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.ToLower(origin)
+		switch {
+		case origin == "*":
+			p.anyOrigin = true
+		case strings.Contains(origin, "://*."):
+			scheme, host, _ := strings.Cut(origin, "://*.")
+			p.patterns = append(p.patterns, originPattern{scheme: scheme + "://", suffix: "." + host})
+		default:
+			p.origins[origin] = true
+		}
+	}
+
+	for _, method := range cfg.AllowedMethods {
+		p.methods[strings.ToUpper(method)] = true
+	}
+	return p
+}
+
+// allowsOrigin reports whether origin may make cross-origin requests
+func (p *policy) allowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	if p.anyOrigin || p.origins[origin] {
+		return true
+	}
+	for _, pattern := range p.patterns {
+		if host, ok := strings.CutPrefix(origin, pattern.scheme); ok &&
+			strings.HasSuffix(host, pattern.suffix) && len(host) > len(pattern.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsMethod reports whether a preflight for method may succeed. Simple
+// methods are always allowed by browsers.
+func (p *policy) allowsMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodPost || p.methods[method]
+}
+
+// policyFor returns the policy that governs path
+func (m *Middleware) policyFor(path string) *policy {
+	for _, r := range m.routes {
+		if path == r.prefix || strings.HasPrefix(path, r.prefix+"/") {
+			return r.policy
+		}
+	}
+	return m.base
+}
+
+// Handler wraps next with the CORS policy
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		// Responses depend on the Origin header whether or not it is allowed,
+		// so shared caches must key on it
+		w.Header().Add("Vary", "Origin")
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		p := m.policyFor(r.URL.Path)
+		allowed := p.allowsOrigin(origin)
+
+		if preflight {
+			// A refused preflight gets no CORS headers, which the browser
+			// reports as a failed request
+			if allowed && p.allowsMethod(r.Header.Get("Access-Control-Request-Method")) {
+				p.writeOrigin(w, origin)
+				w.Header().Set("Access-Control-Allow-Methods", p.allowMethods)
+				if p.allowHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", p.allowHeaders)
+				}
+				if p.maxAge != "" {
+					w.Header().Set("Access-Control-Max-Age", p.maxAge)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if allowed {
+			p.writeOrigin(w, origin)
+			if p.exposeHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", p.exposeHeaders)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (p *policy) writeOrigin(w http.ResponseWriter, origin string) {
+	if p.anyOrigin && !p.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
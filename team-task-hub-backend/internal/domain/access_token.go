@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Personal access token scopes
+const (
+	// ScopeRead allows every read (GET) request a token may make
+	ScopeRead = "read"
+	// ScopeTasksWrite allows changing tasks, their checklists, comments,
+	// time entries and recurrences
+	ScopeTasksWrite = "tasks:write"
+	// ScopeProjectsWrite allows changing projects, sprints, WIP limits and
+	// templates
+	ScopeProjectsWrite = "projects:write"
+)
+
+// AccessTokenScopes lists every scope a token can be given
+var AccessTokenScopes = []string{ScopeRead, ScopeTasksWrite, ScopeProjectsWrite}
+
+// AccessToken is a personal access token for scripts and automation. Only a
+// hash of the token is stored; Prefix is its first characters, for display.
+type AccessToken struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP *string    `json:"last_used_ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// UserEmail is the owner's email, set when authenticating a request
+	UserEmail string `json:"-"`
+}
+
+// CreatedAccessToken carries the token itself, shown only when it is created
+type CreatedAccessToken struct {
+	AccessToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Audit actions recorded on audit events
+const (
+	AuditPasswordChanged      = "password_changed"
+	AuditEmailChangeRequested = "email_change_requested"
+	AuditEmailChanged         = "email_changed"
+
+	// Changes made by an admin
+	AuditRoleChanged                 = "role_changed"
+	AuditTwoFactorRequirementChanged = "two_factor_requirement_changed"
+	AuditUserDeactivated             = "user_deactivated"
+	AuditUserReactivated             = "user_reactivated"
+	AuditPasswordReset               = "password_reset"
+	AuditWorkTransferred             = "work_transferred"
+)
+
+// AuditEvent records one change to a user's account. ActorID is who made the
+// change; it is empty when they have since been deleted, or the change was
+// made from the command line.
+type AuditEvent struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"user_id"`
+	ActorID   *string           `json:"actor_id,omitempty"`
+	Action    string            `json:"action"`
+	Details   map[string]string `json:"details"`
+	IPAddress string            `json:"ip_address"`
+	UserAgent string            `json:"user_agent"`
+	CreatedAt time.Time         `json:"created_at"`
+}
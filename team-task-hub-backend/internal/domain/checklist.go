@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type ChecklistItem struct {
+	ID         string    `json:"id"`
+	TaskID     string    `json:"task_id"`
+	Content    string    `json:"content"`
+	IsDone     bool      `json:"is_done"`
+	AssigneeID *string   `json:"assignee_id,omitempty"`
+	Assignee   *User     `json:"assignee,omitempty"`
+	Position   int       `json:"position"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ChecklistProgress summarises a task's checklist without loading its items
+type ChecklistProgress struct {
+	Total int `json:"total"`
+	Done  int `json:"done"`
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// EmailChangeRequest is a pending change of a user's email address, waiting
+// for the link sent to the new address to be followed
+type EmailChangeRequest struct {
+	NewEmail  string    `json:"new_email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
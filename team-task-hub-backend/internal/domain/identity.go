@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// UserIdentity links a user to an account at an OpenID Connect provider
+type UserIdentity struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Issuer      string    `json:"issuer"`
+	Subject     string    `json:"subject"`
+	Email       string    `json:"email"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+// OIDCLoginState is kept between redirecting a user to the provider and the
+// provider redirecting back, and is used once
+type OIDCLoginState struct {
+	State        string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Login failure reasons recorded on login events
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureInvalidTwoFactor   = "invalid_two_factor_code"
+	LoginFailureLocked             = "locked"
+	LoginFailureIPBlocked          = "ip_blocked"
+	LoginFailureSSODomain          = "sso_domain_not_allowed"
+	LoginFailureSSOEmailUnverified = "sso_email_not_verified"
+	LoginFailureDeactivated        = "account_deactivated"
+)
+
+// LoginEvent records one sign-in attempt. UserID is empty when the email did
+// not match an account.
+type LoginEvent struct {
+	ID            string    `json:"id"`
+	UserID        *string   `json:"user_id,omitempty"`
+	Email         string    `json:"email"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	Success       bool      `json:"success"`
+	FailureReason *string   `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+// ProjectStats summarises a project's tasks for its dashboard
+type ProjectStats struct {
+	ProjectID             string              `json:"project_id"`
+	TotalTasks            int                 `json:"total_tasks"`
+	ByStatus              map[string]int      `json:"by_status"`
+	ByPriority            map[string]int      `json:"by_priority"`
+	ByAssignee            []AssigneeTaskCount `json:"by_assignee"`
+	Overdue               int                 `json:"overdue"`
+	Weekly                []WeeklyThroughput  `json:"weekly"`
+	AverageCycleTimeHours *float64            `json:"average_cycle_time_hours"`
+	CycleTimeSampleSize   int                 `json:"cycle_time_sample_size"`
+}
+
+// AssigneeTaskCount counts one assignee's tasks; a nil AssigneeID groups unassigned tasks
+type AssigneeTaskCount struct {
+	AssigneeID *string `json:"assignee_id"`
+	Assignee   *User   `json:"assignee,omitempty"`
+	Total      int     `json:"total"`
+	Open       int     `json:"open"`
+	InProgress int     `json:"in_progress"`
+	Done       int     `json:"done"`
+	Overdue    int     `json:"overdue"`
+}
+
+// WeeklyThroughput counts tasks created and completed in the week starting on WeekStart
+type WeeklyThroughput struct {
+	WeekStart string `json:"week_start"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+}
+
+// WorkloadRow counts one user's unfinished tasks by priority and due window
+type WorkloadRow struct {
+	UserID      string `json:"user_id"`
+	UserEmail   string `json:"user_email"`
+	UserName    string `json:"user_name"`
+	OpenTasks   int    `json:"open_tasks"`
+	InProgress  int    `json:"in_progress"`
+	Low         int    `json:"low"`
+	Medium      int    `json:"medium"`
+	High        int    `json:"high"`
+	Overdue     int    `json:"overdue"`
+	DueThisWeek int    `json:"due_this_week"`
+	DueLater    int    `json:"due_later"`
+	NoDueDate   int    `json:"no_due_date"`
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// SigningKey is a stored JWT signing key. EncryptedPrivateKey is PKCS #8 DER
+// sealed with the key-encryption key.
+type SigningKey struct {
+	ID                  string
+	Algorithm           string
+	EncryptedPrivateKey []byte
+	ActivatesAt         time.Time
+	CreatedAt           time.Time
+}
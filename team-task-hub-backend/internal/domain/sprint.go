@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Sprint is a time-boxed iteration within a project. Status moves from
+// PLANNED to ACTIVE to COMPLETED.
+type Sprint struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"project_id"`
+	CreatedByID *string    `json:"created_by_id,omitempty"`
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// SprintCompletion reports the outcome of completing a sprint
+type SprintCompletion struct {
+	Sprint           *Sprint `json:"sprint"`
+	RolledOverTasks  int     `json:"rolled_over_tasks"`
+	RolloverSprintID *string `json:"rollover_sprint_id"`
+}
+
+// BurndownPoint is the state of a sprint's scope at the end of one day
+type BurndownPoint struct {
+	Date            string `json:"date"`
+	TotalTasks      int    `json:"total_tasks"`
+	CompletedTasks  int    `json:"completed_tasks"`
+	RemainingTasks  int    `json:"remaining_tasks"`
+	TotalPoints     int    `json:"total_points"`
+	CompletedPoints int    `json:"completed_points"`
+	RemainingPoints int    `json:"remaining_points"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type TaskRecurrence struct {
+	ID                  string     `json:"id"`
+	TaskID              string     `json:"task_id"`
+	ProjectID           string     `json:"project_id"`
+	CreatedByID         *string    `json:"created_by_id,omitempty"`
+	RRule               string     `json:"rrule"`
+	DTStart             time.Time  `json:"dtstart"`
+	GenerateOn          string     `json:"generate_on"`
+	CurrentOccurrenceAt time.Time  `json:"current_occurrence_at"`
+	NextOccurrenceAt    *time.Time `json:"next_occurrence_at,omitempty"`
+	OccurrenceCount     int        `json:"occurrence_count"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+type TaskTemplate struct {
+	ID          string    `json:"id"`
+	CreatedByID *string   `json:"created_by_id,omitempty"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Priority    string    `json:"priority"`
+	Checklist   []string  `json:"checklist"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ProjectTemplate struct {
+	ID          string                `json:"id"`
+	CreatedByID *string               `json:"created_by_id,omitempty"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Tasks       []ProjectTemplateTask `json:"tasks,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+type ProjectTemplateTask struct {
+	ID            string `json:"id"`
+	TemplateID    string `json:"template_id"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	Priority      string `json:"priority"`
+	DueOffsetDays *int   `json:"due_offset_days,omitempty"`
+	Position      int    `json:"position"`
+}
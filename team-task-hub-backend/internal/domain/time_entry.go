@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+type TimeEntry struct {
+	ID              string     `json:"id"`
+	TaskID          string     `json:"task_id"`
+	UserID          string     `json:"user_id"`
+	User            *User      `json:"user,omitempty"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationSeconds int        `json:"duration_seconds"`
+	Note            string     `json:"note"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TimesheetFilter selects and groups time entries for a timesheet report
+type TimesheetFilter struct {
+	From      time.Time
+	To        time.Time
+	UserID    string
+	ProjectID string
+	GroupBy   []string
+}
+
+// TimesheetRow is one aggregated line of a timesheet; dimensions that are not
+// grouped on are left empty
+type TimesheetRow struct {
+	UserID      *string `json:"user_id,omitempty"`
+	UserEmail   *string `json:"user_email,omitempty"`
+	UserName    *string `json:"user_name,omitempty"`
+	ProjectID   *string `json:"project_id,omitempty"`
+	ProjectName *string `json:"project_name,omitempty"`
+	Day         *string `json:"day,omitempty"`
+	Hours       float64 `json:"hours"`
+	Entries     int     `json:"entries"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// TwoFactor is a user's TOTP state. EncryptedSecret is set once enrollment
+// starts, sealed with the key-encryption key; Enabled once the user has
+// confirmed a code from their authenticator.
+type TwoFactor struct {
+	UserID          string
+	EncryptedSecret []byte
+	Enabled         bool
+	LastStep        *int64
+}
+
+// TwoFactorEnrollment is what an authenticator app needs to add the account
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// TwoFactorStatus summarises a user's two-factor settings
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// LoginResult is the outcome of a sign-in step: either an access token, or a
+// challenge token to exchange for one at the next step
+type LoginResult struct {
+	User  *User  `json:"user,omitempty"`
+	Token string `json:"token,omitempty"`
+
+	// TwoFactorRequired asks for a TOTP or recovery code;
+	// EnrollmentRequired asks the user to set up two-factor first
+	TwoFactorRequired  bool       `json:"two_factor_required,omitempty"`
+	EnrollmentRequired bool       `json:"enrollment_required,omitempty"`
+	ChallengeToken     string     `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+
+	// RecoveryCodes are shown once, when enrollment completes at sign-in
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
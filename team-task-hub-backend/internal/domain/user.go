@@ -2,6 +2,12 @@ package domain
 
 import "time"
 
+// User roles. Admins can manage other users' accounts.
+const (
+	RoleMember = "member"
+	RoleAdmin  = "admin"
+)
+
 type User struct {
 	ID           string    `json:"id"`
 	Email        string    `json:"email"`
@@ -9,4 +15,34 @@ type User struct {
 	PasswordHash string    `json:"-"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+	// TwoFactorRequired is set by an admin to make the user enroll at their
+	// next sign-in and keep two-factor on
+	TwoFactorRequired bool `json:"two_factor_required"`
+	// SessionGeneration is carried by the user's session tokens; bumping it
+	// revokes every token issued before
+	SessionGeneration int `json:"-"`
+
+	Role string `json:"role"`
+	// DeactivatedAt is set while the user may not sign in
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+}
+
+// UserFilter narrows the users an admin lists. Empty fields match everyone.
+type UserFilter struct {
+	// Query matches part of the email or name, ignoring case
+	Query string
+	Role  string
+	// Status is active or deactivated
+	Status string
+}
+
+// WorkTransfer reports what moved from a departing user to their successor
+type WorkTransfer struct {
+	FromUserID               string `json:"from_user_id"`
+	ToUserID                 string `json:"to_user_id"`
+	ProjectsTransferred      int    `json:"projects_transferred"`
+	TasksReassigned          int    `json:"tasks_reassigned"`
+	ChecklistItemsReassigned int    `json:"checklist_items_reassigned"`
 }
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// WIPLimits caps how many tasks a project's board columns may hold.
+// Enforcement is REJECT (the change fails) or WARN (the change succeeds with
+// a warning).
+type WIPLimits struct {
+	ProjectID               string         `json:"project_id"`
+	StatusLimits            map[string]int `json:"status_limits"`
+	AssigneeInProgressLimit *int           `json:"assignee_in_progress_limit,omitempty"`
+	Enforcement             string         `json:"enforcement"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+}
+
+// ColumnCount is the number of tasks in one status column against its limit
+type ColumnCount struct {
+	Status   string `json:"status"`
+	Count    int    `json:"count"`
+	Limit    *int   `json:"limit,omitempty"`
+	Exceeded bool   `json:"exceeded"`
+}
+
+// AssigneeWIPCount is the number of in-progress tasks held by one assignee
+type AssigneeWIPCount struct {
+	AssigneeID string `json:"assignee_id"`
+	Assignee   *User  `json:"assignee,omitempty"`
+	Count      int    `json:"count"`
+	Limit      *int   `json:"limit,omitempty"`
+	Exceeded   bool   `json:"exceeded"`
+}
+
+// BoardCounts reports current column and assignee counts for a project
+type BoardCounts struct {
+	Columns     []ColumnCount      `json:"columns"`
+	Assignees   []AssigneeWIPCount `json:"assignees"`
+	Enforcement string             `json:"enforcement,omitempty"`
+}
@@ -6,14 +6,18 @@ type ErrorCode string
 
 const (
 	// Validation errors
-	ErrInvalidInput    ErrorCode = "invalid_input"
-	ErrInvalidEmail    ErrorCode = "invalid_email"
-	ErrWeakPassword    ErrorCode = "weak_password"
-	ErrEmptyTitle      ErrorCode = "empty_title"
-	ErrEmptyName       ErrorCode = "empty_name"
-	ErrEmptyContent    ErrorCode = "empty_content"
-	ErrInvalidStatus   ErrorCode = "invalid_status"
-	ErrInvalidPriority ErrorCode = "invalid_priority"
+	ErrInvalidInput      ErrorCode = "invalid_input"
+	ErrInvalidEmail      ErrorCode = "invalid_email"
+	ErrWeakPassword      ErrorCode = "weak_password"
+	ErrEmptyTitle        ErrorCode = "empty_title"
+	ErrEmptyName         ErrorCode = "empty_name"
+	ErrEmptyContent      ErrorCode = "empty_content"
+	ErrInvalidStatus     ErrorCode = "invalid_status"
+	ErrInvalidPriority   ErrorCode = "invalid_priority"
+	ErrInvalidRecurrence ErrorCode = "invalid_recurrence"
+	// ErrInvalidConfirmation is returned for an unknown, used or expired
+	// email confirmation link
+	ErrInvalidConfirmation ErrorCode = "invalid_confirmation_token"
 
 	// Authentication/Authorization errors
 	ErrUnauthorized    ErrorCode = "unauthorized"
@@ -21,20 +25,64 @@ const (
 	ErrInvalidToken    ErrorCode = "invalid_token"
 	ErrTokenExpired    ErrorCode = "token_expired"
 	ErrInvalidPassword ErrorCode = "invalid_password"
+	// ErrSessionRevoked is returned for a session token issued before the
+	// user's password or email last changed, or they were deactivated
+	ErrSessionRevoked ErrorCode = "session_revoked"
+	// ErrInvalidCredentials covers both an unknown email and a wrong password
+	// on login, so responses don't reveal which accounts exist
+	ErrInvalidCredentials ErrorCode = "invalid_credentials"
+	ErrInvalidTwoFactor   ErrorCode = "invalid_two_factor_code"
+	// ErrTwoFactorRequired is returned when two-factor authentication is
+	// mandatory and the request would go without it
+	ErrTwoFactorRequired ErrorCode = "two_factor_required"
+	// ErrInvalidSSOState is returned when a single sign-on callback does not
+	// belong to a sign-in started by this browser, or came too late
+	ErrInvalidSSOState ErrorCode = "invalid_sso_state"
+	// ErrSSODomainNotAllowed and ErrSSOEmailUnverified refuse provider
+	// accounts whose email may not sign in
+	ErrSSODomainNotAllowed ErrorCode = "sso_domain_not_allowed"
+	ErrSSOEmailUnverified  ErrorCode = "sso_email_not_verified"
+	// ErrInsufficientScope is returned when a personal access token lacks
+	// the scope a request needs, or the route doesn't accept tokens at all
+	ErrInsufficientScope ErrorCode = "insufficient_scope"
+	// ErrAccountDeactivated is returned when a deactivated user signs in or
+	// uses a session from before their deactivation
+	ErrAccountDeactivated ErrorCode = "account_deactivated"
 
 	// Resource errors
-	ErrUserNotFound    ErrorCode = "user_not_found"
-	ErrProjectNotFound ErrorCode = "project_not_found"
-	ErrTaskNotFound    ErrorCode = "task_not_found"
-	ErrCommentNotFound ErrorCode = "comment_not_found"
+	ErrUserNotFound          ErrorCode = "user_not_found"
+	ErrProjectNotFound       ErrorCode = "project_not_found"
+	ErrTaskNotFound          ErrorCode = "task_not_found"
+	ErrCommentNotFound       ErrorCode = "comment_not_found"
+	ErrRecurrenceNotFound    ErrorCode = "recurrence_not_found"
+	ErrTemplateNotFound      ErrorCode = "template_not_found"
+	ErrChecklistItemNotFound ErrorCode = "checklist_item_not_found"
+	ErrTimeEntryNotFound     ErrorCode = "time_entry_not_found"
+	ErrTimerNotRunning       ErrorCode = "timer_not_running"
+	ErrSprintNotFound        ErrorCode = "sprint_not_found"
+	ErrAccessTokenNotFound   ErrorCode = "access_token_not_found"
 
 	// Conflict errors
 	ErrEmailExists       ErrorCode = "email_already_exists"
 	ErrInvalidTransition ErrorCode = "invalid_status_transition"
+	ErrTimerRunning      ErrorCode = "timer_already_running"
+	ErrSprintActive      ErrorCode = "sprint_already_active"
+	ErrWIPLimitExceeded  ErrorCode = "wip_limit_exceeded"
+	ErrTwoFactorEnabled  ErrorCode = "two_factor_already_enabled"
+	ErrTwoFactorDisabled ErrorCode = "two_factor_not_enabled"
+	ErrTooManyTokens     ErrorCode = "access_token_limit_reached"
+	ErrLastAdmin         ErrorCode = "last_admin"
+
+	// Throttling errors
+	ErrRateLimited     ErrorCode = "rate_limited"
+	ErrTooManyAttempts ErrorCode = "too_many_attempts"
 
 	// Database/Server errors
 	ErrInternal      ErrorCode = "internal_server_error"
 	ErrDatabaseError ErrorCode = "database_error"
+	// ErrSSOProvider is returned when the identity provider cannot be reached
+	// or its response fails verification
+	ErrSSOProvider ErrorCode = "sso_provider_error"
 )
 
 type AppError struct {
@@ -53,16 +101,20 @@ func (e *AppError) Error() string {
 // HTTP Status Code mapping
 func (e *AppError) StatusCode() int {
 	switch e.Code {
-	case ErrInvalidEmail, ErrWeakPassword, ErrEmptyTitle, ErrEmptyName, ErrEmptyContent, ErrInvalidStatus, ErrInvalidPriority:
+	case ErrInvalidInput, ErrInvalidEmail, ErrWeakPassword, ErrEmptyTitle, ErrEmptyName, ErrEmptyContent, ErrInvalidStatus, ErrInvalidPriority, ErrInvalidRecurrence, ErrInvalidConfirmation:
 		return 400
-	case ErrUnauthorized, ErrInvalidToken, ErrTokenExpired, ErrInvalidPassword:
+	case ErrUnauthorized, ErrInvalidToken, ErrTokenExpired, ErrSessionRevoked, ErrInvalidPassword, ErrInvalidCredentials, ErrInvalidTwoFactor, ErrInvalidSSOState:
 		return 401
-	case ErrForbidden:
+	case ErrForbidden, ErrTwoFactorRequired, ErrSSODomainNotAllowed, ErrSSOEmailUnverified, ErrInsufficientScope, ErrAccountDeactivated:
 		return 403
-	case ErrUserNotFound, ErrProjectNotFound, ErrTaskNotFound, ErrCommentNotFound:
+	case ErrUserNotFound, ErrProjectNotFound, ErrTaskNotFound, ErrCommentNotFound, ErrRecurrenceNotFound, ErrTemplateNotFound, ErrChecklistItemNotFound, ErrTimeEntryNotFound, ErrTimerNotRunning, ErrSprintNotFound, ErrAccessTokenNotFound:
 		return 404
-	case ErrEmailExists, ErrInvalidTransition:
+	case ErrEmailExists, ErrInvalidTransition, ErrTimerRunning, ErrSprintActive, ErrWIPLimitExceeded, ErrTwoFactorEnabled, ErrTwoFactorDisabled, ErrTooManyTokens, ErrLastAdmin:
 		return 409
+	case ErrRateLimited, ErrTooManyAttempts:
+		return 429
+	case ErrSSOProvider:
+		return 502
 	default:
 		return 500
 	}
@@ -85,6 +137,10 @@ func NewConflictError(code ErrorCode, message string) *AppError {
 	return &AppError{Code: code, Message: message}
 }
 
+func NewRateLimitError(code ErrorCode, message string) *AppError {
+	return &AppError{Code: code, Message: message}
+}
+
 func NewInternalError(message string, err error) *AppError {
 	return &AppError{Code: ErrInternal, Message: message, Err: err}
 }
@@ -92,3 +148,7 @@ func NewInternalError(message string, err error) *AppError {
 func NewDatabaseError(message string, err error) *AppError {
 	return &AppError{Code: ErrDatabaseError, Message: message, Err: err}
 }
+
+func NewSSOProviderError(message string, err error) *AppError {
+	return &AppError{Code: ErrSSOProvider, Message: message, Err: err}
+}
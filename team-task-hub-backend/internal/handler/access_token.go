@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type accessTokenHandler struct {
+	accessTokenService service.AccessTokenService
+}
+
+func NewAccessTokenHandler(accessTokenService service.AccessTokenService) *accessTokenHandler {
+	return &accessTokenHandler{accessTokenService: accessTokenService}
+}
+
+// ListTokens handles GET /api/auth/tokens
+func (h *accessTokenHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	tokens, err := h.accessTokenService.ListTokens(ctx, userID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(tokens, "Access tokens retrieved successfully"))
+}
+
+// CreateToken handles POST /api/auth/tokens
+func (h *accessTokenHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	var req CreateAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	token, err := h.accessTokenService.CreateToken(ctx, userID, req.Name, req.Scopes, req.ExpiresInDays)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(token, "Access token created successfully"))
+}
+
+// RevokeToken handles DELETE /api/auth/tokens/{token_id}
+func (h *accessTokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	tokenID := chi.URLParam(r, "token_id")
+
+	ctx := r.Context()
+	if err := h.accessTokenService.RevokeToken(ctx, userID, tokenID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Access token revoked successfully"))
+}
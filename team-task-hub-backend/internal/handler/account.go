@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type accountHandler struct {
+	accountService service.AccountService
+}
+
+func NewAccountHandler(accountService service.AccountService) *accountHandler {
+	return &accountHandler{accountService: accountService}
+}
+
+// ChangePassword handles POST /api/auth/password
+func (h *accountHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	result, err := h.accountService.ChangePassword(ctx, userID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(result, "Password changed successfully"))
+}
+
+// RequestEmailChange handles POST /api/auth/email
+func (h *accountHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	var req ChangeEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	request, err := h.accountService.RequestEmailChange(ctx, userID, req.NewEmail, req.Password)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(NewSuccessResponse(request, "Confirmation email sent"))
+}
+
+// ConfirmEmailChange handles POST /api/auth/email/confirm
+func (h *accountHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	var req ConfirmEmailChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	result, err := h.accountService.ConfirmEmailChange(ctx, userID, req.Token)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(result, "Email changed successfully"))
+}
+
+// ListAuditEvents handles GET /api/auth/me/audit
+func (h *accountHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil {
+			appErr := apperrors.NewValidationError(apperrors.ErrInvalidInput, "limit must be a number")
+			w.WriteHeader(appErr.StatusCode())
+			json.NewEncoder(w).Encode(NewErrorResponse(appErr))
+			return
+		}
+		limit = parsed
+	}
+
+	ctx := r.Context()
+	events, err := h.accountService.ListAuditEvents(ctx, userID, limit)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(events, "Audit events retrieved successfully"))
+}
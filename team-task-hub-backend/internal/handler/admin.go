@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type adminHandler struct {
+	adminService service.AdminService
+}
+
+func NewAdminHandler(adminService service.AdminService) *adminHandler {
+	return &adminHandler{adminService: adminService}
+}
+
+// ListUsers handles GET /api/admin/users
+func (h *adminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	// Parse pagination parameters
+	page := 1
+	pageSize := 20
+
+	if p := r.URL.Query().Get("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 100 {
+			pageSize = parsed
+		}
+	}
+
+	// Parse optional filters
+	filter := domain.UserFilter{
+		Query:  r.URL.Query().Get("q"),
+		Role:   r.URL.Query().Get("role"),
+		Status: r.URL.Query().Get("status"),
+	}
+
+	ctx := r.Context()
+	users, total, err := h.adminService.ListUsers(ctx, filter, page, pageSize)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewPaginatedResponse(users, total, page, pageSize, "Users retrieved successfully"))
+}
+
+// GetUser handles GET /api/admin/users/{user_id}
+func (h *adminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	ctx := r.Context()
+	user, err := h.adminService.GetUser(ctx, userID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(user, "User retrieved successfully"))
+}
+
+// SetRole handles PUT /api/admin/users/{user_id}/role
+func (h *adminHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	adminID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	var req SetRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.adminService.SetRole(ctx, adminID, userID, req.Role)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(user, "Role updated successfully"))
+}
+
+// SetTwoFactorRequired handles PUT /api/admin/users/{user_id}/two-factor
+func (h *adminHandler) SetTwoFactorRequired(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	adminID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	var req SetTwoFactorRequiredRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.adminService.SetTwoFactorRequired(ctx, adminID, userID, req.Required)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(user, "Two-factor requirement updated successfully"))
+}
+
+// DeactivateUser handles POST /api/admin/users/{user_id}/deactivate
+func (h *adminHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	adminID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	ctx := r.Context()
+	user, err := h.adminService.DeactivateUser(ctx, adminID, userID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(user, "User deactivated successfully"))
+}
+
+// ReactivateUser handles POST /api/admin/users/{user_id}/reactivate
+func (h *adminHandler) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	adminID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	ctx := r.Context()
+	user, err := h.adminService.ReactivateUser(ctx, adminID, userID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(user, "User reactivated successfully"))
+}
+
+// ResetPassword handles POST /api/admin/users/{user_id}/password
+func (h *adminHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	adminID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.adminService.ResetPassword(ctx, adminID, userID, req.NewPassword)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(user, "Password reset successfully"))
+}
+
+// TransferWork handles POST /api/admin/users/{user_id}/transfer
+func (h *adminHandler) TransferWork(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	adminID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	var req TransferWorkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	transfer, err := h.adminService.TransferWork(ctx, adminID, userID, req.ToUserID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(transfer, "Work transferred successfully"))
+}
+
+// ListAuditEvents handles GET /api/admin/users/{user_id}/audit
+func (h *adminHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	userID := chi.URLParam(r, "user_id")
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil {
+			appErr := apperrors.NewValidationError(apperrors.ErrInvalidInput, "limit must be a number")
+			w.WriteHeader(appErr.StatusCode())
+			json.NewEncoder(w).Encode(NewErrorResponse(appErr))
+			return
+		}
+		limit = parsed
+	}
+
+	ctx := r.Context()
+	events, err := h.adminService.ListAuditEvents(ctx, userID, limit)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(events, "Audit events retrieved successfully"))
+}
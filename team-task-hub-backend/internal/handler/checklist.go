@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type checklistHandler struct {
+	checklistService service.ChecklistService
+}
+
+func NewChecklistHandler(checklistService service.ChecklistService) *checklistHandler {
+	return &checklistHandler{checklistService: checklistService}
+}
+
+// ListItems handles GET /api/tasks/{task_id}/checklist
+func (h *checklistHandler) ListItems(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	ctx := r.Context()
+	items, err := h.checklistService.ListItems(ctx, taskID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(items, "Checklist retrieved successfully"))
+}
+
+// AddItem handles POST /api/tasks/{task_id}/checklist
+func (h *checklistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req CreateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	item, err := h.checklistService.AddItem(ctx, taskID, req.Content, req.AssigneeID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(item, "Checklist item created successfully"))
+}
+
+// UpdateItem handles PATCH /api/tasks/{task_id}/checklist/{item_id}
+func (h *checklistHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+	itemID := chi.URLParam(r, "item_id")
+
+	var req UpdateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	item, err := h.checklistService.UpdateItem(ctx, taskID, itemID, req.Content, req.IsDone, req.AssigneeID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(item, "Checklist item updated successfully"))
+}
+
+// ToggleItem handles POST /api/tasks/{task_id}/checklist/{item_id}/toggle
+func (h *checklistHandler) ToggleItem(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+	itemID := chi.URLParam(r, "item_id")
+
+	ctx := r.Context()
+	item, err := h.checklistService.ToggleItem(ctx, taskID, itemID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(item, "Checklist item toggled successfully"))
+}
+
+// ReorderItems handles PUT /api/tasks/{task_id}/checklist/order
+func (h *checklistHandler) ReorderItems(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req ReorderChecklistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	items, err := h.checklistService.ReorderItems(ctx, taskID, req.ItemIDs)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(items, "Checklist reordered successfully"))
+}
+
+// DeleteItem handles DELETE /api/tasks/{task_id}/checklist/{item_id}
+func (h *checklistHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+	itemID := chi.URLParam(r, "item_id")
+
+	ctx := r.Context()
+	if err := h.checklistService.DeleteItem(ctx, taskID, itemID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Checklist item deleted successfully"))
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -39,7 +38,7 @@ func (h *commentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	comment, err := h.commentService.CreateComment(ctx, taskID, userID, req.Content)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -80,7 +79,7 @@ func (h *commentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	comments, total, err := h.commentService.ListComments(ctx, taskID, page, pageSize)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -112,7 +111,7 @@ func (h *commentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	comment, err := h.commentService.UpdateComment(ctx, commentID, req.Content)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -137,7 +136,7 @@ func (h *commentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 
 	commentID := chi.URLParam(r, "comment_id")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	err = h.commentService.DeleteComment(ctx, commentID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -176,7 +175,7 @@ func (h *commentHandler) ListRecentComments(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	comments, total, err := h.commentService.ListRecentComments(ctx, page, pageSize)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/launchventures/team-task-hub-backend/internal/health"
+)
+
+type healthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *healthHandler {
+	return &healthHandler{checker: checker}
+}
+
+// Livez handles GET /livez. The process is live as long as it can serve
+// requests, so no dependencies are checked.
+func (h *healthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// Readyz handles GET /readyz, returning 503 when a critical dependency check fails
+func (h *healthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	report := h.checker.Run(r.Context())
+
+	status := http.StatusOK
+	if report.Status == "fail" {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/launchventures/team-task-hub-backend/internal/config"
+	"github.com/launchventures/team-task-hub-backend/internal/keys"
+)
+
+type jwksHandler struct {
+	ring *keys.Ring
+}
+
+func NewJWKSHandler(ring *keys.Ring) *jwksHandler {
+	return &jwksHandler{ring: ring}
+}
+
+// JWKS handles GET /.well-known/jwks.json. The key set is served bare, as
+// JWT libraries expect, rather than in the API's response envelope.
+func (h *jwksHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(config.JWKSMaxAge.Seconds())))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.ring.JWKS())
+}
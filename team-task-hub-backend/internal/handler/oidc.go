@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+)
+
+// oidcStateCookie ties the provider's callback to the browser that started
+// the sign-in
+const oidcStateCookie = "oidc_state"
+
+type oidcHandler struct {
+	oidcService service.OIDCService
+	frontendURL string
+	redirectURL string
+	stateTTL    time.Duration
+}
+
+// NewOIDCHandler creates the single sign-on handler. frontendURL receives the
+// outcome of each sign-in; redirectURL is this API's callback.
+func NewOIDCHandler(oidcService service.OIDCService, frontendURL, redirectURL string, stateTTL time.Duration) *oidcHandler {
+	return &oidcHandler{
+		oidcService: oidcService,
+		frontendURL: frontendURL,
+		redirectURL: redirectURL,
+		stateTTL:    stateTTL,
+	}
+}
+
+// Login handles GET /api/auth/oidc/login
+func (h *oidcHandler) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	authURL, state, err := h.oidcService.StartLogin(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to start single sign-on", "error", err)
+		h.redirectToFrontend(w, r, url.Values{"error": {NewErrorResponse(err).Error}})
+		return
+	}
+
+	http.SetCookie(w, h.stateCookie(state, int(h.stateTTL.Seconds())))
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback handles GET /api/auth/oidc/callback
+func (h *oidcHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	cookie, _ := r.Cookie(oidcStateCookie)
+	http.SetCookie(w, h.stateCookie("", -1))
+
+	// The provider reports a refused or failed sign-in with an error code
+	if providerErr := query.Get("error"); providerErr != "" {
+		slog.WarnContext(ctx, "identity provider returned an error", "error", providerErr, "description", query.Get("error_description"))
+		h.redirectToFrontend(w, r, url.Values{"error": {string(apperrors.ErrSSOProvider)}, "provider_error": {providerErr}})
+		return
+	}
+
+	state := query.Get("state")
+	if cookie == nil || state == "" || cookie.Value != state {
+		h.redirectToFrontend(w, r, url.Values{"error": {string(apperrors.ErrInvalidSSOState)}})
+		return
+	}
+
+	result, err := h.oidcService.CompleteLogin(ctx, state, query.Get("code"))
+	if err != nil {
+		if ErrorToStatusCode(err) >= http.StatusInternalServerError {
+			slog.ErrorContext(ctx, "single sign-on failed", "error", err)
+		}
+		h.redirectToFrontend(w, r, url.Values{"error": {NewErrorResponse(err).Error}})
+		return
+	}
+
+	if result.ChallengeToken != "" {
+		outcome := url.Values{
+			"challenge_token":      {result.ChallengeToken},
+			"challenge_expires_at": {result.ChallengeExpiresAt.Format(time.RFC3339)},
+		}
+		if result.EnrollmentRequired {
+			outcome.Set("enrollment_required", "true")
+		} else {
+			outcome.Set("two_factor_required", "true")
+		}
+		h.redirectToFrontend(w, r, outcome)
+		return
+	}
+
+	h.redirectToFrontend(w, r, url.Values{"token": {result.Token}})
+}
+
+// redirectToFrontend sends the outcome in the URL fragment, which browsers
+// don't send to servers or in Referer headers
+func (h *oidcHandler) redirectToFrontend(w http.ResponseWriter, r *http.Request, outcome url.Values) {
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, h.frontendURL+"#"+outcome.Encode(), http.StatusFound)
+}
+
+// stateCookie returns the state cookie, or one deleting it when maxAge < 0.
+// SameSite=Lax still sends it on the provider's top-level redirect back.
+func (h *oidcHandler) stateCookie(state string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/api/auth/oidc",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(h.redirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -37,8 +36,8 @@ func (h *projectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
-	project, err := h.projectService.CreateProject(ctx, userID, req.Name, req.Description)
+	ctx := r.Context()
+	project, err := h.projectService.CreateProject(ctx, userID, req.Name, req.Description, req.TemplateID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
 		json.NewEncoder(w).Encode(NewErrorResponse(err))
@@ -76,7 +75,7 @@ func (h *projectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	projects, total, err := h.projectService.ListProjects(ctx, userID, page, pageSize)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -94,7 +93,7 @@ func (h *projectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
 
 	projectID := chi.URLParam(r, "project_id")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	project, err := h.projectService.GetProject(ctx, projectID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -126,7 +125,7 @@ func (h *projectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	project, err := h.projectService.UpdateProject(ctx, projectID, req.Name, req.Description)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -151,7 +150,7 @@ func (h *projectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 
 	projectID := chi.URLParam(r, "project_id")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	err = h.projectService.DeleteProject(ctx, projectID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
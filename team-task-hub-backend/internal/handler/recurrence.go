@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type recurrenceHandler struct {
+	recurrenceService service.RecurrenceService
+}
+
+func NewRecurrenceHandler(recurrenceService service.RecurrenceService) *recurrenceHandler {
+	return &recurrenceHandler{recurrenceService: recurrenceService}
+}
+
+// SetRecurrence handles PUT /api/tasks/{task_id}/recurrence
+func (h *recurrenceHandler) SetRecurrence(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req SetRecurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	recurrence, err := h.recurrenceService.SetRecurrence(ctx, taskID, userID, req.RRule, req.GenerateOn, req.DTStart)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(recurrence, "Recurrence saved successfully"))
+}
+
+// GetRecurrence handles GET /api/tasks/{task_id}/recurrence
+func (h *recurrenceHandler) GetRecurrence(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	ctx := r.Context()
+	recurrence, err := h.recurrenceService.GetRecurrence(ctx, taskID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(recurrence, "Recurrence retrieved successfully"))
+}
+
+// DeleteRecurrence handles DELETE /api/tasks/{task_id}/recurrence
+func (h *recurrenceHandler) DeleteRecurrence(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	ctx := r.Context()
+	if err := h.recurrenceService.DeleteRecurrence(ctx, taskID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Recurrence deleted successfully"))
+}
+
+// PreviewRecurrence handles GET /api/tasks/{task_id}/recurrence/preview
+func (h *recurrenceHandler) PreviewRecurrence(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	count := 10
+	if c := r.URL.Query().Get("count"); c != "" {
+		parsed, err := strconv.Atoi(c)
+		if err != nil {
+			appErr := apperrors.NewValidationError(apperrors.ErrInvalidInput, "count must be a number")
+			w.WriteHeader(appErr.StatusCode())
+			json.NewEncoder(w).Encode(NewErrorResponse(appErr))
+			return
+		}
+		count = parsed
+	}
+
+	ctx := r.Context()
+	occurrences, err := h.recurrenceService.PreviewOccurrences(ctx, taskID, count)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(RecurrencePreviewResponse{Occurrences: occurrences}, "Recurrence preview retrieved successfully"))
+}
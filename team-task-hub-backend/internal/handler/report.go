@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type reportHandler struct {
+	reportService service.ReportService
+}
+
+func NewReportHandler(reportService service.ReportService) *reportHandler {
+	return &reportHandler{reportService: reportService}
+}
+
+// ProjectStats handles GET /api/projects/{project_id}/stats
+func (h *reportHandler) ProjectStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	weeks := 0
+	if wk := r.URL.Query().Get("weeks"); wk != "" {
+		parsed, err := strconv.Atoi(wk)
+		if err != nil {
+			appErr := apperrors.NewValidationError(apperrors.ErrInvalidInput, "weeks must be a number")
+			w.WriteHeader(appErr.StatusCode())
+			json.NewEncoder(w).Encode(NewErrorResponse(appErr))
+			return
+		}
+		weeks = parsed
+	}
+
+	ctx := r.Context()
+	stats, err := h.reportService.GetProjectStats(ctx, projectID, weeks)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(stats, "Project stats retrieved successfully"))
+}
+
+// Workload handles GET /api/reports/workload
+func (h *reportHandler) Workload(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	query := r.URL.Query()
+
+	ctx := r.Context()
+	rows, err := h.reportService.GetWorkload(ctx, userID, query.Get("project_id"))
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	if query.Get("format") == "csv" || strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		writeWorkloadCSV(w, rows)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(rows, "Workload retrieved successfully"))
+}
+
+// writeWorkloadCSV writes workload rows as a CSV attachment
+func writeWorkloadCSV(w http.ResponseWriter, rows []domain.WorkloadRow) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="workload.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"user_id", "user_email", "user_name", "open_tasks", "in_progress", "low", "medium", "high", "overdue", "due_this_week", "due_later", "no_due_date"})
+	for _, row := range rows {
+		writer.Write([]string{
+			row.UserID,
+			csvText(row.UserEmail),
+			csvText(row.UserName),
+			strconv.Itoa(row.OpenTasks),
+			strconv.Itoa(row.InProgress),
+			strconv.Itoa(row.Low),
+			strconv.Itoa(row.Medium),
+			strconv.Itoa(row.High),
+			strconv.Itoa(row.Overdue),
+			strconv.Itoa(row.DueThisWeek),
+			strconv.Itoa(row.DueLater),
+			strconv.Itoa(row.NoDueDate),
+		})
+	}
+	writer.Flush()
+}
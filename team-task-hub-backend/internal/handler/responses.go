@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/launchventures/team-task-hub-backend/internal/domain"
 	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
 )
 
@@ -47,15 +46,68 @@ type UpdateProfileRequest struct {
 	Name string `json:"name" validate:"max=255"`
 }
 
-type AuthResponse struct {
-	User  *domain.User `json:"user"`
-	Token string       `json:"token"`
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// DTOs for user administration
+type SetRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=member admin"`
+}
+
+type SetTwoFactorRequiredRequest struct {
+	Required bool `json:"required"`
+}
+
+type ResetPasswordRequest struct {
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+}
+
+type TransferWorkRequest struct {
+	ToUserID string `json:"to_user_id" validate:"required"`
+}
+
+// DTOs for two-factor authentication
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DTO for personal access token requests
+type CreateAccessTokenRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required"`
+	// ExpiresInDays defaults to the longest lifetime allowed
+	ExpiresInDays *int `json:"expires_in_days,omitempty"`
 }
 
 // DTO for project requests
 type CreateProjectRequest struct {
 	Name        string `json:"name" validate:"required,min=3,max=100"`
 	Description string `json:"description" validate:"max=1000"`
+	TemplateID  string `json:"template_id"`
 }
 
 type UpdateProjectRequest struct {
@@ -159,6 +211,182 @@ type AssignTaskRequest struct {
 	UserID string `json:"user_id" validate:"required"`
 }
 
+// DTO for recurrence requests
+type SetRecurrenceRequest struct {
+	RRule      string     `json:"rrule" validate:"required"`
+	GenerateOn string     `json:"generate_on" validate:"omitempty,oneof=COMPLETION SCHEDULE"`
+	DTStart    *time.Time `json:"dtstart"`
+}
+
+type RecurrencePreviewResponse struct {
+	Occurrences []time.Time `json:"occurrences"`
+}
+
+// DTO for template requests
+type CreateTaskTemplateRequest struct {
+	Name        string   `json:"name" validate:"required,max=255"`
+	Title       string   `json:"title" validate:"required,min=3,max=200"`
+	Description string   `json:"description" validate:"max=2000"`
+	Priority    string   `json:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
+	Checklist   []string `json:"checklist"`
+}
+
+type InstantiateTaskTemplateRequest struct {
+	ProjectID  string     `json:"project_id" validate:"required"`
+	AssigneeID *string    `json:"assignee_id"`
+	DueDate    *time.Time `json:"due_date"`
+}
+
+// UnmarshalJSON handles custom unmarshaling of InstantiateTaskTemplateRequest to support date strings
+func (i *InstantiateTaskTemplateRequest) UnmarshalJSON(data []byte) error {
+	type Alias InstantiateTaskTemplateRequest
+	aux := &struct {
+		DueDate *string `json:"due_date"`
+		*Alias
+	}{
+		Alias: (*Alias)(i),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	// Parse the due_date string if provided
+	if aux.DueDate != nil && *aux.DueDate != "" {
+		// Try to parse as ISO 8601 date (YYYY-MM-DD)
+		t, err := time.Parse("2006-01-02", *aux.DueDate)
+		if err != nil {
+			// Try parsing as full RFC3339 format
+			t, err = time.Parse(time.RFC3339, *aux.DueDate)
+			if err != nil {
+				return err
+			}
+		}
+		i.DueDate = &t
+	}
+
+	return nil
+}
+
+type ProjectTemplateTaskRequest struct {
+	Title         string `json:"title" validate:"required,min=3,max=200"`
+	Description   string `json:"description" validate:"max=2000"`
+	Priority      string `json:"priority" validate:"omitempty,oneof=LOW MEDIUM HIGH"`
+	DueOffsetDays *int   `json:"due_offset_days" validate:"omitempty,min=0"`
+}
+
+type CreateProjectTemplateRequest struct {
+	Name        string                       `json:"name" validate:"required,max=255"`
+	Description string                       `json:"description" validate:"max=1000"`
+	Tasks       []ProjectTemplateTaskRequest `json:"tasks"`
+}
+
+type CreateProjectTemplateFromProjectRequest struct {
+	Name string `json:"name" validate:"max=255"`
+}
+
+// DTO for checklist requests
+type CreateChecklistItemRequest struct {
+	Content    string  `json:"content" validate:"required,min=1,max=500"`
+	AssigneeID *string `json:"assignee_id"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Content    *string `json:"content" validate:"omitempty,min=1,max=500"`
+	IsDone     *bool   `json:"is_done"`
+	AssigneeID *string `json:"assignee_id"`
+}
+
+type ReorderChecklistRequest struct {
+	ItemIDs []string `json:"item_ids" validate:"required"`
+}
+
+type MoveTaskRequest struct {
+	Status   string `json:"status" validate:"omitempty,oneof=OPEN IN_PROGRESS DONE"`
+	BeforeID string `json:"before_id"`
+	AfterID  string `json:"after_id"`
+}
+
+// DTO for WIP limit requests
+type SetWIPLimitsRequest struct {
+	StatusLimits            map[string]int `json:"status_limits"`
+	AssigneeInProgressLimit *int           `json:"assignee_in_progress_limit" validate:"omitempty,min=1"`
+	Enforcement             string         `json:"enforcement" validate:"omitempty,oneof=REJECT WARN"`
+}
+
+// DTO for time tracking requests
+type CreateTimeEntryRequest struct {
+	StartedAt       *time.Time `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	DurationMinutes int        `json:"duration_minutes" validate:"omitempty,min=1"`
+	Note            string     `json:"note" validate:"max=1000"`
+}
+
+type StartTimerRequest struct {
+	Note string `json:"note" validate:"max=1000"`
+}
+
+type UpdateTaskEstimateRequest struct {
+	OriginalEstimateMinutes  *int `json:"original_estimate_minutes" validate:"omitempty,min=0"`
+	RemainingEstimateMinutes *int `json:"remaining_estimate_minutes" validate:"omitempty,min=0"`
+	StoryPoints              *int `json:"story_points" validate:"omitempty,min=0"`
+}
+
+// DTO for sprint requests
+type SprintRequest struct {
+	Name      string    `json:"name" validate:"required,min=1,max=255"`
+	Goal      string    `json:"goal" validate:"max=2000"`
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date" validate:"required"`
+}
+
+// UnmarshalJSON handles custom unmarshaling of SprintRequest to support date strings
+func (s *SprintRequest) UnmarshalJSON(data []byte) error {
+	type Alias SprintRequest
+	aux := &struct {
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	for _, field := range []struct {
+		value  string
+		target *time.Time
+	}{
+		{aux.StartDate, &s.StartDate},
+		{aux.EndDate, &s.EndDate},
+	} {
+		if field.value == "" {
+			continue
+		}
+		// Try to parse as ISO 8601 date (YYYY-MM-DD), then as full RFC3339 format
+		t, err := time.Parse("2006-01-02", field.value)
+		if err != nil {
+			t, err = time.Parse(time.RFC3339, field.value)
+			if err != nil {
+				return err
+			}
+		}
+		*field.target = t
+	}
+
+	return nil
+}
+
+type CompleteSprintRequest struct {
+	RolloverSprintID string `json:"rollover_sprint_id"`
+}
+
+type SetTaskSprintRequest struct {
+	SprintID *string `json:"sprint_id"`
+}
+
 // DTO for comment requests
 type CreateCommentRequest struct {
 	Content string `json:"content" validate:"required,min=1,max=3000"`
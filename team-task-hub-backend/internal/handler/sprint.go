@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type sprintHandler struct {
+	sprintService service.SprintService
+}
+
+func NewSprintHandler(sprintService service.SprintService) *sprintHandler {
+	return &sprintHandler{sprintService: sprintService}
+}
+
+// CreateSprint handles POST /api/projects/{project_id}/sprints
+func (h *sprintHandler) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	var req SprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	sprint, err := h.sprintService.CreateSprint(ctx, projectID, userID, req.Name, req.Goal, req.StartDate, req.EndDate)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(sprint, "Sprint created successfully"))
+}
+
+// ListSprints handles GET /api/projects/{project_id}/sprints
+func (h *sprintHandler) ListSprints(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	ctx := r.Context()
+	sprints, err := h.sprintService.ListSprints(ctx, projectID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(sprints, "Sprints retrieved successfully"))
+}
+
+// GetSprint handles GET /api/sprints/{sprint_id}
+func (h *sprintHandler) GetSprint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	sprintID := chi.URLParam(r, "sprint_id")
+
+	ctx := r.Context()
+	sprint, err := h.sprintService.GetSprint(ctx, sprintID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(sprint, "Sprint retrieved successfully"))
+}
+
+// UpdateSprint handles PUT /api/sprints/{sprint_id}
+func (h *sprintHandler) UpdateSprint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	sprintID := chi.URLParam(r, "sprint_id")
+
+	var req SprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	sprint, err := h.sprintService.UpdateSprint(ctx, sprintID, req.Name, req.Goal, req.StartDate, req.EndDate)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(sprint, "Sprint updated successfully"))
+}
+
+// DeleteSprint handles DELETE /api/sprints/{sprint_id}
+func (h *sprintHandler) DeleteSprint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	sprintID := chi.URLParam(r, "sprint_id")
+
+	ctx := r.Context()
+	if err := h.sprintService.DeleteSprint(ctx, sprintID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Sprint deleted successfully"))
+}
+
+// StartSprint handles POST /api/sprints/{sprint_id}/start
+func (h *sprintHandler) StartSprint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	sprintID := chi.URLParam(r, "sprint_id")
+
+	ctx := r.Context()
+	sprint, err := h.sprintService.StartSprint(ctx, sprintID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(sprint, "Sprint started successfully"))
+}
+
+// CompleteSprint handles POST /api/sprints/{sprint_id}/complete
+func (h *sprintHandler) CompleteSprint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	sprintID := chi.URLParam(r, "sprint_id")
+
+	var req CompleteSprintRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(NewErrorResponse(err))
+			return
+		}
+	}
+
+	ctx := r.Context()
+	completion, err := h.sprintService.CompleteSprint(ctx, sprintID, req.RolloverSprintID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(completion, "Sprint completed successfully"))
+}
+
+// Burndown handles GET /api/sprints/{sprint_id}/burndown
+func (h *sprintHandler) Burndown(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	sprintID := chi.URLParam(r, "sprint_id")
+
+	ctx := r.Context()
+	points, err := h.sprintService.Burndown(ctx, sprintID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(points, "Burndown retrieved successfully"))
+}
+
+// SetTaskSprint handles PUT /api/tasks/{task_id}/sprint
+func (h *sprintHandler) SetTaskSprint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req SetTaskSprintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	task, err := h.sprintService.SetTaskSprint(ctx, taskID, req.SprintID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(task, "Task sprint updated successfully"))
+}
@@ -1,13 +1,13 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
 	"github.com/launchventures/team-task-hub-backend/internal/service"
 	"github.com/launchventures/team-task-hub-backend/internal/utils"
 )
@@ -40,8 +40,8 @@ func (h *taskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
-	task, err := h.taskService.CreateTask(ctx, projectID, userID, req.Title, req.Description, req.Priority, req.AssigneeID, req.DueDate)
+	ctx := r.Context()
+	task, err := h.taskService.CreateTask(ctx, metrics.TaskSourceUser, projectID, userID, req.Title, req.Description, req.Priority, req.AssigneeID, req.DueDate)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
 		json.NewEncoder(w).Encode(NewErrorResponse(err))
@@ -89,9 +89,10 @@ func (h *taskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	// Parse optional filters
 	status := r.URL.Query().Get("status")
 	priority := r.URL.Query().Get("priority")
+	sprintID := r.URL.Query().Get("sprint_id")
 
-	ctx := context.Background()
-	tasks, total, err := h.taskService.ListTasks(ctx, projectID, page, pageSize, status, priority)
+	ctx := r.Context()
+	tasks, total, err := h.taskService.ListTasks(ctx, projectID, page, pageSize, status, priority, sprintID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
 		json.NewEncoder(w).Encode(NewErrorResponse(err))
@@ -133,7 +134,7 @@ func (h *taskHandler) ListAssignedTasks(w http.ResponseWriter, r *http.Request)
 	status := r.URL.Query().Get("status")
 	priority := r.URL.Query().Get("priority")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	tasks, total, err := h.taskService.ListAssignedTasks(ctx, userID, page, pageSize, status, priority)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -158,7 +159,7 @@ func (h *taskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 	taskID := chi.URLParam(r, "task_id")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	task, err := h.taskService.GetTask(ctx, taskID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -190,7 +191,7 @@ func (h *taskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 
 	// Extract values from pointers (empty string if nil for partial updates)
 	title := ""
@@ -249,7 +250,7 @@ func (h *taskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	// Get current task to preserve other fields
 	task, err := h.taskService.GetTask(ctx, taskID)
 	if err != nil {
@@ -290,7 +291,7 @@ func (h *taskHandler) UpdateTaskPriority(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	// Get current task first to preserve other fields
 	task, err := h.taskService.GetTask(ctx, taskID)
 	if err != nil {
@@ -330,7 +331,7 @@ func (h *taskHandler) UpdateTaskAssignee(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	// If no assignee provided, clear assignment; otherwise set assignee and who assigned
 	if req.AssigneeID == nil || *req.AssigneeID == "" {
 		if err := h.taskService.UnassignTask(ctx, taskID); err != nil {
@@ -377,7 +378,7 @@ func (h *taskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	err = h.taskService.AssignTask(ctx, taskID, req.UserID, userID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -389,6 +390,70 @@ func (h *taskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Task assigned successfully"))
 }
 
+// UpdateTaskEstimate handles PATCH /api/tasks/{task_id}/estimate
+func (h *taskHandler) UpdateTaskEstimate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req UpdateTaskEstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	task, err := h.taskService.UpdateEstimates(ctx, taskID, req.OriginalEstimateMinutes, req.RemainingEstimateMinutes, req.StoryPoints)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(task, "Task estimate updated successfully"))
+}
+
+// MoveTask handles POST /api/tasks/{task_id}/move
+func (h *taskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req MoveTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	task, err := h.taskService.MoveTask(ctx, taskID, req.Status, req.BeforeID, req.AfterID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(task, "Task moved successfully"))
+}
+
 // DeleteTask handles DELETE /api/projects/{project_id}/tasks/{task_id}
 func (h *taskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -402,7 +467,7 @@ func (h *taskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 
 	taskID := chi.URLParam(r, "task_id")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	err = h.taskService.DeleteTask(ctx, taskID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
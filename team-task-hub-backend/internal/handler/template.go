@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type templateHandler struct {
+	templateService service.TemplateService
+}
+
+func NewTemplateHandler(templateService service.TemplateService) *templateHandler {
+	return &templateHandler{templateService: templateService}
+}
+
+// CreateTaskTemplate handles POST /api/templates/tasks
+func (h *templateHandler) CreateTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	var req CreateTaskTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	template, err := h.templateService.CreateTaskTemplate(ctx, userID, req.Name, req.Title, req.Description, req.Priority, req.Checklist)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(template, "Task template created successfully"))
+}
+
+// ListTaskTemplates handles GET /api/templates/tasks
+func (h *templateHandler) ListTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	templates, err := h.templateService.ListTaskTemplates(ctx)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(templates, "Task templates retrieved successfully"))
+}
+
+// GetTaskTemplate handles GET /api/templates/tasks/{template_id}
+func (h *templateHandler) GetTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	templateID := chi.URLParam(r, "template_id")
+
+	ctx := r.Context()
+	template, err := h.templateService.GetTaskTemplate(ctx, templateID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(template, "Task template retrieved successfully"))
+}
+
+// DeleteTaskTemplate handles DELETE /api/templates/tasks/{template_id}
+func (h *templateHandler) DeleteTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	templateID := chi.URLParam(r, "template_id")
+
+	ctx := r.Context()
+	if err := h.templateService.DeleteTaskTemplate(ctx, templateID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Task template deleted successfully"))
+}
+
+// InstantiateTaskTemplate handles POST /api/templates/tasks/{template_id}/instantiate
+func (h *templateHandler) InstantiateTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	templateID := chi.URLParam(r, "template_id")
+
+	var req InstantiateTaskTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	task, err := h.templateService.InstantiateTaskTemplate(ctx, templateID, req.ProjectID, userID, req.AssigneeID, req.DueDate)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(task, "Task created from template successfully"))
+}
+
+// CreateProjectTemplate handles POST /api/templates/projects
+func (h *templateHandler) CreateProjectTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	var req CreateProjectTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	tasks := make([]domain.ProjectTemplateTask, 0, len(req.Tasks))
+	for _, t := range req.Tasks {
+		tasks = append(tasks, domain.ProjectTemplateTask{
+			Title:         t.Title,
+			Description:   t.Description,
+			Priority:      t.Priority,
+			DueOffsetDays: t.DueOffsetDays,
+		})
+	}
+
+	ctx := r.Context()
+	template, err := h.templateService.CreateProjectTemplate(ctx, userID, req.Name, req.Description, tasks)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(template, "Project template created successfully"))
+}
+
+// CreateProjectTemplateFromProject handles POST /api/projects/{project_id}/template
+func (h *templateHandler) CreateProjectTemplateFromProject(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	var req CreateProjectTemplateFromProjectRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(NewErrorResponse(err))
+			return
+		}
+	}
+
+	ctx := r.Context()
+	template, err := h.templateService.CreateProjectTemplateFromProject(ctx, projectID, userID, req.Name)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(template, "Project template created successfully"))
+}
+
+// ListProjectTemplates handles GET /api/templates/projects
+func (h *templateHandler) ListProjectTemplates(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	templates, err := h.templateService.ListProjectTemplates(ctx)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(templates, "Project templates retrieved successfully"))
+}
+
+// GetProjectTemplate handles GET /api/templates/projects/{template_id}
+func (h *templateHandler) GetProjectTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	templateID := chi.URLParam(r, "template_id")
+
+	ctx := r.Context()
+	template, err := h.templateService.GetProjectTemplate(ctx, templateID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(template, "Project template retrieved successfully"))
+}
+
+// DeleteProjectTemplate handles DELETE /api/templates/projects/{template_id}
+func (h *templateHandler) DeleteProjectTemplate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	templateID := chi.URLParam(r, "template_id")
+
+	ctx := r.Context()
+	if err := h.templateService.DeleteProjectTemplate(ctx, templateID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Project template deleted successfully"))
+}
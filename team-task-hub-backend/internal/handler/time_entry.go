@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type timeEntryHandler struct {
+	timeEntryService service.TimeEntryService
+}
+
+func NewTimeEntryHandler(timeEntryService service.TimeEntryService) *timeEntryHandler {
+	return &timeEntryHandler{timeEntryService: timeEntryService}
+}
+
+// LogTime handles POST /api/tasks/{task_id}/time-entries
+func (h *timeEntryHandler) LogTime(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req CreateTimeEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	entry, err := h.timeEntryService.LogTime(ctx, taskID, userID, req.StartedAt, req.EndedAt, req.DurationMinutes, req.Note)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(entry, "Time entry created successfully"))
+}
+
+// ListTaskEntries handles GET /api/tasks/{task_id}/time-entries
+func (h *timeEntryHandler) ListTaskEntries(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	ctx := r.Context()
+	entries, err := h.timeEntryService.ListTaskEntries(ctx, taskID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(entries, "Time entries retrieved successfully"))
+}
+
+// DeleteEntry handles DELETE /api/time-entries/{entry_id}
+func (h *timeEntryHandler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	entryID := chi.URLParam(r, "entry_id")
+
+	ctx := r.Context()
+	if err := h.timeEntryService.DeleteEntry(ctx, entryID, userID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "Time entry deleted successfully"))
+}
+
+// StartTimer handles POST /api/tasks/{task_id}/timer/start
+func (h *timeEntryHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	taskID := chi.URLParam(r, "task_id")
+
+	var req StartTimerRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(NewErrorResponse(err))
+			return
+		}
+	}
+
+	ctx := r.Context()
+	entry, err := h.timeEntryService.StartTimer(ctx, taskID, userID, req.Note)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(NewSuccessResponse(entry, "Timer started successfully"))
+}
+
+// StopTimer handles POST /api/timer/stop
+func (h *timeEntryHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	entry, err := h.timeEntryService.StopTimer(ctx, userID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(entry, "Timer stopped successfully"))
+}
+
+// GetRunningTimer handles GET /api/timer
+func (h *timeEntryHandler) GetRunningTimer(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	entry, err := h.timeEntryService.GetRunningTimer(ctx, userID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(entry, "Running timer retrieved successfully"))
+}
+
+// Timesheet handles GET /api/reports/timesheet
+// Query params: from, to (YYYY-MM-DD, inclusive; defaults to the last 7 days),
+// user_id, project_id, group_by (comma separated: user, project, day) and
+// format=csv (or Accept: text/csv) for a CSV download.
+func (h *timeEntryHandler) Timesheet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	query := r.URL.Query()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	filter := domain.TimesheetFilter{
+		From:      today.AddDate(0, 0, -6),
+		To:        today.AddDate(0, 0, 1),
+		UserID:    query.Get("user_id"),
+		ProjectID: query.Get("project_id"),
+	}
+
+	if from := query.Get("from"); from != "" {
+		t, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			appErr := apperrors.NewValidationError(apperrors.ErrInvalidInput, "from must be a date in YYYY-MM-DD format")
+			w.WriteHeader(appErr.StatusCode())
+			json.NewEncoder(w).Encode(NewErrorResponse(appErr))
+			return
+		}
+		filter.From = t
+	}
+
+	if to := query.Get("to"); to != "" {
+		t, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			appErr := apperrors.NewValidationError(apperrors.ErrInvalidInput, "to must be a date in YYYY-MM-DD format")
+			w.WriteHeader(appErr.StatusCode())
+			json.NewEncoder(w).Encode(NewErrorResponse(appErr))
+			return
+		}
+		filter.To = t.AddDate(0, 0, 1)
+	}
+
+	if groupBy := query.Get("group_by"); groupBy != "" {
+		filter.GroupBy = strings.Split(groupBy, ",")
+	}
+
+	ctx := r.Context()
+	rows, err := h.timeEntryService.Timesheet(ctx, filter)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	if query.Get("format") == "csv" || strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		writeTimesheetCSV(w, rows)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(rows, "Timesheet retrieved successfully"))
+}
+
+// writeTimesheetCSV writes timesheet rows as a CSV attachment
+func writeTimesheetCSV(w http.ResponseWriter, rows []domain.TimesheetRow) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="timesheet.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"user_id", "user_email", "user_name", "project_id", "project_name", "day", "hours", "entries"})
+	for _, row := range rows {
+		writer.Write([]string{
+			derefCSV(row.UserID),
+			derefCSV(row.UserEmail),
+			derefCSV(row.UserName),
+			derefCSV(row.ProjectID),
+			derefCSV(row.ProjectName),
+			derefCSV(row.Day),
+			strconv.FormatFloat(row.Hours, 'f', 2, 64),
+			strconv.Itoa(row.Entries),
+		})
+	}
+	writer.Flush()
+}
+
+func derefCSV(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return csvText(*s)
+}
+
+// csvText keeps user-entered text from being run as a formula when an
+// export is opened in a spreadsheet, by prefixing cells that start with one
+// of the characters spreadsheets treat as a formula with a quote
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"strconv"
 
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
 	"github.com/launchventures/team-task-hub-backend/internal/service"
 	"github.com/launchventures/team-task-hub-backend/internal/utils"
 )
@@ -23,7 +24,7 @@ func NewUserHandler(userService service.UserService) *userHandler {
 func (h *userHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	defer func() {
 		if rec := recover(); rec != nil {
-			log.Printf("[SignUp] PANIC: %v", rec)
+			slog.ErrorContext(r.Context(), "panic recovered in signup", "panic", rec)
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(NewErrorResponse(nil))
@@ -34,20 +35,15 @@ func (h *userHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 
 	var req SignUpRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Printf("[SignUp] Decode error: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(NewErrorResponse(err))
 		return
 	}
 
-	log.Printf("[SignUp] Email: %s, Password length: %d", req.Email, len(req.Password))
-
-	ctx := context.Background()
-	user, token, err := h.userService.SignUp(ctx, req.Email, req.Password)
+	ctx := r.Context()
+	result, err := h.userService.SignUp(ctx, req.Email, req.Password)
 	if err != nil {
-		log.Printf("[SignUp] Service error: %v, Type: %T", err, err)
 		statusCode := ErrorToStatusCode(err)
-		log.Printf("[SignUp] Status code: %d", statusCode)
 		w.WriteHeader(statusCode)
 		errResp := NewErrorResponse(err)
 		errResp.Message = fmt.Sprintf("%v", err) // Add actual error message for debugging
@@ -55,15 +51,8 @@ func (h *userHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Printf("[SignUp] User created: %s", user.ID)
-
-	authResp := AuthResponse{
-		User:  user,
-		Token: token,
-	}
-
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(NewSuccessResponse(authResp, "User registered successfully"))
+	json.NewEncoder(w).Encode(NewSuccessResponse(result, "User registered successfully"))
 }
 
 // Login handles POST /api/auth/login
@@ -77,21 +66,67 @@ func (h *userHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
-	user, token, err := h.userService.Login(ctx, req.Email, req.Password)
+	ctx := r.Context()
+	result, err := h.userService.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	message := "Login successful"
+	if result.ChallengeToken != "" {
+		message = "Two-factor authentication required"
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(result, message))
+}
+
+// VerifyTwoFactor handles POST /api/auth/login/2fa
+func (h *userHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req VerifyTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	result, err := h.userService.VerifyTwoFactor(ctx, req.ChallengeToken, req.Code)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
 		json.NewEncoder(w).Encode(NewErrorResponse(err))
 		return
 	}
 
-	authResp := AuthResponse{
-		User:  user,
-		Token: token,
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(result, "Login successful"))
+}
+
+// StartTwoFactorEnrollment handles POST /api/auth/login/2fa/enroll
+func (h *userHandler) StartTwoFactorEnrollment(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req TwoFactorChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	enrollment, err := h.userService.StartTwoFactorEnrollment(ctx, req.ChallengeToken)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(NewSuccessResponse(authResp, "Login successful"))
+	json.NewEncoder(w).Encode(NewSuccessResponse(enrollment, "Two-factor enrollment started"))
 }
 
 // GetProfile handles GET /api/auth/me
@@ -106,7 +141,7 @@ func (h *userHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	user, err := h.userService.GetProfile(ctx, userID)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -137,7 +172,7 @@ func (h *userHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	user, err := h.userService.UpdateProfile(ctx, userID, req.Name)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -161,7 +196,7 @@ func (h *userHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := context.Background()
+	ctx := r.Context()
 	users, err := h.userService.ListUsers(ctx)
 	if err != nil {
 		w.WriteHeader(ErrorToStatusCode(err))
@@ -172,3 +207,39 @@ func (h *userHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(NewSuccessResponse(users, "Users retrieved successfully"))
 }
+
+// ListLoginEvents handles GET /api/auth/me/logins
+func (h *userHandler) ListLoginEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	// Extract user ID from context (set by auth middleware)
+	userID, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil {
+			appErr := apperrors.NewValidationError(apperrors.ErrInvalidInput, "limit must be a number")
+			w.WriteHeader(appErr.StatusCode())
+			json.NewEncoder(w).Encode(NewErrorResponse(appErr))
+			return
+		}
+		limit = parsed
+	}
+
+	ctx := r.Context()
+	events, err := h.userService.ListLoginEvents(ctx, userID, limit)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(events, "Login events retrieved successfully"))
+}
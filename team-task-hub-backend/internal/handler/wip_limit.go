@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+type wipLimitHandler struct {
+	wipLimitService service.WIPLimitService
+}
+
+func NewWIPLimitHandler(wipLimitService service.WIPLimitService) *wipLimitHandler {
+	return &wipLimitHandler{wipLimitService: wipLimitService}
+}
+
+// GetLimits handles GET /api/projects/{project_id}/wip-limits
+func (h *wipLimitHandler) GetLimits(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	ctx := r.Context()
+	limits, err := h.wipLimitService.GetLimits(ctx, projectID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(limits, "WIP limits retrieved successfully"))
+}
+
+// SetLimits handles PUT /api/projects/{project_id}/wip-limits
+func (h *wipLimitHandler) SetLimits(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	var req SetWIPLimitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	ctx := r.Context()
+	limits, err := h.wipLimitService.SetLimits(ctx, projectID, req.StatusLimits, req.AssigneeInProgressLimit, req.Enforcement)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(limits, "WIP limits updated successfully"))
+}
+
+// DeleteLimits handles DELETE /api/projects/{project_id}/wip-limits
+func (h *wipLimitHandler) DeleteLimits(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	ctx := r.Context()
+	if err := h.wipLimitService.DeleteLimits(ctx, projectID); err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(nil, "WIP limits removed successfully"))
+}
+
+// GetBoardCounts handles GET /api/projects/{project_id}/board/counts
+func (h *wipLimitHandler) GetBoardCounts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_, err := utils.ExtractUserIDFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	projectID := chi.URLParam(r, "project_id")
+
+	ctx := r.Context()
+	counts, err := h.wipLimitService.GetBoardCounts(ctx, projectID)
+	if err != nil {
+		w.WriteHeader(ErrorToStatusCode(err))
+		json.NewEncoder(w).Encode(NewErrorResponse(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(NewSuccessResponse(counts, "Board counts retrieved successfully"))
+}
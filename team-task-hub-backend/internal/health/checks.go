@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/migration"
+)
+
+// DatabaseCheck pings the connection pool
+func DatabaseCheck(pool *pgxpool.Pool) Check {
+	return Check{
+		Name:     "database",
+		Critical: true,
+		Run:      pool.Ping,
+	}
+}
+
+// MigrationCheck verifies the database schema is clean and at least at the
+// version the binary expects
+func MigrationCheck(pool *pgxpool.Pool, expected uint) Check {
+	return Check{
+		Name:     "migrations",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			version, dirty, err := migration.CurrentVersion(ctx, pool)
+			if err != nil {
+				return err
+			}
+			if dirty {
+				return fmt.Errorf("schema version %d is dirty", version)
+			}
+			if version < expected {
+				return fmt.Errorf("schema version is %d, expected %d", version, expected)
+			}
+			return nil
+		},
+	}
+}
+
+// WorkerCheck reports a background worker's health without affecting readiness
+func WorkerCheck(name string, check func(ctx context.Context) error) Check {
+	return Check{
+		Name: name,
+		Run:  check,
+	}
+}
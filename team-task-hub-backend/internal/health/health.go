@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// checkTimeout bounds each individual check
+const checkTimeout = 2 * time.Second
+
+// Check is a named dependency check. A failing critical check makes the
+// service unready; a failing non-critical check only marks it degraded.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of one check
+type CheckResult struct {
+	Status     string  `json:"status"`
+	Critical   bool    `json:"critical"`
+	DurationMS float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of all checks: ok, degraded or fail
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker runs readiness checks
+type Checker struct {
+	checks []Check
+}
+
+func NewChecker(checks ...Check) *Checker {
+	return &Checker{checks: checks}
+}
+
+// Run runs every check concurrently and combines the results
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{Status: "ok", Checks: make(map[string]CheckResult, len(c.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status == "fail" {
+				if check.Critical {
+					report.Status = "fail"
+				} else if report.Status == "ok" {
+					report.Status = "degraded"
+				}
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := CheckResult{
+		Status:     "ok",
+		Critical:   check.Critical,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = "fail"
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,135 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Signing algorithms, as named in JWT alg headers
+const (
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+// rsaKeyBits is the size of generated RSA keys
+const rsaKeyBits = 2048
+
+// Key is a signing key pair. It signs tokens from ActivatesAt until a newer
+// key activates, and verifies them for as long as it is in a Ring.
+type Key struct {
+	ID          string
+	Algorithm   string
+	Private     crypto.Signer
+	ActivatesAt time.Time
+}
+
+// Generate creates a key for algorithm that signs from activatesAt
+func Generate(algorithm string, activatesAt time.Time) (*Key, error) {
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case RS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case EdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s key: %w", algorithm, err)
+	}
+
+	return &Key{
+		ID:          uuid.New().String(),
+		Algorithm:   algorithm,
+		Private:     private,
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+// MarshalPrivate encodes the private key as PKCS #8 DER
+func (k *Key) MarshalPrivate() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.Private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key %s: %w", k.ID, err)
+	}
+	return der, nil
+}
+
+// Parse restores a key stored with MarshalPrivate
+func Parse(id, algorithm string, privateDER []byte, activatesAt time.Time) (*Key, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(privateDER)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key %s: %w", id, err)
+	}
+
+	var private crypto.Signer
+	switch p := parsed.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == RS256 {
+			private = p
+		}
+	case ed25519.PrivateKey:
+		if algorithm == EdDSA {
+			private = p
+		}
+	}
+	if private == nil {
+		return nil, fmt.Errorf("key %s is a %T, not an %s key", id, parsed, algorithm)
+	}
+
+	return &Key{ID: id, Algorithm: algorithm, Private: private, ActivatesAt: activatesAt}, nil
+}
+
+// Method returns the JWT signing method for the key
+func (k *Key) Method() jwt.SigningMethod {
+	if k.Algorithm == EdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// JWK is a public key in JSON Web Key form (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 curve and public key (RFC 8037)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK returns the public half of the key
+func (k *Key) JWK() JWK {
+	jwk := JWK{KeyID: k.ID, Use: "sig", Algorithm: k.Algorithm}
+	switch public := k.Private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
@@ -0,0 +1,122 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Ring is the set of keys tokens are signed and verified with. It is
+// replaced as a whole when keys are rotated, and safe for concurrent use.
+// Ring implements utils.KeySet.
+type Ring struct {
+	algorithm string
+
+	mu   sync.RWMutex
+	keys []*Key // newest activation first
+	byID map[string]*Key
+}
+
+// NewRing returns an empty ring for keys of algorithm
+func NewRing(algorithm string) *Ring {
+	return &Ring{algorithm: algorithm, byID: map[string]*Key{}}
+}
+
+// Set replaces the keys in the ring
+func (r *Ring) Set(keys []*Key) {
+	sorted := append([]*Key(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].ActivatesAt.Equal(sorted[j].ActivatesAt) {
+			return sorted[i].ActivatesAt.After(sorted[j].ActivatesAt)
+		}
+		return sorted[i].ID > sorted[j].ID
+	})
+
+	byID := make(map[string]*Key, len(sorted))
+	for _, k := range sorted {
+		byID[k.ID] = k
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = sorted
+	r.byID = byID
+}
+
+// Current returns the key that signs now: the most recently activated one
+func (r *Ring) Current() (*Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	for _, k := range r.keys {
+		if !k.ActivatesAt.After(now) {
+			return k, nil
+		}
+	}
+	return nil, errors.New("no active signing key")
+}
+
+// SigningKey returns the current key for signing a token
+func (r *Ring) SigningKey() (string, jwt.SigningMethod, any, error) {
+	k, err := r.Current()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return k.ID, k.Method(), k.Private, nil
+}
+
+// VerificationKey returns the public key with ID kid, if it uses alg
+func (r *Ring) VerificationKey(kid, alg string) (any, error) {
+	r.mu.RLock()
+	k, ok := r.byID[kid]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if k.Algorithm != alg {
+		return nil, fmt.Errorf("key %q is for %s, not %s", kid, k.Algorithm, alg)
+	}
+	return k.Private.Public(), nil
+}
+
+// Algorithms lists the algorithms of the keys in the ring, and of the
+// configured algorithm
+func (r *Ring) Algorithms() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	algorithms := []string{r.algorithm}
+	for _, k := range r.keys {
+		if !slices.Contains(algorithms, k.Algorithm) {
+			algorithms = append(algorithms, k.Algorithm)
+		}
+	}
+	return algorithms
+}
+
+// JWKS returns the public keys of the ring, including keys not yet active so
+// verifiers know them before they sign
+func (r *Ring) JWKS() JWKS {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := JWKS{Keys: make([]JWK, 0, len(r.keys))}
+	for _, k := range r.keys {
+		set.Keys = append(set.Keys, k.JWK())
+	}
+	return set
+}
+
+// Len returns the number of keys in the ring
+func (r *Ring) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.keys)
+}
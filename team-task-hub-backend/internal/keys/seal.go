@@ -0,0 +1,66 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// KeyEncryptionKeySize is the length of the key-encryption key: AES-256
+const KeyEncryptionKeySize = 32
+
+// Sealer encrypts secrets for storage, signing keys' private halves and TOTP
+// secrets, with AES-GCM under a key-encryption key, so a copy of the database
+// alone can't sign tokens or generate codes
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// NewSealer returns a sealer for the base64 encoded key-encryption key
+func NewSealer(encodedKEK string) (*Sealer, error) {
+	kek, err := base64.StdEncoding.DecodeString(encodedKEK)
+	if err != nil {
+		return nil, fmt.Errorf("key-encryption key is not valid base64: %w", err)
+	}
+	if len(kek) != KeyEncryptionKeySize {
+		return nil, fmt.Errorf("key-encryption key must be %d bytes, got %d", KeyEncryptionKeySize, len(kek))
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal encrypts the secret of the record with the given ID, such as a key ID
+// or user ID, returning the nonce followed by the ciphertext. The ID is
+// authenticated too, so a sealed secret can't be moved to another record.
+func (s *Sealer) Seal(id string, secret []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(secret)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return s.aead.Seal(nonce, nonce, secret, []byte(id)), nil
+}
+
+// Open decrypts a secret sealed with Seal for the same ID
+func (s *Sealer) Open(id string, sealed []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, errors.New("sealed secret is too short")
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+
+	secret, err := s.aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the secret of %s with the configured key-encryption key", id)
+	}
+	return secret, nil
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	requestFieldsKey
+)
+
+// RequestFields are per-request values filled in as the request passes
+// through the middleware chain and logged once it completes
+type RequestFields struct {
+	UserID string
+}
+
+// New builds a JSON logger writing to w at the given level (debug, info,
+// warn or error; anything else means info). Sensitive attributes are
+// redacted and request IDs carried by the context are attached to every
+// record logged with a *Context method.
+func New(w io.Writer, level string) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       ParseLevel(level),
+		ReplaceAttr: redact,
+	})
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// ParseLevel converts a level name to a slog level, defaulting to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithRequestFields returns a context carrying fields for the request log line
+func WithRequestFields(ctx context.Context, fields *RequestFields) context.Context {
+	return context.WithValue(ctx, requestFieldsKey, fields)
+}
+
+// SetUserID records the authenticated user on the request log line
+func SetUserID(ctx context.Context, userID string) {
+	if fields, ok := ctx.Value(requestFieldsKey).(*RequestFields); ok {
+		fields.UserID = userID
+	}
+}
+
+// contextHandler adds the request ID and trace IDs from the record's context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// redact hides secrets entirely and masks email addresses down to their
+// first character and domain. Any key containing "token" is hidden, so log
+// the IDs of tokens under keys without it, such as pat_id.
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+
+	switch {
+	case strings.Contains(key, "password"),
+		strings.Contains(key, "token"),
+		strings.Contains(key, "secret"),
+		key == "authorization",
+		key == "cookie":
+		return slog.String(attr.Key, "[REDACTED]")
+	case strings.Contains(key, "email"):
+		return slog.String(attr.Key, MaskEmail(attr.Value.String()))
+	}
+
+	return attr
+}
+
+// MaskEmail keeps an address's first character and domain, e.g. j***@example.com
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return "[REDACTED]"
+	}
+	return email[:1] + "***" + email[at:]
+}
@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every metric exposed on /metrics. A dedicated registry keeps
+// the exposition free of anything registered globally by dependencies.
+var Registry = prometheus.NewRegistry()
+
+// Sources of created tasks, as labelled on TasksCreated
+const (
+	TaskSourceUser            = "user"
+	TaskSourceTaskTemplate    = "task_template"
+	TaskSourceProjectTemplate = "project_template"
+	TaskSourceRecurrence      = "recurrence"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled, by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency, by method, route pattern and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// TasksCreated counts created tasks by source, one of the TaskSource
+	// values below
+	TasksCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tasks_created_total",
+		Help: "Tasks created, by source.",
+	}, []string{"source"})
+
+	// StatusTransitions counts task status changes
+	StatusTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "task_status_transitions_total",
+		Help: "Task status changes, by previous and new status.",
+	}, []string{"from", "to"})
+
+	// CommentsPosted counts comments posted on tasks
+	CommentsPosted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "comments_posted_total",
+		Help: "Comments posted on tasks.",
+	})
+
+	// LoginAttempts counts sign-in attempts by outcome: success,
+	// invalid_credentials, locked or ip_blocked
+	LoginAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "login_attempts_total",
+		Help: "Sign-in attempts, by result.",
+	}, []string{"result"})
+
+	// RateLimited counts requests rejected by the rate limiter, by route group
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limited_requests_total",
+		Help: "Requests rejected with 429, by rate limit group.",
+	}, []string{"group"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		TasksCreated,
+		StatusTransitions,
+		CommentsPosted,
+		LoginAttempts,
+		RateLimited,
+	)
+}
+
+// RegisterPool exposes a connection pool's statistics
+func RegisterPool(pool *pgxpool.Pool) {
+	Registry.MustRegister(newPoolCollector(pool))
+}
+
+// Middleware records request counts and latency per chi route pattern. It
+// must be installed on the root router so the pattern is complete once the
+// request has been routed.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		// Unmatched paths share one label so scanners can't explode cardinality
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		labels := prometheus.Labels{"method": r.Method, "route": route, "status": strconv.Itoa(status)}
+		httpRequests.With(labels).Inc()
+		httpDuration.With(labels).Observe(time.Since(start).Seconds())
+	})
+}
+
+// Handler serves the metrics exposition. When token is set, scrapers must
+// send it as a bearer token.
+func Handler(token string) http.Handler {
+	exposition := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	if token == "" {
+		return exposition
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		exposition.ServeHTTP(w, r)
+	})
+}
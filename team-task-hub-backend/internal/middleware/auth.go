@@ -3,53 +3,176 @@ package middleware
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
-	"time"
 
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/logging"
 	"github.com/launchventures/team-task-hub-backend/internal/utils"
 )
 
-// AuthMiddleware validates JWT tokens and adds user ID to context
-func AuthMiddleware(next http.Handler) http.Handler {
+// AccessTokenAuthenticator resolves personal access tokens
+type AccessTokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*domain.AccessToken, error)
+}
+
+// SessionValidator rejects session tokens that have been revoked
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, userID string, sessionGeneration int) error
+}
+
+// AdminChecker reports whether a user holds the admin role
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+}
+
+// AuthMiddleware validates bearer tokens and adds the user ID to the context.
+// It accepts session JWTs that sessions hasn't revoked, and personal access
+// tokens through tokens; requests with a personal access token also carry its
+// scopes, which RequireScope and SessionOnly check.
+func AuthMiddleware(tokens AccessTokenAuthenticator, sessions SessionValidator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Get authorization header
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				http.Error(w, `{"status":"error","error":"Unauthorized","message":"missing authorization header"}`, http.StatusUnauthorized)
+				return
+			}
+
+			// Extract bearer token
+			parts := strings.Split(authHeader, " ")
+			if len(parts) != 2 || parts[0] != "Bearer" {
+				http.Error(w, `{"status":"error","error":"Unauthorized","message":"invalid authorization header format"}`, http.StatusUnauthorized)
+				return
+			}
+
+			token := parts[1]
+			ctx := r.Context()
+
+			if utils.IsAccessToken(token) {
+				accessToken, err := tokens.Authenticate(ctx, token)
+				if err != nil {
+					writeAuthError(w, err)
+					return
+				}
+
+				logging.SetUserID(ctx, accessToken.UserID)
+				ctx = context.WithValue(ctx, "user_id", accessToken.UserID)
+				ctx = context.WithValue(ctx, "user_email", accessToken.UserEmail)
+				ctx = utils.WithAccessTokenScopes(ctx, accessToken.Scopes)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			// Validate token
+			claims, appErr := utils.ValidateToken(token)
+			if appErr != nil {
+				writeAuthError(w, appErr)
+				return
+			}
+			if err := sessions.ValidateSession(ctx, claims.UserID, claims.SessionGeneration); err != nil {
+				writeAuthError(w, err)
+				return
+			}
+
+			// Add user ID and email to context
+			logging.SetUserID(ctx, claims.UserID)
+			ctx = context.WithValue(ctx, "user_id", claims.UserID)
+			ctx = context.WithValue(ctx, "user_email", claims.Email)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope limits what personal access tokens may do on a route group:
+// reads (GET and HEAD) need the read scope, anything else needs writeScope.
+// Requests with a session token pass unchecked.
+func RequireScope(writeScope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, ok := utils.AccessTokenScopesFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			needed := writeScope
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				needed = domain.ScopeRead
+			}
+			if !slices.Contains(scopes, needed) {
+				writeAuthError(w, apperrors.NewAuthError(apperrors.ErrInsufficientScope,
+					fmt.Sprintf("this access token needs the %s scope", needed)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SessionOnly refuses personal access tokens, for routes that manage the
+// account itself: a leaked token must not be able to mint more tokens or turn
+// off two-factor authentication
+func SessionOnly(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, `{"status":"error","error":"Unauthorized","message":"missing authorization header"}`, http.StatusUnauthorized)
+		if _, ok := utils.AccessTokenScopesFromContext(r.Context()); ok {
+			writeAuthError(w, apperrors.NewAuthError(apperrors.ErrInsufficientScope,
+				"access tokens cannot be used to manage the account; sign in instead"))
 			return
 		}
 
-		// Extract bearer token
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, `{"status":"error","error":"Unauthorized","message":"invalid authorization header format"}`, http.StatusUnauthorized)
-			return
-		}
+		next.ServeHTTP(w, r)
+	})
+}
 
-		token := parts[1]
-
-		// Validate token
-		claims, appErr := utils.ValidateToken(token)
-		if appErr != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(appErr.StatusCode())
-			errResp := map[string]interface{}{
-				"status":  "error",
-				"error":   appErr.Code,
-				"message": appErr.Message,
+// RequireAdmin refuses users without the admin role. The role is looked up on
+// every request, so revoking it takes effect immediately.
+func RequireAdmin(admins AdminChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, err := utils.ExtractUserIDFromContext(r.Context())
+			if err != nil {
+				writeAuthError(w, err)
+				return
 			}
-			json.NewEncoder(w).Encode(errResp)
-			return
-		}
 
-		// Add user ID and email to context
-		ctx := context.WithValue(r.Context(), "user_id", claims.UserID)
-		ctx = context.WithValue(ctx, "user_email", claims.Email)
+			isAdmin, err := admins.IsAdmin(r.Context(), userID)
+			if err != nil {
+				writeAuthError(w, err)
+				return
+			}
+			if !isAdmin {
+				writeAuthError(w, apperrors.NewAuthError(apperrors.ErrForbidden, "this action requires an administrator"))
+				return
+			}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeAuthError writes an authentication or authorization failure
+func writeAuthError(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*apperrors.AppError)
+	if !ok {
+		appErr = apperrors.NewInternalError("authentication failed", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.StatusCode())
+	errResp := map[string]interface{}{
+		"status":  "error",
+		"error":   appErr.Code,
+		"message": appErr.Message,
+	}
+	json.NewEncoder(w).Encode(errResp)
 }
 
 // ErrorMiddleware handles and formats API errors consistently, with panic recovery
@@ -57,7 +180,7 @@ func ErrorMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Printf("ERROR: panic recovered in %s %s: %v", r.Method, r.URL.Path, err)
+				slog.ErrorContext(r.Context(), "panic recovered", "method", r.Method, "path", r.URL.Path, "panic", err)
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusInternalServerError)
 				errResp := map[string]interface{}{
@@ -72,14 +195,3 @@ func ErrorMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
-
-// LoggingMiddleware logs HTTP requests and responses
-func LoggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		startTime := time.Now()
-		log.Printf("[REQUEST] %s %s", r.Method, r.URL.Path)
-		next.ServeHTTP(w, r)
-		duration := time.Since(startTime).Milliseconds()
-		log.Printf("[RESPONSE] %s %s - %dms", r.Method, r.URL.Path, duration)
-	})
-}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// ClientInfoMiddleware resolves the client IP and user agent once and stores
+// them in the request context. Set trustProxy only behind a reverse proxy
+// that sets X-Forwarded-For.
+func ClientInfoMiddleware(trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			info := utils.ClientInfo{
+				IP:        utils.ClientIP(r, trustProxy),
+				UserAgent: r.UserAgent(),
+			}
+			next.ServeHTTP(w, r.WithContext(utils.WithClientInfo(r.Context(), info)))
+		})
+	}
+}
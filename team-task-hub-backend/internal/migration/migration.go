@@ -0,0 +1,62 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LatestVersion returns the highest migration version found in dir, which
+// holds golang-migrate files named like 000001_name.up.sql
+func LatestVersion(dir string) (uint, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", dir)
+	}
+	return latest, nil
+}
+
+// CurrentVersion reads the applied schema version and dirty flag from the
+// golang-migrate bookkeeping table. A database without migrations is at version 0.
+func CurrentVersion(ctx context.Context, db *pgxpool.Pool) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
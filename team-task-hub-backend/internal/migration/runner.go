@@ -0,0 +1,85 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// Status describes the database schema relative to the migrations on disk
+type Status struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+}
+
+// Runner applies the SQL migrations in a directory to a database. Every
+// operation refuses to run against a dirty database; a failed migration has
+// to be repaired by hand and recorded with Force.
+type Runner struct {
+	m    *migrate.Migrate
+	path string
+}
+
+func NewRunner(dbURL, path string) (*Runner, error) {
+	m, err := migrate.New("file://"+path, dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+	return &Runner{m: m, path: path}, nil
+}
+
+// Close releases the runner's database and source handles
+func (r *Runner) Close() error {
+	sourceErr, dbErr := r.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// Up applies every pending migration
+func (r *Runner) Up() error {
+	return ignoreNoChange(r.m.Up())
+}
+
+// Down rolls back the given number of applied migrations
+func (r *Runner) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("down needs at least one step")
+	}
+	return ignoreNoChange(r.m.Steps(-steps))
+}
+
+// To migrates up or down to the given version
+func (r *Runner) To(version uint) error {
+	return ignoreNoChange(r.m.Migrate(version))
+}
+
+// Force records version as applied and clears the dirty flag without running
+// any migration. It is the way out after repairing a failed migration by hand.
+func (r *Runner) Force(version int) error {
+	return r.m.Force(version)
+}
+
+// Status reports the applied version and the newest version on disk
+func (r *Runner) Status() (Status, error) {
+	latest, err := LatestVersion(r.path)
+	if err != nil {
+		return Status{}, err
+	}
+
+	version, dirty, err := r.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return Status{Version: version, Dirty: dirty, Latest: latest}, nil
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
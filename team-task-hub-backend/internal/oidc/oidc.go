@@ -0,0 +1,150 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/launchventures/team-task-hub-backend/internal/config"
+	"golang.org/x/oauth2"
+)
+
+// discoveryTimeout bounds fetching the provider's discovery document
+const discoveryTimeout = 10 * time.Second
+
+// Claims are the ID token claims used to sign a user in
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider runs the authorization code flow with PKCE against an OpenID
+// Connect provider. Discovery happens on first use and is retried until it
+// succeeds, so the API starts even while the provider is unreachable. Signing
+// keys are fetched from the provider's JWKS endpoint and refreshed when an
+// unknown key ID appears.
+type Provider struct {
+	cfg    config.OIDCConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	provider *gooidc.Provider
+}
+
+func NewProvider(cfg config.OIDCConfig) *Provider {
+	return &Provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: discoveryTimeout},
+	}
+}
+
+// discover returns the provider, fetching its discovery document if needed
+func (p *Provider) discover(ctx context.Context) (*gooidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider != nil {
+		return p.provider, nil
+	}
+
+	provider, err := gooidc.NewProvider(gooidc.ClientContext(ctx, p.client), p.cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider: %w", err)
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+func (p *Provider) oauth2Config(provider *gooidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       p.cfg.Scopes,
+	}
+}
+
+// AuthCodeURL returns the provider URL that starts a sign-in. The verifier's
+// S256 challenge is sent; the verifier itself is kept for Exchange.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return p.oauth2Config(provider).AuthCodeURL(state,
+		gooidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(verifier),
+	), nil
+}
+
+// Exchange redeems an authorization code and verifies the returned ID token:
+// signature, issuer, audience, expiry and nonce
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = gooidc.ClientContext(ctx, p.client)
+	token, err := p.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	idToken, err := provider.Verifier(&gooidc.Config{ClientID: p.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("ID token nonce does not match")
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified *bool  `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to read ID token claims: %w", err)
+	}
+
+	return &Claims{
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified != nil && *claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// Session holds the per-sign-in random values: the state that ties the
+// callback to the browser that started the sign-in, the nonce bound into the
+// ID token, and the PKCE code verifier
+type Session struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// NewSession returns a session with fresh random values
+func NewSession() Session {
+	return Session{
+		State:    oauth2.GenerateVerifier(),
+		Nonce:    oauth2.GenerateVerifier(),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// idleBucketTTL is how long an untouched bucket is kept. Any bucket idle for
+// longer has refilled under every configured limit.
+const idleBucketTTL = time.Hour
+
+// PostgresStore keeps buckets in the rate_limit_buckets table so every replica
+// shares the same limits. Each take is a single atomic upsert.
+type PostgresStore struct {
+	db *pgxpool.Pool
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresStore(db *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{db: db, lastSweep: time.Now()}
+}
+
+// Take removes one token from the bucket for key if one is available
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.maybeSweep()
+
+	// $2 is the burst and $3 the refill rate per second. SET expressions see
+	// the row as it was before the update, so the refilled level is the same
+	// in each; allowed records whether a token was taken.
+	query := `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+		VALUES ($1, $2::float8 - 1, TRUE, NOW())
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8 * $3::float8)
+				- CASE WHEN LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8 * $3::float8) >= 1 THEN 1 ELSE 0 END,
+			allowed = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8 * $3::float8) >= 1,
+			updated_at = NOW()
+		RETURNING tokens, allowed
+	`
+
+	var tokens float64
+	var allowed bool
+	err := s.db.QueryRow(ctx, query, key, float64(limit.Burst), limit.rate()).Scan(&tokens, &allowed)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	return result(limit, tokens, allowed), nil
+}
+
+// maybeSweep deletes idle buckets in the background at most once per
+// sweepInterval per replica
+func (s *PostgresStore) maybeSweep() {
+	s.mu.Lock()
+	due := time.Since(s.lastSweep) >= sweepInterval
+	if due {
+		s.lastSweep = time.Now()
+	}
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err := s.db.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < $1`, time.Now().Add(-idleBucketTTL))
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to sweep rate limit buckets", "error", err)
+		}
+	}()
+}
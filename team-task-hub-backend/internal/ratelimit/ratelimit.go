@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket: up to Burst requests at once, refilled at
+// PerMinute requests per minute
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+// rate returns the refill rate in tokens per second
+func (l Limit) rate() float64 {
+	return float64(l.PerMinute) / 60
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed bool
+	// Remaining is how many more requests the bucket allows right now
+	Remaining int
+	// RetryAfter is how long until the next request would be allowed; zero
+	// when Allowed
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+}
+
+// Store keeps token buckets by key
+type Store interface {
+	// Take removes one token from the bucket for key if one is available
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// result describes a bucket holding tokens after a take attempt
+func result(limit Limit, tokens float64, allowed bool) Result {
+	rate := limit.rate()
+	res := Result{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// AccessTokenRepository defines personal access token data access operations
+type AccessTokenRepository interface {
+	CreateToken(ctx context.Context, token *domain.AccessToken, tokenHash string, lifetime time.Duration) error
+	CountTokensByUserID(ctx context.Context, userID string) (int, error)
+	ListTokensByUserID(ctx context.Context, userID string) ([]domain.AccessToken, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*domain.AccessToken, bool, error)
+	TouchToken(ctx context.Context, id, ip string) error
+	DeleteToken(ctx context.Context, id, userID string) error
+}
+
+// lastUsedGranularity limits how often a token's last use is written, so a
+// busy script doesn't turn every request into a database write
+const lastUsedGranularity = time.Minute
+
+type accessTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAccessTokenRepository(db *pgxpool.Pool) AccessTokenRepository {
+	return &accessTokenRepository{db: db}
+}
+
+// CreateToken stores a new token expiring after lifetime, or never when
+// lifetime is zero
+func (r *accessTokenRepository) CreateToken(ctx context.Context, token *domain.AccessToken, tokenHash string, lifetime time.Duration) error {
+	const query = `
+		INSERT INTO personal_access_tokens (id, user_id, name, token_hash, prefix, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6,
+			CASE WHEN $7::float8 > 0 THEN NOW() + $7::float8 * INTERVAL '1 second' END,
+			NOW())
+		RETURNING expires_at, created_at
+	`
+
+	token.ID = uuid.New().String()
+	err := r.db.QueryRow(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Name,
+		tokenHash,
+		token.Prefix,
+		token.Scopes,
+		lifetime.Seconds(),
+	).Scan(&token.ExpiresAt, &token.CreatedAt)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to create access token", err)
+	}
+
+	return nil
+}
+
+// CountTokensByUserID counts a user's tokens, expired ones included
+func (r *accessTokenRepository) CountTokensByUserID(ctx context.Context, userID string) (int, error) {
+	const query = `SELECT COUNT(*) FROM personal_access_tokens WHERE user_id = $1`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, apperrors.NewDatabaseError("failed to count access tokens", err)
+	}
+
+	return count, nil
+}
+
+// ListTokensByUserID retrieves a user's tokens, newest first
+func (r *accessTokenRepository) ListTokensByUserID(ctx context.Context, userID string) ([]domain.AccessToken, error) {
+	const query = `
+		SELECT id, user_id, name, prefix, scopes, expires_at, last_used_at, last_used_ip, created_at
+		FROM personal_access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list access tokens", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]domain.AccessToken, 0)
+	for rows.Next() {
+		var t domain.AccessToken
+		err := rows.Scan(
+			&t.ID,
+			&t.UserID,
+			&t.Name,
+			&t.Prefix,
+			&t.Scopes,
+			&t.ExpiresAt,
+			&t.LastUsedAt,
+			&t.LastUsedIP,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan access token", err)
+		}
+		tokens = append(tokens, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating access tokens", err)
+	}
+
+	return tokens, nil
+}
+
+// GetTokenByHash retrieves a token and its owner's email, and reports whether
+// it has expired. Tokens of deactivated users are not found.
+func (r *accessTokenRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*domain.AccessToken, bool, error) {
+	const query = `
+		SELECT t.id, t.user_id, t.name, t.prefix, t.scopes, t.expires_at, t.last_used_at, t.last_used_ip, t.created_at,
+			u.email, t.expires_at IS NOT NULL AND t.expires_at <= NOW()
+		FROM personal_access_tokens t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.token_hash = $1 AND u.deactivated_at IS NULL
+	`
+
+	t := &domain.AccessToken{}
+	var expired bool
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.Prefix,
+		&t.Scopes,
+		&t.ExpiresAt,
+		&t.LastUsedAt,
+		&t.LastUsedIP,
+		&t.CreatedAt,
+		&t.UserEmail,
+		&expired,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, apperrors.NewNotFoundError(apperrors.ErrAccessTokenNotFound, "access token not found")
+		}
+		return nil, false, apperrors.NewDatabaseError("failed to get access token", err)
+	}
+
+	return t, expired, nil
+}
+
+// TouchToken records that a token was just used from ip
+func (r *accessTokenRepository) TouchToken(ctx context.Context, id, ip string) error {
+	const query = `
+		UPDATE personal_access_tokens
+		SET last_used_at = NOW(), last_used_ip = $2
+		WHERE id = $1
+		  AND (last_used_at IS NULL OR last_used_at < NOW() - $3::float8 * INTERVAL '1 second' OR last_used_ip IS DISTINCT FROM $2)
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, ip, lastUsedGranularity.Seconds()); err != nil {
+		return apperrors.NewDatabaseError("failed to record access token use", err)
+	}
+
+	return nil
+}
+
+// DeleteToken revokes one of a user's tokens
+func (r *accessTokenRepository) DeleteToken(ctx context.Context, id, userID string) error {
+	const query = `DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to delete access token", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFoundError(apperrors.ErrAccessTokenNotFound, "access token not found")
+	}
+
+	return nil
+}
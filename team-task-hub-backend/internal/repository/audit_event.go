@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// AuditEventRepository defines account audit log data access operations
+type AuditEventRepository interface {
+	RecordEvent(ctx context.Context, event *domain.AuditEvent) error
+	ListEventsByUserID(ctx context.Context, userID string, limit int) ([]domain.AuditEvent, error)
+}
+
+type auditEventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditEventRepository(db *pgxpool.Pool) AuditEventRepository {
+	return &auditEventRepository{db: db}
+}
+
+// RecordEvent stores an audit event
+func (r *auditEventRepository) RecordEvent(ctx context.Context, event *domain.AuditEvent) error {
+	const query = `
+		INSERT INTO audit_events (id, user_id, actor_id, action, details, ip_address, user_agent, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at
+	`
+
+	if event.Details == nil {
+		event.Details = map[string]string{}
+	}
+
+	event.ID = uuid.New().String()
+	err := r.db.QueryRow(ctx, query,
+		event.ID,
+		event.UserID,
+		event.ActorID,
+		event.Action,
+		event.Details,
+		event.IPAddress,
+		event.UserAgent,
+	).Scan(&event.CreatedAt)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to record audit event", err)
+	}
+
+	return nil
+}
+
+// ListEventsByUserID returns a user's most recent audit events, newest first
+func (r *auditEventRepository) ListEventsByUserID(ctx context.Context, userID string, limit int) ([]domain.AuditEvent, error) {
+	const query = `
+		SELECT id, user_id, actor_id, action, details, ip_address, user_agent, created_at
+		FROM audit_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list audit events", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.AuditEvent, 0)
+	for rows.Next() {
+		var event domain.AuditEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.ActorID,
+			&event.Action,
+			&event.Details,
+			&event.IPAddress,
+			&event.UserAgent,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan audit event", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list audit events", err)
+	}
+
+	return events, nil
+}
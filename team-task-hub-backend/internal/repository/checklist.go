@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// ChecklistRepository defines task checklist data access operations
+type ChecklistRepository interface {
+	CreateItem(ctx context.Context, taskID, content string, assigneeID *string) (*domain.ChecklistItem, error)
+	CreateItems(ctx context.Context, taskID string, contents []string) error
+	GetItemByID(ctx context.Context, id string) (*domain.ChecklistItem, error)
+	ListItemsByTaskID(ctx context.Context, taskID string) ([]domain.ChecklistItem, error)
+	UpdateItem(ctx context.Context, id, content string, isDone bool, assigneeID *string) (*domain.ChecklistItem, error)
+	ReorderItems(ctx context.Context, taskID string, itemIDs []string) error
+	DeleteItem(ctx context.Context, id string) error
+}
+
+type checklistRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewChecklistRepository(db *pgxpool.Pool) ChecklistRepository {
+	return &checklistRepository{db: db}
+}
+
+const checklistSelectQuery = `
+	SELECT ci.id, ci.task_id, ci.content, ci.is_done, ci.assignee_id, ci.position, ci.created_at, ci.updated_at,
+	       u.id, u.email, u.name
+	FROM task_checklist_items ci
+	LEFT JOIN users u ON ci.assignee_id = u.id
+`
+
+func scanChecklistItem(row pgx.Row) (*domain.ChecklistItem, error) {
+	item := &domain.ChecklistItem{}
+	var userID *string
+	var userEmail *string
+	var userName *string
+
+	err := row.Scan(
+		&item.ID,
+		&item.TaskID,
+		&item.Content,
+		&item.IsDone,
+		&item.AssigneeID,
+		&item.Position,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+		&userID,
+		&userEmail,
+		&userName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != nil && userEmail != nil {
+		item.Assignee = &domain.User{
+			ID:    *userID,
+			Email: *userEmail,
+			Name:  derefString(userName),
+		}
+	}
+
+	return item, nil
+}
+
+// CreateItem appends a checklist item to the end of a task's checklist
+func (r *checklistRepository) CreateItem(ctx context.Context, taskID, content string, assigneeID *string) (*domain.ChecklistItem, error) {
+	itemID := uuid.New().String()
+	const query = `
+		INSERT INTO task_checklist_items (id, task_id, content, assignee_id, position, created_at, updated_at)
+		SELECT $1, $2, $3, $4, COALESCE(MAX(position) + 1, 0), NOW(), NOW()
+		FROM task_checklist_items
+		WHERE task_id = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, itemID, taskID, content, assigneeID); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to create checklist item", err)
+	}
+
+	return r.GetItemByID(ctx, itemID)
+}
+
+// CreateItems appends several checklist items in order, e.g. when instantiating a template
+func (r *checklistRepository) CreateItems(ctx context.Context, taskID string, contents []string) error {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	const query = `
+		INSERT INTO task_checklist_items (id, task_id, content, position, created_at, updated_at)
+		SELECT uuid_generate_v4(), $1, item.content, item.ordinality - 1, NOW(), NOW()
+		FROM unnest($2::text[]) WITH ORDINALITY AS item(content, ordinality)
+	`
+
+	if _, err := r.db.Exec(ctx, query, taskID, contents); err != nil {
+		return apperrors.NewDatabaseError("failed to create checklist items", err)
+	}
+
+	return nil
+}
+
+// GetItemByID retrieves a checklist item by ID
+func (r *checklistRepository) GetItemByID(ctx context.Context, id string) (*domain.ChecklistItem, error) {
+	item, err := scanChecklistItem(r.db.QueryRow(ctx, checklistSelectQuery+` WHERE ci.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrChecklistItemNotFound, "checklist item not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get checklist item", err)
+	}
+
+	return item, nil
+}
+
+// ListItemsByTaskID retrieves a task's checklist in order
+func (r *checklistRepository) ListItemsByTaskID(ctx context.Context, taskID string) ([]domain.ChecklistItem, error) {
+	rows, err := r.db.Query(ctx, checklistSelectQuery+` WHERE ci.task_id = $1 ORDER BY ci.position ASC, ci.created_at ASC`, taskID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list checklist items", err)
+	}
+	defer rows.Close()
+
+	items := make([]domain.ChecklistItem, 0)
+	for rows.Next() {
+		item, err := scanChecklistItem(rows)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan checklist item", err)
+		}
+		items = append(items, *item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating checklist items", err)
+	}
+
+	return items, nil
+}
+
+// UpdateItem updates a checklist item's text, done flag and assignee
+func (r *checklistRepository) UpdateItem(ctx context.Context, id, content string, isDone bool, assigneeID *string) (*domain.ChecklistItem, error) {
+	const query = `
+		UPDATE task_checklist_items
+		SET content = $2, is_done = $3, assignee_id = $4, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, id, content, isDone, assigneeID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to update checklist item", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrChecklistItemNotFound, "checklist item not found")
+	}
+
+	return r.GetItemByID(ctx, id)
+}
+
+// ReorderItems rewrites item positions to follow the given order. The IDs must
+// be exactly the task's current items.
+func (r *checklistRepository) ReorderItems(ctx context.Context, taskID string, itemIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var existing int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM task_checklist_items WHERE task_id = $1`, taskID).Scan(&existing); err != nil {
+		return apperrors.NewDatabaseError("failed to count checklist items", err)
+	}
+	if existing != len(itemIDs) {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "item_ids must list every checklist item of the task exactly once")
+	}
+
+	const query = `
+		UPDATE task_checklist_items ci
+		SET position = item.ordinality - 1, updated_at = NOW()
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS item(id, ordinality)
+		WHERE ci.id = item.id AND ci.task_id = $1
+	`
+	result, err := tx.Exec(ctx, query, taskID, itemIDs)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to reorder checklist items", err)
+	}
+	if int(result.RowsAffected()) != len(itemIDs) {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "item_ids must list every checklist item of the task exactly once")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.NewDatabaseError("failed to commit checklist order", err)
+	}
+
+	return nil
+}
+
+// DeleteItem deletes a checklist item
+func (r *checklistRepository) DeleteItem(ctx context.Context, id string) error {
+	const query = `DELETE FROM task_checklist_items WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to delete checklist item", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFoundError(apperrors.ErrChecklistItemNotFound, "checklist item not found")
+	}
+
+	return nil
+}
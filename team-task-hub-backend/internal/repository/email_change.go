@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// EmailChangeRepository defines pending email change data access operations
+type EmailChangeRepository interface {
+	SaveRequest(ctx context.Context, userID string, request *domain.EmailChangeRequest, tokenHash string) error
+	ConfirmRequest(ctx context.Context, userID, tokenHash string) (*domain.User, error)
+}
+
+type emailChangeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEmailChangeRepository(db *pgxpool.Pool) EmailChangeRepository {
+	return &emailChangeRepository{db: db}
+}
+
+// SaveRequest stores a pending email change, replacing any earlier one so
+// only the latest confirmation link works
+func (r *emailChangeRepository) SaveRequest(ctx context.Context, userID string, request *domain.EmailChangeRequest, tokenHash string) error {
+	const query = `
+		INSERT INTO email_change_requests (user_id, new_email, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET new_email = EXCLUDED.new_email,
+		    token_hash = EXCLUDED.token_hash,
+		    expires_at = EXCLUDED.expires_at,
+		    created_at = EXCLUDED.created_at
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, request.NewEmail, tokenHash, request.ExpiresAt); err != nil {
+		return apperrors.NewDatabaseError("failed to save email change request", err)
+	}
+
+	return nil
+}
+
+// ConfirmRequest switches the user to the address of their pending email
+// change if tokenHash matches and it hasn't expired, and revokes their
+// sessions by starting a new session generation. The request is removed in the same
+// transaction, so each link works at most once, but stays usable if the
+// address has been taken meanwhile and the change fails.
+func (r *emailChangeRepository) ConfirmRequest(ctx context.Context, userID, tokenHash string) (*domain.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const consumeQuery = `
+		DELETE FROM email_change_requests
+		WHERE user_id = $1 AND token_hash = $2
+		RETURNING new_email, expires_at
+	`
+
+	request := &domain.EmailChangeRequest{}
+	err = tx.QueryRow(ctx, consumeQuery, userID, tokenHash).Scan(&request.NewEmail, &request.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidConfirmation, "confirmation link not found or already used")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get email change request", err)
+	}
+	if time.Now().After(request.ExpiresAt) {
+		// The expired request is removed all the same
+		if err := tx.Commit(ctx); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to commit transaction", err)
+		}
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidConfirmation, "confirmation link expired")
+	}
+
+	const updateQuery = `
+		UPDATE users
+		SET email = $2, session_generation = session_generation + 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(ctx, updateQuery, userID, request.NewEmail))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, "email") {
+			return nil, apperrors.NewConflictError(apperrors.ErrEmailExists, "email already exists")
+		}
+		return nil, apperrors.NewDatabaseError("failed to update email", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// IdentityRepository defines OpenID Connect identity and sign-in state data
+// access operations
+type IdentityRepository interface {
+	GetUserByIdentity(ctx context.Context, issuer, subject, email string) (*domain.User, error)
+	LinkIdentity(ctx context.Context, userID, issuer, subject, email string) error
+	CreateUserWithIdentity(ctx context.Context, email, name, issuer, subject string) (*domain.User, error)
+	SaveLoginState(ctx context.Context, state *domain.OIDCLoginState, ttl time.Duration) error
+	ConsumeLoginState(ctx context.Context, state string) (*domain.OIDCLoginState, error)
+}
+
+type identityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdentityRepository(db *pgxpool.Pool) IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+// GetUserByIdentity retrieves the user linked to issuer and subject, recording
+// the sign-in and the email the provider reported
+func (r *identityRepository) GetUserByIdentity(ctx context.Context, issuer, subject, email string) (*domain.User, error) {
+	const query = `
+		WITH identity AS (
+			UPDATE user_identities
+			SET email = $3, last_login_at = NOW()
+			WHERE issuer = $1 AND subject = $2
+			RETURNING user_id
+		)
+		SELECT ` + userColumns + `
+		FROM users u
+		JOIN identity i ON i.user_id = u.id
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, issuer, subject, email))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get user by identity", err)
+	}
+
+	return user, nil
+}
+
+// LinkIdentity links an existing user to a provider account
+func (r *identityRepository) LinkIdentity(ctx context.Context, userID, issuer, subject, email string) error {
+	if err := insertIdentity(ctx, r.db, userID, issuer, subject, email); err != nil {
+		return apperrors.NewDatabaseError("failed to link identity", err)
+	}
+
+	return nil
+}
+
+// CreateUserWithIdentity provisions a user for a provider account. The user
+// has no password, so they can only sign in through the provider.
+func (r *identityRepository) CreateUserWithIdentity(ctx context.Context, email, name, issuer, subject string) (*domain.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		INSERT INTO users (id, email, password_hash, name, created_at, updated_at)
+		VALUES ($1, $2, '', $3, NOW(), NOW())
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(ctx, query, uuid.New().String(), email, name))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, "email") {
+			return nil, apperrors.NewConflictError(apperrors.ErrEmailExists, "email already exists")
+		}
+		return nil, apperrors.NewDatabaseError("failed to create user", err)
+	}
+
+	if err := insertIdentity(ctx, tx, user.ID, issuer, subject, email); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to link identity", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return user, nil
+}
+
+// insertIdentity links userID to the provider account issuer and subject
+func insertIdentity(ctx context.Context, db execer, userID, issuer, subject, email string) error {
+	const query = `
+		INSERT INTO user_identities (id, user_id, issuer, subject, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+	`
+
+	_, err := db.Exec(ctx, query, uuid.New().String(), userID, issuer, subject, email)
+	return err
+}
+
+// SaveLoginState stores the state of a sign-in that expires after ttl, and
+// clears out states that were never used
+func (r *identityRepository) SaveLoginState(ctx context.Context, state *domain.OIDCLoginState, ttl time.Duration) error {
+	const cleanup = `DELETE FROM oidc_login_states WHERE expires_at < NOW()`
+	if _, err := r.db.Exec(ctx, cleanup); err != nil {
+		return apperrors.NewDatabaseError("failed to delete expired sign-in states", err)
+	}
+
+	const query = `
+		INSERT INTO oidc_login_states (state, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4::float8 * INTERVAL '1 second')
+		RETURNING expires_at
+	`
+
+	err := r.db.QueryRow(ctx, query, state.State, state.CodeVerifier, state.Nonce, ttl.Seconds()).Scan(&state.ExpiresAt)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to save sign-in state", err)
+	}
+
+	return nil
+}
+
+// ConsumeLoginState removes and returns an unexpired sign-in state, so each
+// state can complete at most one sign-in
+func (r *identityRepository) ConsumeLoginState(ctx context.Context, state string) (*domain.OIDCLoginState, error) {
+	const query = `
+		DELETE FROM oidc_login_states
+		WHERE state = $1
+		RETURNING state, code_verifier, nonce, expires_at, expires_at >= NOW()
+	`
+
+	s := &domain.OIDCLoginState{}
+	var valid bool
+	err := r.db.QueryRow(ctx, query, state).Scan(&s.State, &s.CodeVerifier, &s.Nonce, &s.ExpiresAt, &valid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewAuthError(apperrors.ErrInvalidSSOState, "sign-in session not found or already used")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get sign-in state", err)
+	}
+	if !valid {
+		return nil, apperrors.NewAuthError(apperrors.ErrInvalidSSOState, "sign-in session expired")
+	}
+
+	return s, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// LoginEventRepository defines sign-in attempt data access operations
+type LoginEventRepository interface {
+	RecordEvent(ctx context.Context, event *domain.LoginEvent) error
+	GetFailureStreak(ctx context.Context, email string, lookback time.Duration) (int, time.Duration, error)
+	CountFailuresByIP(ctx context.Context, ip string, window time.Duration) (int, error)
+	ListEventsByUserID(ctx context.Context, userID string, limit int) ([]domain.LoginEvent, error)
+}
+
+// guessFailures are the failure reasons that count towards lockouts: wrong
+// passwords and wrong two-factor codes
+var guessFailures = []string{domain.LoginFailureInvalidCredentials, domain.LoginFailureInvalidTwoFactor}
+
+type loginEventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginEventRepository(db *pgxpool.Pool) LoginEventRepository {
+	return &loginEventRepository{db: db}
+}
+
+// RecordEvent stores a sign-in attempt
+func (r *loginEventRepository) RecordEvent(ctx context.Context, event *domain.LoginEvent) error {
+	const query = `
+		INSERT INTO login_events (id, user_id, email, ip_address, user_agent, success, failure_reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at
+	`
+
+	event.ID = uuid.New().String()
+	err := r.db.QueryRow(ctx, query,
+		event.ID,
+		event.UserID,
+		event.Email,
+		event.IPAddress,
+		event.UserAgent,
+		event.Success,
+		event.FailureReason,
+	).Scan(&event.CreatedAt)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to record login event", err)
+	}
+
+	return nil
+}
+
+// GetFailureStreak counts wrong password or code attempts against email since its last
+// successful login, looking back at most lookback, and reports how long ago
+// the latest one was. Blocked attempts are not counted, so hammering a locked
+// account doesn't extend the lock.
+func (r *loginEventRepository) GetFailureStreak(ctx context.Context, email string, lookback time.Duration) (int, time.Duration, error) {
+	const query = `
+		SELECT COUNT(*), COALESCE(EXTRACT(EPOCH FROM NOW() - MAX(created_at)), 0)::float8
+		FROM login_events
+		WHERE email = $1
+		  AND NOT success
+		  AND failure_reason = ANY($2)
+		  AND created_at > NOW() - $3::float8 * INTERVAL '1 second'
+		  AND created_at > COALESCE(
+		      (SELECT MAX(created_at) FROM login_events WHERE email = $1 AND success),
+		      '-infinity'::timestamp
+		  )
+	`
+
+	var count int
+	var sinceLast float64
+	err := r.db.QueryRow(ctx, query, email, guessFailures, lookback.Seconds()).Scan(&count, &sinceLast)
+	if err != nil {
+		return 0, 0, apperrors.NewDatabaseError("failed to count failed logins", err)
+	}
+
+	return count, time.Duration(sinceLast * float64(time.Second)), nil
+}
+
+// CountFailuresByIP counts wrong password or code attempts from ip within window
+func (r *loginEventRepository) CountFailuresByIP(ctx context.Context, ip string, window time.Duration) (int, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM login_events
+		WHERE ip_address = $1
+		  AND NOT success
+		  AND failure_reason = ANY($2)
+		  AND created_at > NOW() - $3::float8 * INTERVAL '1 second'
+	`
+
+	var count int
+	err := r.db.QueryRow(ctx, query, ip, guessFailures, window.Seconds()).Scan(&count)
+	if err != nil {
+		return 0, apperrors.NewDatabaseError("failed to count failed logins", err)
+	}
+
+	return count, nil
+}
+
+// ListEventsByUserID returns a user's most recent sign-in attempts, newest first
+func (r *loginEventRepository) ListEventsByUserID(ctx context.Context, userID string, limit int) ([]domain.LoginEvent, error) {
+	const query = `
+		SELECT id, user_id, email, ip_address, user_agent, success, failure_reason, created_at
+		FROM login_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list login events", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.LoginEvent, 0)
+	for rows.Next() {
+		var event domain.LoginEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Email,
+			&event.IPAddress,
+			&event.UserAgent,
+			&event.Success,
+			&event.FailureReason,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan login event", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list login events", err)
+	}
+
+	return events, nil
+}
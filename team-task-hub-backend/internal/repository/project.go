@@ -14,6 +14,7 @@ import (
 // ProjectRepository defines project data access operations
 type ProjectRepository interface {
 	CreateProject(ctx context.Context, userID, createdByID string, name, description string) (*domain.Project, error)
+	CreateProjectWithTasks(ctx context.Context, userID, createdByID string, name, description string, seedTasks []domain.Task) (*domain.Project, error)
 	GetProjectByID(ctx context.Context, id string) (*domain.Project, error)
 	ListProjectsByUserID(ctx context.Context, userID string, limit, offset int) ([]domain.Project, int, error)
 	UpdateProject(ctx context.Context, id string, name, description string) (*domain.Project, error)
@@ -84,6 +85,44 @@ func (r *projectRepository) CreateProject(ctx context.Context, userID, createdBy
 	return project, nil
 }
 
+// CreateProjectWithTasks creates a project and its seed tasks in a single transaction
+func (r *projectRepository) CreateProjectWithTasks(ctx context.Context, userID, createdByID string, name, description string, seedTasks []domain.Task) (*domain.Project, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	projectID := uuid.New().String()
+	const insertProject = `
+		INSERT INTO projects (id, user_id, name, description, created_by_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+	`
+	if _, err := tx.Exec(ctx, insertProject, projectID, userID, name, description, createdByID); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to create project", err)
+	}
+
+	const insertTask = `
+		INSERT INTO tasks (id, project_id, title, description, status, priority, created_by_id, due_date, rank, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 'OPEN', $5, $6, $7, $8, NOW(), NOW())
+	`
+	for _, task := range seedTasks {
+		taskID := uuid.New().String()
+		if _, err := tx.Exec(ctx, insertTask, taskID, projectID, task.Title, task.Description, task.Priority, createdByID, task.DueDate, task.Rank); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to create seed task", err)
+		}
+		if err := recordStatusChange(ctx, tx, taskID, nil, "OPEN"); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to record task status", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit project", err)
+	}
+
+	return r.GetProjectByID(ctx, projectID)
+}
+
 // GetProjectByID retrieves a project by ID
 func (r *projectRepository) GetProjectByID(ctx context.Context, id string) (*domain.Project, error) {
 	const query = `
@@ -130,25 +169,34 @@ func (r *projectRepository) GetProjectByID(ctx context.Context, id string) (*dom
 	return project, nil
 }
 
-// ListProjectsByUserID retrieves all projects with pagination (shared across all users)
+// projectVisibleTo returns the condition for the projects, aliased p, the
+// user whose ID is bound to placeholder may see. Projects are shared across
+// the team, so any active user sees all of them. Everything that lists
+// projects or reports across them applies it, so they agree on who sees what.
+func projectVisibleTo(placeholder string) string {
+	return `EXISTS (SELECT 1 FROM users viewer WHERE viewer.id = ` + placeholder + ` AND viewer.deactivated_at IS NULL)`
+}
+
+// ListProjectsByUserID retrieves the projects userID can see with pagination
 func (r *projectRepository) ListProjectsByUserID(ctx context.Context, userID string, limit, offset int) ([]domain.Project, int, error) {
-	countQuery := `SELECT COUNT(*) FROM projects`
+	countQuery := `SELECT COUNT(*) FROM projects p WHERE ` + projectVisibleTo("$1")
 	var total int
-	err := r.db.QueryRow(ctx, countQuery).Scan(&total)
+	err := r.db.QueryRow(ctx, countQuery, userID).Scan(&total)
 	if err != nil {
 		return nil, 0, apperrors.NewDatabaseError("failed to count projects", err)
 	}
 
-	const query = `
+	query := `
 		SELECT p.id, p.user_id, p.name, p.description, p.created_by_id, p.created_at, p.updated_at,
 		       cb.id, cb.email, cb.name
 		FROM projects p
 		LEFT JOIN users cb ON p.created_by_id = cb.id
+		WHERE ` + projectVisibleTo("$3") + `
 		ORDER BY p.created_at DESC
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	rows, err := r.db.Query(ctx, query, limit, offset, userID)
 	if err != nil {
 		return nil, 0, apperrors.NewDatabaseError("failed to list projects", err)
 	}
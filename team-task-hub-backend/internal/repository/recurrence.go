@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// RecurrenceRepository defines task recurrence data access operations
+type RecurrenceRepository interface {
+	UpsertRecurrence(ctx context.Context, rec *domain.TaskRecurrence) (*domain.TaskRecurrence, error)
+	GetRecurrenceByTaskID(ctx context.Context, taskID string) (*domain.TaskRecurrence, error)
+	DeleteRecurrenceByTaskID(ctx context.Context, taskID string) error
+	ListDueScheduledRecurrences(ctx context.Context, now time.Time, limit int) ([]domain.TaskRecurrence, error)
+	AdvanceRecurrence(ctx context.Context, recurrenceID, currentTaskID string, next *domain.Task, followingOccurrenceAt *time.Time) (string, error)
+}
+
+type recurrenceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRecurrenceRepository(db *pgxpool.Pool) RecurrenceRepository {
+	return &recurrenceRepository{db: db}
+}
+
+const recurrenceColumns = `id, task_id, project_id, created_by_id, rrule, dtstart, generate_on, current_occurrence_at, next_occurrence_at, occurrence_count, created_at, updated_at`
+
+func scanRecurrence(row pgx.Row) (*domain.TaskRecurrence, error) {
+	rec := &domain.TaskRecurrence{}
+	err := row.Scan(
+		&rec.ID,
+		&rec.TaskID,
+		&rec.ProjectID,
+		&rec.CreatedByID,
+		&rec.RRule,
+		&rec.DTStart,
+		&rec.GenerateOn,
+		&rec.CurrentOccurrenceAt,
+		&rec.NextOccurrenceAt,
+		&rec.OccurrenceCount,
+		&rec.CreatedAt,
+		&rec.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// UpsertRecurrence creates or replaces the recurrence rule attached to a task
+func (r *recurrenceRepository) UpsertRecurrence(ctx context.Context, rec *domain.TaskRecurrence) (*domain.TaskRecurrence, error) {
+	query := `
+		INSERT INTO task_recurrences (id, task_id, project_id, created_by_id, rrule, dtstart, generate_on, current_occurrence_at, next_occurrence_at, occurrence_count, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 1, NOW(), NOW())
+		ON CONFLICT (task_id) DO UPDATE
+		SET rrule = EXCLUDED.rrule,
+		    dtstart = EXCLUDED.dtstart,
+		    generate_on = EXCLUDED.generate_on,
+		    current_occurrence_at = EXCLUDED.current_occurrence_at,
+		    next_occurrence_at = EXCLUDED.next_occurrence_at,
+		    occurrence_count = 1,
+		    updated_at = NOW()
+		RETURNING ` + recurrenceColumns
+
+	saved, err := scanRecurrence(r.db.QueryRow(ctx, query,
+		uuid.New().String(),
+		rec.TaskID,
+		rec.ProjectID,
+		rec.CreatedByID,
+		rec.RRule,
+		rec.DTStart,
+		rec.GenerateOn,
+		rec.CurrentOccurrenceAt,
+		rec.NextOccurrenceAt,
+	))
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to save recurrence", err)
+	}
+
+	return saved, nil
+}
+
+// GetRecurrenceByTaskID retrieves the recurrence whose current occurrence is the given task
+func (r *recurrenceRepository) GetRecurrenceByTaskID(ctx context.Context, taskID string) (*domain.TaskRecurrence, error) {
+	query := `SELECT ` + recurrenceColumns + ` FROM task_recurrences WHERE task_id = $1`
+
+	rec, err := scanRecurrence(r.db.QueryRow(ctx, query, taskID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrRecurrenceNotFound, "recurrence not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get recurrence", err)
+	}
+
+	return rec, nil
+}
+
+// DeleteRecurrenceByTaskID stops a task from recurring
+func (r *recurrenceRepository) DeleteRecurrenceByTaskID(ctx context.Context, taskID string) error {
+	const query = `DELETE FROM task_recurrences WHERE task_id = $1`
+
+	result, err := r.db.Exec(ctx, query, taskID)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to delete recurrence", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFoundError(apperrors.ErrRecurrenceNotFound, "recurrence not found")
+	}
+
+	return nil
+}
+
+// ListDueScheduledRecurrences retrieves scheduled recurrences whose current occurrence has been reached
+func (r *recurrenceRepository) ListDueScheduledRecurrences(ctx context.Context, now time.Time, limit int) ([]domain.TaskRecurrence, error) {
+	query := `
+		SELECT ` + recurrenceColumns + `
+		FROM task_recurrences
+		WHERE generate_on = 'SCHEDULE' AND next_occurrence_at IS NOT NULL AND current_occurrence_at <= $1
+		ORDER BY current_occurrence_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list due recurrences", err)
+	}
+	defer rows.Close()
+
+	recurrences := make([]domain.TaskRecurrence, 0)
+	for rows.Next() {
+		rec, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan recurrence", err)
+		}
+		recurrences = append(recurrences, *rec)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating recurrences", err)
+	}
+
+	return recurrences, nil
+}
+
+// AdvanceRecurrence creates the next occurrence of a recurring task, as an open
+// task, and moves the recurrence onto it in a single transaction. The row is
+// locked and matched on currentTaskID so concurrent completions and the
+// scheduler cannot generate the same occurrence twice; an empty ID is returned
+// when another caller got there first.
+func (r *recurrenceRepository) AdvanceRecurrence(ctx context.Context, recurrenceID, currentTaskID string, next *domain.Task, followingOccurrenceAt *time.Time) (string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var lockedID string
+	err = tx.QueryRow(ctx, `SELECT id FROM task_recurrences WHERE id = $1 AND task_id = $2 FOR UPDATE`, recurrenceID, currentTaskID).Scan(&lockedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", apperrors.NewDatabaseError("failed to lock recurrence", err)
+	}
+
+	next.Status = "OPEN"
+	if err := insertTask(ctx, tx, next); err != nil {
+		return "", err
+	}
+
+	const updateQuery = `
+		UPDATE task_recurrences
+		SET task_id = $2, current_occurrence_at = $3, next_occurrence_at = $4, occurrence_count = occurrence_count + 1, updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err = tx.Exec(ctx, updateQuery, recurrenceID, next.ID, next.DueDate, followingOccurrenceAt)
+	if err != nil {
+		return "", apperrors.NewDatabaseError("failed to advance recurrence", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", apperrors.NewDatabaseError("failed to commit recurrence", err)
+	}
+
+	return next.ID, nil
+}
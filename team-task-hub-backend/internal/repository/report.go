@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// ReportRepository defines aggregate reporting queries
+type ReportRepository interface {
+	GetProjectStats(ctx context.Context, projectID string, weeks int) (*domain.ProjectStats, error)
+	GetWorkload(ctx context.Context, userID, projectID string) ([]domain.WorkloadRow, error)
+}
+
+type reportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepository(db *pgxpool.Pool) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+// GetProjectStats aggregates a project's task counts, weekly throughput over
+// the last weeks weeks and average cycle time, without loading any tasks
+func (r *reportRepository) GetProjectStats(ctx context.Context, projectID string, weeks int) (*domain.ProjectStats, error) {
+	stats := &domain.ProjectStats{
+		ProjectID:  projectID,
+		ByStatus:   map[string]int{"OPEN": 0, "IN_PROGRESS": 0, "DONE": 0},
+		ByPriority: map[string]int{"LOW": 0, "MEDIUM": 0, "HIGH": 0},
+		ByAssignee: make([]domain.AssigneeTaskCount, 0),
+		Weekly:     make([]domain.WeeklyThroughput, 0, weeks),
+	}
+
+	if err := r.countByStatusAndPriority(ctx, projectID, stats); err != nil {
+		return nil, err
+	}
+
+	if err := r.countByAssignee(ctx, projectID, stats); err != nil {
+		return nil, err
+	}
+
+	if err := r.weeklyThroughput(ctx, projectID, weeks, stats); err != nil {
+		return nil, err
+	}
+
+	const cycleTimeQuery = `
+		SELECT AVG(EXTRACT(EPOCH FROM (d.done_at - t.created_at)) / 3600.0)::FLOAT8, COUNT(*)
+		FROM tasks t
+		JOIN LATERAL (
+			SELECT MAX(h.changed_at) AS done_at
+			FROM task_status_history h
+			WHERE h.task_id = t.id AND h.to_status = 'DONE'
+		) d ON d.done_at IS NOT NULL
+		WHERE t.project_id = $1 AND t.status = 'DONE'
+	`
+	if err := r.db.QueryRow(ctx, cycleTimeQuery, projectID).Scan(&stats.AverageCycleTimeHours, &stats.CycleTimeSampleSize); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to compute cycle time", err)
+	}
+
+	return stats, nil
+}
+
+func (r *reportRepository) countByStatusAndPriority(ctx context.Context, projectID string, stats *domain.ProjectStats) error {
+	const query = `SELECT status, priority, COUNT(*) FROM tasks WHERE project_id = $1 GROUP BY status, priority`
+
+	rows, err := r.db.Query(ctx, query, projectID)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to count tasks by status and priority", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status, priority string
+		var count int
+		if err := rows.Scan(&status, &priority, &count); err != nil {
+			return apperrors.NewDatabaseError("failed to scan task count", err)
+		}
+		stats.ByStatus[status] += count
+		stats.ByPriority[priority] += count
+		stats.TotalTasks += count
+	}
+
+	if err = rows.Err(); err != nil {
+		return apperrors.NewDatabaseError("error iterating task counts", err)
+	}
+
+	return nil
+}
+
+func (r *reportRepository) countByAssignee(ctx context.Context, projectID string, stats *domain.ProjectStats) error {
+	const query = `
+		SELECT t.assignee_id, u.email, u.name,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE t.status = 'OPEN'),
+		       COUNT(*) FILTER (WHERE t.status = 'IN_PROGRESS'),
+		       COUNT(*) FILTER (WHERE t.status = 'DONE'),
+		       COUNT(*) FILTER (WHERE t.status <> 'DONE' AND t.due_date < NOW())
+		FROM tasks t
+		LEFT JOIN users u ON t.assignee_id = u.id
+		WHERE t.project_id = $1
+		GROUP BY t.assignee_id, u.email, u.name
+		ORDER BY COUNT(*) DESC, u.email ASC NULLS LAST
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to count tasks by assignee", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.AssigneeTaskCount
+		var email, name *string
+		if err := rows.Scan(&c.AssigneeID, &email, &name, &c.Total, &c.Open, &c.InProgress, &c.Done, &c.Overdue); err != nil {
+			return apperrors.NewDatabaseError("failed to scan assignee count", err)
+		}
+		if c.AssigneeID != nil && email != nil {
+			c.Assignee = &domain.User{ID: *c.AssigneeID, Email: *email, Name: derefString(name)}
+		}
+		stats.Overdue += c.Overdue
+		stats.ByAssignee = append(stats.ByAssignee, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return apperrors.NewDatabaseError("error iterating assignee counts", err)
+	}
+
+	return nil
+}
+
+func (r *reportRepository) weeklyThroughput(ctx context.Context, projectID string, weeks int, stats *domain.ProjectStats) error {
+	const query = `
+		WITH weeks AS (
+			SELECT generate_series(
+				DATE_TRUNC('week', NOW()) - ($2::INTEGER - 1) * INTERVAL '1 week',
+				DATE_TRUNC('week', NOW()),
+				INTERVAL '1 week'
+			) AS week_start
+		)
+		SELECT TO_CHAR(w.week_start, 'YYYY-MM-DD'),
+		       (SELECT COUNT(*) FROM tasks t
+		        WHERE t.project_id = $1 AND t.created_at >= w.week_start AND t.created_at < w.week_start + INTERVAL '1 week'),
+		       (SELECT COUNT(*) FROM task_status_history h JOIN tasks t ON h.task_id = t.id
+		        WHERE t.project_id = $1 AND h.to_status = 'DONE' AND h.changed_at >= w.week_start AND h.changed_at < w.week_start + INTERVAL '1 week')
+		FROM weeks w
+		ORDER BY w.week_start
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID, weeks)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to compute weekly throughput", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var week domain.WeeklyThroughput
+		if err := rows.Scan(&week.WeekStart, &week.Created, &week.Completed); err != nil {
+			return apperrors.NewDatabaseError("failed to scan weekly throughput", err)
+		}
+		stats.Weekly = append(stats.Weekly, week)
+	}
+
+	if err = rows.Err(); err != nil {
+		return apperrors.NewDatabaseError("error iterating weekly throughput", err)
+	}
+
+	return nil
+}
+
+// GetWorkload counts each assignee's unfinished tasks across the projects
+// userID can see, or within one of them when projectID is set. Weeks end on
+// Sunday (UTC).
+func (r *reportRepository) GetWorkload(ctx context.Context, userID, projectID string) ([]domain.WorkloadRow, error) {
+	query := `
+		SELECT u.id, u.email, COALESCE(u.name, ''),
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE t.status = 'IN_PROGRESS'),
+		       COUNT(*) FILTER (WHERE t.priority = 'LOW'),
+		       COUNT(*) FILTER (WHERE t.priority = 'MEDIUM'),
+		       COUNT(*) FILTER (WHERE t.priority = 'HIGH'),
+		       COUNT(*) FILTER (WHERE t.due_date < NOW()),
+		       COUNT(*) FILTER (WHERE t.due_date >= NOW() AND t.due_date < DATE_TRUNC('week', NOW()) + INTERVAL '1 week'),
+		       COUNT(*) FILTER (WHERE t.due_date >= DATE_TRUNC('week', NOW()) + INTERVAL '1 week'),
+		       COUNT(*) FILTER (WHERE t.due_date IS NULL)
+		FROM tasks t
+		JOIN users u ON t.assignee_id = u.id
+		JOIN projects p ON t.project_id = p.id
+		WHERE t.status <> 'DONE' AND ` + projectVisibleTo("$1") + `
+	`
+	args := []interface{}{userID}
+
+	if projectID != "" {
+		query += " AND t.project_id = $2"
+		args = append(args, projectID)
+	}
+
+	query += " GROUP BY u.id, u.email, u.name ORDER BY COUNT(*) DESC, u.email ASC"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to compute workload", err)
+	}
+	defer rows.Close()
+
+	workload := make([]domain.WorkloadRow, 0)
+	for rows.Next() {
+		var row domain.WorkloadRow
+		err := rows.Scan(
+			&row.UserID, &row.UserEmail, &row.UserName,
+			&row.OpenTasks, &row.InProgress,
+			&row.Low, &row.Medium, &row.High,
+			&row.Overdue, &row.DueThisWeek, &row.DueLater, &row.NoDueDate,
+		)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan workload row", err)
+		}
+		workload = append(workload, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating workload rows", err)
+	}
+
+	return workload, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// SigningKeyRepository defines JWT signing key data access operations
+type SigningKeyRepository interface {
+	ListKeys(ctx context.Context) ([]domain.SigningKey, error)
+	InsertKeyAfter(ctx context.Context, key *domain.SigningKey, latestID *string) (bool, error)
+	DeleteSupersededKeys(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// signingKeyLockID is the advisory lock serialising key rotation across
+// instances
+const signingKeyLockID = 7_402_117_301
+
+type signingKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSigningKeyRepository(db *pgxpool.Pool) SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+// ListKeys retrieves every stored key, newest activation first
+func (r *signingKeyRepository) ListKeys(ctx context.Context) ([]domain.SigningKey, error) {
+	const query = `
+		SELECT kid, algorithm, encrypted_private_key, activates_at, created_at
+		FROM jwt_signing_keys
+		ORDER BY activates_at DESC, kid DESC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list signing keys", err)
+	}
+	defer rows.Close()
+
+	keys := make([]domain.SigningKey, 0)
+	for rows.Next() {
+		var k domain.SigningKey
+		if err := rows.Scan(&k.ID, &k.Algorithm, &k.EncryptedPrivateKey, &k.ActivatesAt, &k.CreatedAt); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan signing key", err)
+		}
+		keys = append(keys, k)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating signing keys", err)
+	}
+
+	return keys, nil
+}
+
+// InsertKeyAfter stores key if the newest stored key is still latestID (nil
+// for none), and reports whether it did. When instances rotate at the same
+// time only the first key is kept.
+func (r *signingKeyRepository) InsertKeyAfter(ctx context.Context, key *domain.SigningKey, latestID *string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, signingKeyLockID); err != nil {
+		return false, apperrors.NewDatabaseError("failed to lock signing keys", err)
+	}
+
+	const query = `
+		INSERT INTO jwt_signing_keys (kid, algorithm, encrypted_private_key, activates_at, created_at)
+		SELECT $1, $2, $3, $4, NOW()
+		WHERE (SELECT kid FROM jwt_signing_keys ORDER BY activates_at DESC, kid DESC LIMIT 1) IS NOT DISTINCT FROM $5::varchar
+		RETURNING created_at
+	`
+
+	err = tx.QueryRow(ctx, query, key.ID, key.Algorithm, key.EncryptedPrivateKey, key.ActivatesAt, latestID).Scan(&key.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, apperrors.NewDatabaseError("failed to insert signing key", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return true, nil
+}
+
+// DeleteSupersededKeys deletes keys replaced by a newer key that has been
+// signing for longer than retention, so no unexpired token uses them
+func (r *signingKeyRepository) DeleteSupersededKeys(ctx context.Context, retention time.Duration) (int64, error) {
+	const query = `
+		DELETE FROM jwt_signing_keys k
+		WHERE EXISTS (
+			SELECT 1 FROM jwt_signing_keys n
+			WHERE n.activates_at > k.activates_at
+			  AND n.activates_at < NOW() - $1::float8 * INTERVAL '1 second'
+		)
+	`
+
+	result, err := r.db.Exec(ctx, query, retention.Seconds())
+	if err != nil {
+		return 0, apperrors.NewDatabaseError("failed to delete superseded signing keys", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// SprintRepository defines sprint data access operations
+type SprintRepository interface {
+	CreateSprint(ctx context.Context, sprint *domain.Sprint) (*domain.Sprint, error)
+	GetSprintByID(ctx context.Context, id string) (*domain.Sprint, error)
+	ListSprintsByProjectID(ctx context.Context, projectID string) ([]domain.Sprint, error)
+	UpdateSprint(ctx context.Context, id, name, goal string, startDate, endDate time.Time) (*domain.Sprint, error)
+	DeleteSprint(ctx context.Context, id string) error
+	StartSprint(ctx context.Context, id string) (*domain.Sprint, error)
+	CompleteSprint(ctx context.Context, id string, rolloverSprintID *string) (*domain.Sprint, int, error)
+	Burndown(ctx context.Context, sprintID string, from, to time.Time) ([]domain.BurndownPoint, error)
+}
+
+type sprintRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSprintRepository(db *pgxpool.Pool) SprintRepository {
+	return &sprintRepository{db: db}
+}
+
+const sprintColumns = `id, project_id, created_by_id, name, COALESCE(goal, ''), start_date, end_date, status, started_at, completed_at, created_at, updated_at`
+
+func scanSprint(row pgx.Row) (*domain.Sprint, error) {
+	sprint := &domain.Sprint{}
+	err := row.Scan(
+		&sprint.ID,
+		&sprint.ProjectID,
+		&sprint.CreatedByID,
+		&sprint.Name,
+		&sprint.Goal,
+		&sprint.StartDate,
+		&sprint.EndDate,
+		&sprint.Status,
+		&sprint.StartedAt,
+		&sprint.CompletedAt,
+		&sprint.CreatedAt,
+		&sprint.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return sprint, nil
+}
+
+// CreateSprint creates a new planned sprint
+func (r *sprintRepository) CreateSprint(ctx context.Context, sprint *domain.Sprint) (*domain.Sprint, error) {
+	query := `
+		INSERT INTO sprints (id, project_id, created_by_id, name, goal, start_date, end_date, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'PLANNED', NOW(), NOW())
+		RETURNING ` + sprintColumns
+
+	created, err := scanSprint(r.db.QueryRow(ctx, query, uuid.New().String(), sprint.ProjectID, sprint.CreatedByID, sprint.Name, sprint.Goal, sprint.StartDate, sprint.EndDate))
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to create sprint", err)
+	}
+
+	return created, nil
+}
+
+// GetSprintByID retrieves a sprint by ID
+func (r *sprintRepository) GetSprintByID(ctx context.Context, id string) (*domain.Sprint, error) {
+	sprint, err := scanSprint(r.db.QueryRow(ctx, `SELECT `+sprintColumns+` FROM sprints WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrSprintNotFound, "sprint not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get sprint", err)
+	}
+
+	return sprint, nil
+}
+
+// ListSprintsByProjectID retrieves a project's sprints in chronological order
+func (r *sprintRepository) ListSprintsByProjectID(ctx context.Context, projectID string) ([]domain.Sprint, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+sprintColumns+` FROM sprints WHERE project_id = $1 ORDER BY start_date ASC, created_at ASC`, projectID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list sprints", err)
+	}
+	defer rows.Close()
+
+	sprints := make([]domain.Sprint, 0)
+	for rows.Next() {
+		sprint, err := scanSprint(rows)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan sprint", err)
+		}
+		sprints = append(sprints, *sprint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating sprints", err)
+	}
+
+	return sprints, nil
+}
+
+// UpdateSprint updates a sprint's name, goal and dates
+func (r *sprintRepository) UpdateSprint(ctx context.Context, id, name, goal string, startDate, endDate time.Time) (*domain.Sprint, error) {
+	query := `
+		UPDATE sprints
+		SET name = $2, goal = $3, start_date = $4, end_date = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + sprintColumns
+
+	sprint, err := scanSprint(r.db.QueryRow(ctx, query, id, name, goal, startDate, endDate))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrSprintNotFound, "sprint not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to update sprint", err)
+	}
+
+	return sprint, nil
+}
+
+// DeleteSprint deletes a sprint; its tasks return to the backlog
+func (r *sprintRepository) DeleteSprint(ctx context.Context, id string) error {
+	const query = `DELETE FROM sprints WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to delete sprint", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFoundError(apperrors.ErrSprintNotFound, "sprint not found")
+	}
+
+	return nil
+}
+
+// StartSprint moves a planned sprint to active. The partial unique index on
+// active sprints rejects a second active sprint in the same project.
+func (r *sprintRepository) StartSprint(ctx context.Context, id string) (*domain.Sprint, error) {
+	query := `
+		UPDATE sprints
+		SET status = 'ACTIVE', started_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'PLANNED'
+		RETURNING ` + sprintColumns
+
+	sprint, err := scanSprint(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 is unique_violation
+			return nil, apperrors.NewConflictError(apperrors.ErrSprintActive, "project already has an active sprint")
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewConflictError(apperrors.ErrInvalidTransition, "only a planned sprint can be started")
+		}
+		return nil, apperrors.NewDatabaseError("failed to start sprint", err)
+	}
+
+	return sprint, nil
+}
+
+// CompleteSprint completes an active sprint and moves its unfinished tasks to
+// rolloverSprintID, or to the backlog when it is nil. It returns the number of
+// tasks moved.
+func (r *sprintRepository) CompleteSprint(ctx context.Context, id string, rolloverSprintID *string) (*domain.Sprint, int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE sprints
+		SET status = 'COMPLETED', completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'ACTIVE'
+		RETURNING ` + sprintColumns
+
+	sprint, err := scanSprint(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, apperrors.NewConflictError(apperrors.ErrInvalidTransition, "only an active sprint can be completed")
+		}
+		return nil, 0, apperrors.NewDatabaseError("failed to complete sprint", err)
+	}
+
+	const rolloverQuery = `
+		UPDATE tasks
+		SET sprint_id = $2, updated_at = NOW()
+		WHERE sprint_id = $1 AND status <> 'DONE'
+	`
+	result, err := tx.Exec(ctx, rolloverQuery, id, rolloverSprintID)
+	if err != nil {
+		return nil, 0, apperrors.NewDatabaseError("failed to roll over unfinished tasks", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, apperrors.NewDatabaseError("failed to commit sprint completion", err)
+	}
+
+	return sprint, int(result.RowsAffected()), nil
+}
+
+// Burndown computes, for each day in [from, to], the sprint's scope and how
+// much of it was done at the end of that day. Scope is the sprint's current
+// tasks that existed on that day; a task's state on a day is its last
+// recorded status change up to the end of the day.
+func (r *sprintRepository) Burndown(ctx context.Context, sprintID string, from, to time.Time) ([]domain.BurndownPoint, error) {
+	const query = `
+		WITH days AS (
+			SELECT generate_series($2::date, $3::date, INTERVAL '1 day')::date AS day
+		),
+		sprint_tasks AS (
+			SELECT id, created_at, COALESCE(story_points, 0) AS points
+			FROM tasks
+			WHERE sprint_id = $1
+		)
+		SELECT TO_CHAR(d.day, 'YYYY-MM-DD'),
+		       COUNT(st.id),
+		       COUNT(st.id) FILTER (WHERE h.to_status = 'DONE'),
+		       COALESCE(SUM(st.points), 0),
+		       COALESCE(SUM(st.points) FILTER (WHERE h.to_status = 'DONE'), 0)
+		FROM days d
+		LEFT JOIN sprint_tasks st ON st.created_at < d.day + 1
+		LEFT JOIN LATERAL (
+			SELECT sh.to_status
+			FROM task_status_history sh
+			WHERE sh.task_id = st.id AND sh.changed_at < d.day + 1
+			ORDER BY sh.changed_at DESC
+			LIMIT 1
+		) h ON true
+		GROUP BY d.day
+		ORDER BY d.day
+	`
+
+	rows, err := r.db.Query(ctx, query, sprintID, from, to)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to compute burndown", err)
+	}
+	defer rows.Close()
+
+	points := make([]domain.BurndownPoint, 0)
+	for rows.Next() {
+		var p domain.BurndownPoint
+		err := rows.Scan(
+			&p.Date,
+			&p.TotalTasks,
+			&p.CompletedTasks,
+			&p.TotalPoints,
+			&p.CompletedPoints,
+		)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan burndown point", err)
+		}
+		p.RemainingTasks = p.TotalTasks - p.CompletedTasks
+		p.RemainingPoints = p.TotalPoints - p.CompletedPoints
+		points = append(points, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating burndown", err)
+	}
+
+	return points, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/launchventures/team-task-hub-backend/internal/domain"
 	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
@@ -15,13 +16,20 @@ import (
 
 // TaskRepository defines task data access operations
 type TaskRepository interface {
-	CreateTask(ctx context.Context, projectID, createdByID string, title, description, status, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
+	CreateTask(ctx context.Context, projectID, createdByID string, title, description, status, priority, rank string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
 	GetTaskByID(ctx context.Context, id string) (*domain.Task, error)
-	ListTasksByProjectID(ctx context.Context, projectID string, limit, offset int, status, priority string) ([]domain.Task, int, error)
+	ListTasksByProjectID(ctx context.Context, projectID string, limit, offset int, status, priority, sprintID string) ([]domain.Task, int, error)
 	ListTasksByAssignee(ctx context.Context, userID string, limit, offset int, status, priority string) ([]domain.Task, int, error)
-	UpdateTask(ctx context.Context, id string, title, description, status, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
+	UpdateTask(ctx context.Context, id string, title, description, status, priority, rank string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
 	AssignTaskToUser(ctx context.Context, taskID, userID, assignedByID string) (*domain.TaskAssignment, error)
 	UnassignTask(ctx context.Context, taskID string) error
+	UpdateEstimates(ctx context.Context, id string, originalMinutes, remainingMinutes, storyPoints *int) (*domain.Task, error)
+	UpdateSprint(ctx context.Context, id string, sprintID *string) (*domain.Task, error)
+	MoveTask(ctx context.Context, id, status, rank string) (*domain.Task, error)
+	GetLastRank(ctx context.Context, projectID, status string) (string, error)
+	GetAdjacentRank(ctx context.Context, projectID, status, rank, excludeID string, below bool) (string, error)
+	ListColumnTaskIDs(ctx context.Context, projectID, status string) ([]string, error)
+	UpdateRanks(ctx context.Context, ids, ranks []string) error
 	DeleteTask(ctx context.Context, id string) error
 }
 
@@ -34,40 +42,86 @@ func NewTaskRepository(db *pgxpool.Pool) TaskRepository {
 }
 
 // CreateTask creates a new task
-func (r *taskRepository) CreateTask(ctx context.Context, projectID, createdByID string, title, description, status, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
-	taskID := uuid.New().String()
+func (r *taskRepository) CreateTask(ctx context.Context, projectID, createdByID string, title, description, status, priority, rank string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
 	var assignedByID *string
 	if createdByID != "" {
 		assignedByID = &createdByID
 	}
+	task := &domain.Task{
+		ProjectID:    projectID,
+		AssigneeID:   assigneeID,
+		AssignedByID: assignedByID,
+		CreatedByID:  &createdByID,
+		Title:        title,
+		Description:  description,
+		Status:       status,
+		Priority:     priority,
+		DueDate:      dueDate,
+		Rank:         rank,
+	}
 
-	const insertQuery = `
-		INSERT INTO tasks (id, project_id, title, description, status, priority, assignee_id, assigned_by_id, created_by_id, due_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
-	`
-
-	_, err := r.db.Exec(ctx, insertQuery, taskID, projectID, title, description, status, priority, assigneeID, assignedByID, createdByID, dueDate)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return nil, apperrors.NewDatabaseError("failed to create task", err)
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTask(ctx, tx, task); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit task", err)
 	}
 
 	// Fetch the created task with user objects populated using GetTaskByID
 	// which already has the logic to populate Assignee, AssignedBy, and CreatedBy
-	return r.GetTaskByID(ctx, taskID)
+	return r.GetTaskByID(ctx, task.ID)
 }
 
-// GetTaskByID retrieves a task by ID
-func (r *taskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
-	const query = `
-		SELECT t.id, t.project_id, t.assignee_id, t.assigned_by_id, t.created_by_id, t.title, t.description, t.status, t.priority, t.due_date, t.created_at, t.updated_at,
-		       u.id, u.email, u.name, ab.id, ab.email, ab.name, cb.id, cb.email, cb.name
-		FROM tasks t
-		LEFT JOIN users u ON t.assignee_id = u.id
-		LEFT JOIN users ab ON t.assigned_by_id = ab.id
-		LEFT JOIN users cb ON t.created_by_id = cb.id
-		WHERE t.id = $1
+// insertTask creates task within tx, recording its first status, and sets its
+// ID. Everything that creates tasks goes through it, so they all start with a
+// status history.
+func insertTask(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
+	const insertQuery = `
+		INSERT INTO tasks (id, project_id, title, description, status, priority, assignee_id, assigned_by_id, created_by_id, due_date, rank, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
 	`
 
+	taskID := uuid.New().String()
+	_, err := tx.Exec(ctx, insertQuery, taskID, task.ProjectID, task.Title, task.Description, task.Status, task.Priority, task.AssigneeID, task.AssignedByID, task.CreatedByID, task.DueDate, task.Rank)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to create task", err)
+	}
+
+	if err := recordStatusChange(ctx, tx, taskID, nil, task.Status); err != nil {
+		return apperrors.NewDatabaseError("failed to record task status", err)
+	}
+
+	task.ID = taskID
+	return nil
+}
+
+// taskSelectQuery selects a task with its related users and checklist progress.
+// Checklist progress is aggregated per row through a lateral join so list
+// queries stay a single round trip.
+const taskSelectQuery = `
+	SELECT t.id, t.project_id, t.assignee_id, t.assigned_by_id, t.created_by_id, t.title, t.description, t.status, t.priority, t.due_date, t.created_at, t.updated_at,
+	       u.id, u.email, u.name, ab.id, ab.email, ab.name, cb.id, cb.email, cb.name,
+	       cl.total, cl.done, t.original_estimate_minutes, t.remaining_estimate_minutes, t.sprint_id, t.story_points, t.rank
+	FROM tasks t
+	LEFT JOIN users u ON t.assignee_id = u.id
+	LEFT JOIN users ab ON t.assigned_by_id = ab.id
+	LEFT JOIN users cb ON t.created_by_id = cb.id
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS total, COUNT(*) FILTER (WHERE ci.is_done) AS done
+		FROM task_checklist_items ci
+		WHERE ci.task_id = t.id
+	) cl ON true
+`
+
+// scanTask scans a row produced by taskSelectQuery
+func scanTask(row pgx.Row) (*domain.Task, error) {
 	task := &domain.Task{}
 	var userID *string
 	var userEmail *string
@@ -79,7 +133,7 @@ func (r *taskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 	var createdByUserEmail *string
 	var createdByUserName *string
 
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := row.Scan(
 		&task.ID,
 		&task.ProjectID,
 		&task.AssigneeID,
@@ -101,13 +155,16 @@ func (r *taskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 		&createdByUserID,
 		&createdByUserEmail,
 		&createdByUserName,
+		&task.Checklist.Total,
+		&task.Checklist.Done,
+		&task.OriginalEstimateMinutes,
+		&task.RemainingEstimateMinutes,
+		&task.SprintID,
+		&task.StoryPoints,
+		&task.Rank,
 	)
-
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
-		}
-		return nil, apperrors.NewDatabaseError("failed to get task", err)
+		return nil, err
 	}
 
 	// Populate assignee if available
@@ -115,7 +172,7 @@ func (r *taskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 		task.Assignee = &domain.User{
 			ID:    *userID,
 			Email: *userEmail,
-			Name:  *userName,
+			Name:  derefString(userName),
 		}
 	}
 
@@ -124,7 +181,7 @@ func (r *taskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 		task.AssignedBy = &domain.User{
 			ID:    *assignedByUserID,
 			Email: *assignedByUserEmail,
-			Name:  *assignedByUserName,
+			Name:  derefString(assignedByUserName),
 		}
 	}
 
@@ -133,15 +190,51 @@ func (r *taskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Ta
 		task.CreatedBy = &domain.User{
 			ID:    *createdByUserID,
 			Email: *createdByUserEmail,
-			Name:  *createdByUserName,
+			Name:  derefString(createdByUserName),
 		}
 	}
 
 	return task, nil
 }
 
-// ListTasksByProjectID retrieves all tasks for a project with optional filters
-func (r *taskRepository) ListTasksByProjectID(ctx context.Context, projectID string, limit, offset int, status, priority string) ([]domain.Task, int, error) {
+// execer is satisfied by both *pgxpool.Pool and pgx.Tx
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// recordStatusChange appends an entry to a task's status history
+func recordStatusChange(ctx context.Context, db execer, taskID string, fromStatus *string, toStatus string) error {
+	const query = `
+		INSERT INTO task_status_history (id, task_id, from_status, to_status, changed_at)
+		VALUES ($1, $2, $3, $4, NOW())
+	`
+	_, err := db.Exec(ctx, query, uuid.New().String(), taskID, fromStatus, toStatus)
+	return err
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// GetTaskByID retrieves a task by ID
+func (r *taskRepository) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
+	task, err := scanTask(r.db.QueryRow(ctx, taskSelectQuery+` WHERE t.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get task", err)
+	}
+
+	return task, nil
+}
+
+// ListTasksByProjectID retrieves all tasks for a project with optional filters.
+// A sprintID of "none" selects backlog tasks that are not in any sprint.
+func (r *taskRepository) ListTasksByProjectID(ctx context.Context, projectID string, limit, offset int, status, priority, sprintID string) ([]domain.Task, int, error) {
 	// Build dynamic query with optional filters
 	whereClause := "WHERE t.project_id = $1"
 	args := []interface{}{projectID}
@@ -156,6 +249,13 @@ func (r *taskRepository) ListTasksByProjectID(ctx context.Context, projectID str
 		args = append(args, priority)
 	}
 
+	if sprintID == "none" {
+		whereClause += " AND t.sprint_id IS NULL"
+	} else if sprintID != "" {
+		whereClause += " AND t.sprint_id = $" + strconv.Itoa(len(args)+1)
+		args = append(args, sprintID)
+	}
+
 	// Count total
 	countQuery := "SELECT COUNT(*) FROM tasks t " + whereClause
 	var total int
@@ -165,14 +265,8 @@ func (r *taskRepository) ListTasksByProjectID(ctx context.Context, projectID str
 	}
 
 	// Get paginated results with user data
-	query := `SELECT t.id, t.project_id, t.assignee_id, t.assigned_by_id, t.created_by_id, t.title, t.description, t.status, t.priority, t.due_date, t.created_at, t.updated_at,
-	       u.id, u.email, u.name, ab.id, ab.email, ab.name, cb.id, cb.email, cb.name
-	FROM tasks t
-	LEFT JOIN users u ON t.assignee_id = u.id
-	LEFT JOIN users ab ON t.assigned_by_id = ab.id
-	LEFT JOIN users cb ON t.created_by_id = cb.id
-	` + whereClause
-	query += " ORDER BY t.id DESC LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+	query := taskSelectQuery + whereClause
+	query += " ORDER BY t.rank ASC, t.id ASC LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
 	args = append(args, limit, offset)
 
 	rows, err := r.db.Query(ctx, query, args...)
@@ -183,72 +277,11 @@ func (r *taskRepository) ListTasksByProjectID(ctx context.Context, projectID str
 
 	tasks := make([]domain.Task, 0)
 	for rows.Next() {
-		var t domain.Task
-		var userID *string
-		var userEmail *string
-		var userName *string
-		var assignedByUserID *string
-		var assignedByUserEmail *string
-		var assignedByUserName *string
-		var createdByUserID *string
-		var createdByUserEmail *string
-		var createdByUserName *string
-
-		err := rows.Scan(
-			&t.ID,
-			&t.ProjectID,
-			&t.AssigneeID,
-			&t.AssignedByID,
-			&t.CreatedByID,
-			&t.Title,
-			&t.Description,
-			&t.Status,
-			&t.Priority,
-			&t.DueDate,
-			&t.CreatedAt,
-			&t.UpdatedAt,
-			&userID,
-			&userEmail,
-			&userName,
-			&assignedByUserID,
-			&assignedByUserEmail,
-			&assignedByUserName,
-			&createdByUserID,
-			&createdByUserEmail,
-			&createdByUserName,
-		)
+		t, err := scanTask(rows)
 		if err != nil {
 			return nil, 0, apperrors.NewDatabaseError("failed to scan task", err)
 		}
-
-		// Populate assignee if available
-		if userID != nil && userEmail != nil {
-			t.Assignee = &domain.User{
-				ID:    *userID,
-				Email: *userEmail,
-				Name:  *userName,
-			}
-		}
-
-		// Populate assigned_by user if available
-		if assignedByUserID != nil && assignedByUserEmail != nil {
-			t.AssignedBy = &domain.User{
-				ID:    *assignedByUserID,
-				Email: *assignedByUserEmail,
-				Name:  *assignedByUserName,
-			}
-		}
-
-		// Populate created_by user if available
-		if createdByUserID != nil && createdByUserEmail != nil {
-			t.CreatedBy = &domain.User{
-				ID:    *createdByUserID,
-				Email: *createdByUserEmail,
-				Name:  *createdByUserName,
-			}
-		}
-
-		tasks = append(tasks, t)
+		tasks = append(tasks, *t)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -260,15 +293,7 @@ func (r *taskRepository) ListTasksByProjectID(ctx context.Context, projectID str
 
 // ListTasksByAssignee retrieves all tasks assigned to a user
 func (r *taskRepository) ListTasksByAssignee(ctx context.Context, userID string, limit, offset int, status, priority string) ([]domain.Task, int, error) {
-	query := `
-		SELECT t.id, t.project_id, t.assignee_id, t.assigned_by_id, t.created_by_id, t.title, t.description, t.status, t.priority, t.due_date, t.created_at, t.updated_at,
-		       u.id, u.email, u.name, ab.id, ab.email, ab.name, cb.id, cb.email, cb.name
-		FROM tasks t
-		LEFT JOIN users u ON t.assignee_id = u.id
-		LEFT JOIN users ab ON t.assigned_by_id = ab.id
-		LEFT JOIN users cb ON t.created_by_id = cb.id
-		WHERE t.assignee_id = $1
-	`
+	query := taskSelectQuery + ` WHERE t.assignee_id = $1`
 	args := []interface{}{userID}
 
 	if status != "" {
@@ -297,7 +322,7 @@ func (r *taskRepository) ListTasksByAssignee(ctx context.Context, userID string,
 		return nil, 0, err
 	}
 
-	query += " ORDER BY t.id DESC LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
+	query += " ORDER BY t.rank ASC, t.id ASC LIMIT $" + strconv.Itoa(len(args)+1) + " OFFSET $" + strconv.Itoa(len(args)+2)
 	args = append(args, limit, offset)
 
 	rows, err := r.db.Query(ctx, query, args...)
@@ -308,62 +333,37 @@ func (r *taskRepository) ListTasksByAssignee(ctx context.Context, userID string,
 
 	tasks := make([]domain.Task, 0)
 	for rows.Next() {
-		var t domain.Task
-		var assigneeUserID *string
-		var userEmail *string
-		var userName *string
-		var assignedByUserID *string
-		var assignedByUserEmail *string
-		var assignedByUserName *string
-		var createdByUserID *string
-		var createdByUserEmail *string
-		var createdByUserName *string
-
-		if err := rows.Scan(
-			&t.ID, &t.ProjectID, &t.AssigneeID, &t.AssignedByID, &t.CreatedByID, &t.Title, &t.Description, &t.Status, &t.Priority, &t.DueDate, &t.CreatedAt, &t.UpdatedAt,
-			&assigneeUserID, &userEmail, &userName, &assignedByUserID, &assignedByUserEmail, &assignedByUserName, &createdByUserID, &createdByUserEmail, &createdByUserName,
-		); err != nil {
+		t, err := scanTask(rows)
+		if err != nil {
 			return nil, 0, err
 		}
+		tasks = append(tasks, *t)
+	}
 
-		// Populate assignee if available
-		if assigneeUserID != nil && userEmail != nil {
-			t.Assignee = &domain.User{
-				ID:    *assigneeUserID,
-				Email: *userEmail,
-				Name:  *userName,
-			}
-		}
+	return tasks, count, nil
+}
 
-		// Populate assigned_by user if available
-		if assignedByUserID != nil && assignedByUserEmail != nil {
-			t.AssignedBy = &domain.User{
-				ID:    *assignedByUserID,
-				Email: *assignedByUserEmail,
-				Name:  *assignedByUserName,
-			}
-		}
+// UpdateTask updates a task. A non-empty rank is set in the same transaction,
+// so a task changing column never shows in its new column with its old rank.
+func (r *taskRepository) UpdateTask(ctx context.Context, id string, title, description, status, priority, rank string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
 
-		// Populate created_by user if available
-		if createdByUserID != nil && createdByUserEmail != nil {
-			t.CreatedBy = &domain.User{
-				ID:    *createdByUserID,
-				Email: *createdByUserEmail,
-				Name:  *createdByUserName,
-			}
+	// Lock the task and remember its status so a change can be recorded
+	var previousStatus string
+	if err := tx.QueryRow(ctx, `SELECT status FROM tasks WHERE id = $1 FOR UPDATE`, id).Scan(&previousStatus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
 		}
-
-		tasks = append(tasks, t)
+		return nil, apperrors.NewDatabaseError("failed to get task", err)
 	}
 
-	return tasks, count, nil
-}
-
-// UpdateTask updates a task
-func (r *taskRepository) UpdateTask(ctx context.Context, id string, title, description, status, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
 	// Update the task
 	if assigneeID != nil && dueDate != nil {
-		_, err := r.db.Exec(ctx, `
+		_, err := tx.Exec(ctx, `
 			UPDATE tasks
 			SET title = $1, description = $2, status = $3, priority = $4, assignee_id = $5, due_date = $6, updated_at = NOW()
 			WHERE id = $7
@@ -372,7 +372,7 @@ func (r *taskRepository) UpdateTask(ctx context.Context, id string, title, descr
 			return nil, apperrors.NewDatabaseError("failed to update task", err)
 		}
 	} else if assigneeID != nil {
-		_, err := r.db.Exec(ctx, `
+		_, err := tx.Exec(ctx, `
 			UPDATE tasks
 			SET title = $1, description = $2, status = $3, priority = $4, assignee_id = $5, updated_at = NOW()
 			WHERE id = $6
@@ -381,7 +381,7 @@ func (r *taskRepository) UpdateTask(ctx context.Context, id string, title, descr
 			return nil, apperrors.NewDatabaseError("failed to update task", err)
 		}
 	} else if dueDate != nil {
-		_, err := r.db.Exec(ctx, `
+		_, err := tx.Exec(ctx, `
 			UPDATE tasks
 			SET title = $1, description = $2, status = $3, priority = $4, due_date = $5, updated_at = NOW()
 			WHERE id = $6
@@ -390,7 +390,7 @@ func (r *taskRepository) UpdateTask(ctx context.Context, id string, title, descr
 			return nil, apperrors.NewDatabaseError("failed to update task", err)
 		}
 	} else {
-		_, err := r.db.Exec(ctx, `
+		_, err := tx.Exec(ctx, `
 			UPDATE tasks
 			SET title = $1, description = $2, status = $3, priority = $4, updated_at = NOW()
 			WHERE id = $5
@@ -400,75 +400,29 @@ func (r *taskRepository) UpdateTask(ctx context.Context, id string, title, descr
 		}
 	}
 
-	// Fetch and return the updated task with assignee, assigned_by, and created_by
-	const selectQuery = `
-		SELECT t.id, t.project_id, t.assignee_id, t.assigned_by_id, t.created_by_id, t.title, t.description, t.status, t.priority, t.due_date, t.created_at, t.updated_at,
-		       u.id, u.email, ab.id, ab.email, cb.id, cb.email
-		FROM tasks t
-		LEFT JOIN users u ON t.assignee_id = u.id
-		LEFT JOIN users ab ON t.assigned_by_id = ab.id
-		LEFT JOIN users cb ON t.created_by_id = cb.id
-		WHERE t.id = $1
-	`
-
-	task := &domain.Task{}
-	var userID *string
-	var userEmail *string
-	var assignedByUserID *string
-	var assignedByUserEmail *string
-	var createdByUserID *string
-	var createdByUserEmail *string
-
-	err := r.db.QueryRow(ctx, selectQuery, id).Scan(
-		&task.ID,
-		&task.ProjectID,
-		&task.AssigneeID,
-		&task.AssignedByID,
-		&task.CreatedByID,
-		&task.Title,
-		&task.Description,
-		&task.Status,
-		&task.Priority,
-		&task.DueDate,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-		&userID,
-		&userEmail,
-		&assignedByUserID,
-		&assignedByUserEmail,
-		&createdByUserID,
-		&createdByUserEmail,
-	)
-
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
+	if rank != "" {
+		if _, err := tx.Exec(ctx, `UPDATE tasks SET rank = $2 WHERE id = $1`, id, rank); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to update task rank", err)
 		}
-		return nil, apperrors.NewDatabaseError("failed to fetch updated task", err)
 	}
 
-	// Populate assignee if available
-	if userID != nil && userEmail != nil {
-		task.Assignee = &domain.User{
-			ID:    *userID,
-			Email: *userEmail,
+	if status != previousStatus {
+		if err := recordStatusChange(ctx, tx, id, &previousStatus, status); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to record task status", err)
 		}
 	}
 
-	// Populate assigned_by user if available
-	if assignedByUserID != nil && assignedByUserEmail != nil {
-		task.AssignedBy = &domain.User{
-			ID:    *assignedByUserID,
-			Email: *assignedByUserEmail,
-		}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit task", err)
 	}
 
-	// Populate created_by user if available
-	if createdByUserID != nil && createdByUserEmail != nil {
-		task.CreatedBy = &domain.User{
-			ID:    *createdByUserID,
-			Email: *createdByUserEmail,
+	// Fetch and return the updated task with assignee, assigned_by, and created_by
+	task, err := scanTask(r.db.QueryRow(ctx, taskSelectQuery+` WHERE t.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
 		}
+		return nil, apperrors.NewDatabaseError("failed to fetch updated task", err)
 	}
 
 	return task, nil
@@ -516,6 +470,155 @@ func (r *taskRepository) UnassignTask(ctx context.Context, taskID string) error
 	return nil
 }
 
+// UpdateEstimates sets a task's original and remaining estimates and story points
+func (r *taskRepository) UpdateEstimates(ctx context.Context, id string, originalMinutes, remainingMinutes, storyPoints *int) (*domain.Task, error) {
+	const query = `
+		UPDATE tasks
+		SET original_estimate_minutes = $2, remaining_estimate_minutes = $3, story_points = $4, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, id, originalMinutes, remainingMinutes, storyPoints)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to update task estimates", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
+	}
+
+	return r.GetTaskByID(ctx, id)
+}
+
+// UpdateSprint moves a task into a sprint, or back to the backlog when sprintID is nil
+func (r *taskRepository) UpdateSprint(ctx context.Context, id string, sprintID *string) (*domain.Task, error) {
+	const query = `
+		UPDATE tasks
+		SET sprint_id = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, id, sprintID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to update task sprint", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
+	}
+
+	return r.GetTaskByID(ctx, id)
+}
+
+// MoveTask places a task in a status column at the given rank, recording a
+// status change if the column differs
+func (r *taskRepository) MoveTask(ctx context.Context, id, status, rank string) (*domain.Task, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var previousStatus string
+	if err := tx.QueryRow(ctx, `SELECT status FROM tasks WHERE id = $1 FOR UPDATE`, id).Scan(&previousStatus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get task", err)
+	}
+
+	const query = `
+		UPDATE tasks
+		SET status = $2, rank = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := tx.Exec(ctx, query, id, status, rank); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to move task", err)
+	}
+
+	if status != previousStatus {
+		if err := recordStatusChange(ctx, tx, id, &previousStatus, status); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to record task status", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit task move", err)
+	}
+
+	return r.GetTaskByID(ctx, id)
+}
+
+// GetLastRank returns the highest rank in a status column, or "" if it is empty
+func (r *taskRepository) GetLastRank(ctx context.Context, projectID, status string) (string, error) {
+	const query = `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE project_id = $1 AND status = $2`
+
+	var rank string
+	if err := r.db.QueryRow(ctx, query, projectID, status).Scan(&rank); err != nil {
+		return "", apperrors.NewDatabaseError("failed to get last task rank", err)
+	}
+
+	return rank, nil
+}
+
+// GetAdjacentRank returns the nearest rank below (after) or above (before) the
+// given rank in a status column, ignoring excludeID, or "" if there is none
+func (r *taskRepository) GetAdjacentRank(ctx context.Context, projectID, status, rank, excludeID string, below bool) (string, error) {
+	query := `SELECT COALESCE(MAX(rank), '') FROM tasks WHERE project_id = $1 AND status = $2 AND rank < $3 AND id <> $4`
+	if below {
+		query = `SELECT COALESCE(MIN(rank), '') FROM tasks WHERE project_id = $1 AND status = $2 AND rank > $3 AND id <> $4`
+	}
+
+	var adjacent string
+	if err := r.db.QueryRow(ctx, query, projectID, status, rank, excludeID).Scan(&adjacent); err != nil {
+		return "", apperrors.NewDatabaseError("failed to get adjacent task rank", err)
+	}
+
+	return adjacent, nil
+}
+
+// ListColumnTaskIDs returns the IDs of a status column's tasks in rank order
+func (r *taskRepository) ListColumnTaskIDs(ctx context.Context, projectID, status string) ([]string, error) {
+	const query = `SELECT id FROM tasks WHERE project_id = $1 AND status = $2 ORDER BY rank ASC, id ASC`
+
+	rows, err := r.db.Query(ctx, query, projectID, status)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list column tasks", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan task ID", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating column tasks", err)
+	}
+
+	return ids, nil
+}
+
+// UpdateRanks assigns ranks[i] to the task ids[i] in a single statement
+func (r *taskRepository) UpdateRanks(ctx context.Context, ids, ranks []string) error {
+	const query = `
+		UPDATE tasks t
+		SET rank = u.rank
+		FROM unnest($1::uuid[], $2::text[]) AS u(id, rank)
+		WHERE t.id = u.id
+	`
+
+	if _, err := r.db.Exec(ctx, query, ids, ranks); err != nil {
+		return apperrors.NewDatabaseError("failed to update task ranks", err)
+	}
+
+	return nil
+}
+
 // DeleteTask deletes a task
 func (r *taskRepository) DeleteTask(ctx context.Context, id string) error {
 	const query = `DELETE FROM tasks WHERE id = $1`
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// TemplateRepository defines task and project template data access operations
+type TemplateRepository interface {
+	CreateTaskTemplate(ctx context.Context, template *domain.TaskTemplate) (*domain.TaskTemplate, error)
+	GetTaskTemplateByID(ctx context.Context, id string) (*domain.TaskTemplate, error)
+	ListTaskTemplates(ctx context.Context) ([]domain.TaskTemplate, error)
+	DeleteTaskTemplate(ctx context.Context, id string) error
+	CreateProjectTemplate(ctx context.Context, template *domain.ProjectTemplate) (*domain.ProjectTemplate, error)
+	GetProjectTemplateByID(ctx context.Context, id string) (*domain.ProjectTemplate, error)
+	ListProjectTemplates(ctx context.Context) ([]domain.ProjectTemplate, error)
+	DeleteProjectTemplate(ctx context.Context, id string) error
+}
+
+type templateRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTemplateRepository(db *pgxpool.Pool) TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+// CreateTaskTemplate creates a new task template
+func (r *templateRepository) CreateTaskTemplate(ctx context.Context, template *domain.TaskTemplate) (*domain.TaskTemplate, error) {
+	const query = `
+		INSERT INTO task_templates (id, created_by_id, name, title, description, priority, checklist, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING id, created_by_id, name, title, COALESCE(description, ''), priority, checklist, created_at, updated_at
+	`
+
+	created := &domain.TaskTemplate{}
+	err := r.db.QueryRow(ctx, query, uuid.New().String(), template.CreatedByID, template.Name, template.Title, template.Description, template.Priority, template.Checklist).Scan(
+		&created.ID,
+		&created.CreatedByID,
+		&created.Name,
+		&created.Title,
+		&created.Description,
+		&created.Priority,
+		&created.Checklist,
+		&created.CreatedAt,
+		&created.UpdatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to create task template", err)
+	}
+
+	return created, nil
+}
+
+// GetTaskTemplateByID retrieves a task template by ID
+func (r *templateRepository) GetTaskTemplateByID(ctx context.Context, id string) (*domain.TaskTemplate, error) {
+	const query = `
+		SELECT id, created_by_id, name, title, COALESCE(description, ''), priority, checklist, created_at, updated_at
+		FROM task_templates
+		WHERE id = $1
+	`
+
+	template := &domain.TaskTemplate{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&template.ID,
+		&template.CreatedByID,
+		&template.Name,
+		&template.Title,
+		&template.Description,
+		&template.Priority,
+		&template.Checklist,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTemplateNotFound, "task template not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get task template", err)
+	}
+
+	return template, nil
+}
+
+// ListTaskTemplates retrieves all task templates
+func (r *templateRepository) ListTaskTemplates(ctx context.Context) ([]domain.TaskTemplate, error) {
+	const query = `
+		SELECT id, created_by_id, name, title, COALESCE(description, ''), priority, checklist, created_at, updated_at
+		FROM task_templates
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list task templates", err)
+	}
+	defer rows.Close()
+
+	templates := make([]domain.TaskTemplate, 0)
+	for rows.Next() {
+		var t domain.TaskTemplate
+		err := rows.Scan(
+			&t.ID,
+			&t.CreatedByID,
+			&t.Name,
+			&t.Title,
+			&t.Description,
+			&t.Priority,
+			&t.Checklist,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan task template", err)
+		}
+		templates = append(templates, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating task templates", err)
+	}
+
+	return templates, nil
+}
+
+// DeleteTaskTemplate deletes a task template
+func (r *templateRepository) DeleteTaskTemplate(ctx context.Context, id string) error {
+	const query = `DELETE FROM task_templates WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to delete task template", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFoundError(apperrors.ErrTemplateNotFound, "task template not found")
+	}
+
+	return nil
+}
+
+// CreateProjectTemplate creates a project template together with its seed tasks
+func (r *templateRepository) CreateProjectTemplate(ctx context.Context, template *domain.ProjectTemplate) (*domain.ProjectTemplate, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	templateID := uuid.New().String()
+	const insertTemplate = `
+		INSERT INTO project_templates (id, created_by_id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+	`
+	if _, err := tx.Exec(ctx, insertTemplate, templateID, template.CreatedByID, template.Name, template.Description); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to create project template", err)
+	}
+
+	const insertTask = `
+		INSERT INTO project_template_tasks (id, template_id, title, description, priority, due_offset_days, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	for i, task := range template.Tasks {
+		if _, err := tx.Exec(ctx, insertTask, uuid.New().String(), templateID, task.Title, task.Description, task.Priority, task.DueOffsetDays, i); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to create project template task", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit project template", err)
+	}
+
+	return r.GetProjectTemplateByID(ctx, templateID)
+}
+
+// GetProjectTemplateByID retrieves a project template with its seed tasks in order
+func (r *templateRepository) GetProjectTemplateByID(ctx context.Context, id string) (*domain.ProjectTemplate, error) {
+	const query = `
+		SELECT id, created_by_id, name, COALESCE(description, ''), created_at, updated_at
+		FROM project_templates
+		WHERE id = $1
+	`
+
+	template := &domain.ProjectTemplate{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&template.ID,
+		&template.CreatedByID,
+		&template.Name,
+		&template.Description,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTemplateNotFound, "project template not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get project template", err)
+	}
+
+	const tasksQuery = `
+		SELECT id, template_id, title, COALESCE(description, ''), priority, due_offset_days, position
+		FROM project_template_tasks
+		WHERE template_id = $1
+		ORDER BY position ASC
+	`
+
+	rows, err := r.db.Query(ctx, tasksQuery, id)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list project template tasks", err)
+	}
+	defer rows.Close()
+
+	template.Tasks = make([]domain.ProjectTemplateTask, 0)
+	for rows.Next() {
+		var t domain.ProjectTemplateTask
+		err := rows.Scan(
+			&t.ID,
+			&t.TemplateID,
+			&t.Title,
+			&t.Description,
+			&t.Priority,
+			&t.DueOffsetDays,
+			&t.Position,
+		)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan project template task", err)
+		}
+		template.Tasks = append(template.Tasks, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating project template tasks", err)
+	}
+
+	return template, nil
+}
+
+// ListProjectTemplates retrieves all project templates without their seed tasks
+func (r *templateRepository) ListProjectTemplates(ctx context.Context) ([]domain.ProjectTemplate, error) {
+	const query = `
+		SELECT id, created_by_id, name, COALESCE(description, ''), created_at, updated_at
+		FROM project_templates
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list project templates", err)
+	}
+	defer rows.Close()
+
+	templates := make([]domain.ProjectTemplate, 0)
+	for rows.Next() {
+		var t domain.ProjectTemplate
+		err := rows.Scan(
+			&t.ID,
+			&t.CreatedByID,
+			&t.Name,
+			&t.Description,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan project template", err)
+		}
+		templates = append(templates, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating project templates", err)
+	}
+
+	return templates, nil
+}
+
+// DeleteProjectTemplate deletes a project template (cascades to its seed tasks)
+func (r *templateRepository) DeleteProjectTemplate(ctx context.Context, id string) error {
+	const query = `DELETE FROM project_templates WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to delete project template", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFoundError(apperrors.ErrTemplateNotFound, "project template not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// TimeEntryRepository defines time tracking data access operations
+type TimeEntryRepository interface {
+	CreateEntry(ctx context.Context, taskID, userID string, startedAt, endedAt time.Time, note string) (*domain.TimeEntry, error)
+	StartTimer(ctx context.Context, taskID, userID, note string) (*domain.TimeEntry, error)
+	StopTimer(ctx context.Context, userID string) (*domain.TimeEntry, error)
+	GetRunningTimer(ctx context.Context, userID string) (*domain.TimeEntry, error)
+	GetEntryByID(ctx context.Context, id string) (*domain.TimeEntry, error)
+	ListEntriesByTaskID(ctx context.Context, taskID string) ([]domain.TimeEntry, error)
+	DeleteEntry(ctx context.Context, id string) error
+	Timesheet(ctx context.Context, filter domain.TimesheetFilter) ([]domain.TimesheetRow, error)
+}
+
+type timeEntryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTimeEntryRepository(db *pgxpool.Pool) TimeEntryRepository {
+	return &timeEntryRepository{db: db}
+}
+
+const timeEntrySelectQuery = `
+	SELECT te.id, te.task_id, te.user_id, te.started_at, te.ended_at, te.duration_seconds, COALESCE(te.note, ''), te.created_at, te.updated_at,
+	       u.email, u.name
+	FROM time_entries te
+	JOIN users u ON te.user_id = u.id
+`
+
+func scanTimeEntry(row pgx.Row) (*domain.TimeEntry, error) {
+	entry := &domain.TimeEntry{}
+	var userEmail string
+	var userName *string
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.TaskID,
+		&entry.UserID,
+		&entry.StartedAt,
+		&entry.EndedAt,
+		&entry.DurationSeconds,
+		&entry.Note,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+		&userEmail,
+		&userName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.User = &domain.User{
+		ID:    entry.UserID,
+		Email: userEmail,
+		Name:  derefString(userName),
+	}
+
+	// A running timer reports the time elapsed so far
+	if entry.EndedAt == nil {
+		entry.DurationSeconds = int(time.Since(entry.StartedAt).Seconds())
+	}
+
+	return entry, nil
+}
+
+// CreateEntry logs a completed period of work on a task
+func (r *timeEntryRepository) CreateEntry(ctx context.Context, taskID, userID string, startedAt, endedAt time.Time, note string) (*domain.TimeEntry, error) {
+	entryID := uuid.New().String()
+	const query = `
+		INSERT INTO time_entries (id, task_id, user_id, started_at, ended_at, duration_seconds, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+	`
+
+	duration := int(endedAt.Sub(startedAt).Seconds())
+	if _, err := r.db.Exec(ctx, query, entryID, taskID, userID, startedAt, endedAt, duration, note); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to create time entry", err)
+	}
+
+	return r.GetEntryByID(ctx, entryID)
+}
+
+// StartTimer starts a running timer on a task. The partial unique index on
+// running entries rejects a second timer for the same user.
+func (r *timeEntryRepository) StartTimer(ctx context.Context, taskID, userID, note string) (*domain.TimeEntry, error) {
+	entryID := uuid.New().String()
+	const query = `
+		INSERT INTO time_entries (id, task_id, user_id, started_at, note, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), $4, NOW(), NOW())
+	`
+
+	if _, err := r.db.Exec(ctx, query, entryID, taskID, userID, note); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 is unique_violation
+			return nil, apperrors.NewConflictError(apperrors.ErrTimerRunning, "a timer is already running; stop it first")
+		}
+		return nil, apperrors.NewDatabaseError("failed to start timer", err)
+	}
+
+	return r.GetEntryByID(ctx, entryID)
+}
+
+// StopTimer stops the user's running timer and records its duration
+func (r *timeEntryRepository) StopTimer(ctx context.Context, userID string) (*domain.TimeEntry, error) {
+	const query = `
+		UPDATE time_entries
+		SET ended_at = NOW(), duration_seconds = GREATEST(EXTRACT(EPOCH FROM (NOW() - started_at))::INTEGER, 0), updated_at = NOW()
+		WHERE user_id = $1 AND ended_at IS NULL
+		RETURNING id
+	`
+
+	var entryID string
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&entryID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTimerNotRunning, "no timer is running")
+		}
+		return nil, apperrors.NewDatabaseError("failed to stop timer", err)
+	}
+
+	return r.GetEntryByID(ctx, entryID)
+}
+
+// GetRunningTimer retrieves the user's running timer
+func (r *timeEntryRepository) GetRunningTimer(ctx context.Context, userID string) (*domain.TimeEntry, error) {
+	entry, err := scanTimeEntry(r.db.QueryRow(ctx, timeEntrySelectQuery+` WHERE te.user_id = $1 AND te.ended_at IS NULL`, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTimerNotRunning, "no timer is running")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get running timer", err)
+	}
+
+	return entry, nil
+}
+
+// GetEntryByID retrieves a time entry by ID
+func (r *timeEntryRepository) GetEntryByID(ctx context.Context, id string) (*domain.TimeEntry, error) {
+	entry, err := scanTimeEntry(r.db.QueryRow(ctx, timeEntrySelectQuery+` WHERE te.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrTimeEntryNotFound, "time entry not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get time entry", err)
+	}
+
+	return entry, nil
+}
+
+// ListEntriesByTaskID retrieves all time entries of a task, newest first
+func (r *timeEntryRepository) ListEntriesByTaskID(ctx context.Context, taskID string) ([]domain.TimeEntry, error) {
+	rows, err := r.db.Query(ctx, timeEntrySelectQuery+` WHERE te.task_id = $1 ORDER BY te.started_at DESC`, taskID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list time entries", err)
+	}
+	defer rows.Close()
+
+	entries := make([]domain.TimeEntry, 0)
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan time entry", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating time entries", err)
+	}
+
+	return entries, nil
+}
+
+// DeleteEntry deletes a time entry
+func (r *timeEntryRepository) DeleteEntry(ctx context.Context, id string) error {
+	const query = `DELETE FROM time_entries WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to delete time entry", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NewNotFoundError(apperrors.ErrTimeEntryNotFound, "time entry not found")
+	}
+
+	return nil
+}
+
+// Timesheet aggregates completed time entries started within [From, To) by the
+// requested dimensions (user, project, day). Running timers are not counted.
+func (r *timeEntryRepository) Timesheet(ctx context.Context, filter domain.TimesheetFilter) ([]domain.TimesheetRow, error) {
+	var userCols, projectCols, dayCols = "NULL::uuid, NULL::text, NULL::text", "NULL::uuid, NULL::text", "NULL::text"
+	groupBy := make([]string, 0, 3)
+	orderBy := make([]string, 0, 3)
+	for _, dimension := range filter.GroupBy {
+		switch dimension {
+		case "user":
+			userCols = "u.id, u.email, u.name"
+			groupBy = append(groupBy, "u.id", "u.email", "u.name")
+			orderBy = append(orderBy, "u.email")
+		case "project":
+			projectCols = "p.id, p.name"
+			groupBy = append(groupBy, "p.id", "p.name")
+			orderBy = append(orderBy, "p.name")
+		case "day":
+			dayCols = "TO_CHAR(DATE(te.started_at), 'YYYY-MM-DD')"
+			groupBy = append(groupBy, "DATE(te.started_at)")
+			orderBy = append(orderBy, "DATE(te.started_at)")
+		}
+	}
+
+	query := `
+		SELECT ` + userCols + `, ` + projectCols + `, ` + dayCols + `,
+		       ROUND(SUM(te.duration_seconds) / 3600.0, 2)::FLOAT8, COUNT(*)
+		FROM time_entries te
+		JOIN users u ON te.user_id = u.id
+		JOIN tasks t ON te.task_id = t.id
+		JOIN projects p ON t.project_id = p.id
+		WHERE te.ended_at IS NOT NULL AND te.started_at >= $1 AND te.started_at < $2
+	`
+	args := []interface{}{filter.From, filter.To}
+
+	if filter.UserID != "" {
+		query += " AND te.user_id = $" + strconv.Itoa(len(args)+1)
+		args = append(args, filter.UserID)
+	}
+
+	if filter.ProjectID != "" {
+		query += " AND t.project_id = $" + strconv.Itoa(len(args)+1)
+		args = append(args, filter.ProjectID)
+	}
+
+	if len(groupBy) > 0 {
+		query += " GROUP BY " + strings.Join(groupBy, ", ")
+		query += " ORDER BY " + strings.Join(orderBy, ", ")
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to build timesheet", err)
+	}
+	defer rows.Close()
+
+	timesheet := make([]domain.TimesheetRow, 0)
+	for rows.Next() {
+		var row domain.TimesheetRow
+		var hours *float64
+		err := rows.Scan(
+			&row.UserID,
+			&row.UserEmail,
+			&row.UserName,
+			&row.ProjectID,
+			&row.ProjectName,
+			&row.Day,
+			&hours,
+			&row.Entries,
+		)
+		if err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan timesheet row", err)
+		}
+		if hours != nil {
+			row.Hours = *hours
+		}
+		timesheet = append(timesheet, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating timesheet", err)
+	}
+
+	return timesheet, nil
+}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// TwoFactorRepository defines TOTP and recovery code data access operations
+type TwoFactorRepository interface {
+	GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error)
+	SetPendingSecret(ctx context.Context, userID string, encryptedSecret []byte) error
+	Enable(ctx context.Context, userID string, step int64, codeHashes []string) error
+	Disable(ctx context.Context, userID string) error
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+	ListPlainSecrets(ctx context.Context) (map[string]string, error)
+	EncryptPlainSecret(ctx context.Context, userID, secret string, encryptedSecret []byte) error
+}
+
+type twoFactorRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTwoFactorRepository(db *pgxpool.Pool) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+// GetTwoFactor retrieves a user's TOTP state
+func (r *twoFactorRepository) GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error) {
+	const query = `
+		SELECT id, encrypted_totp_secret, totp_enabled, totp_last_step
+		FROM users
+		WHERE id = $1
+	`
+
+	tf := &domain.TwoFactor{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(&tf.UserID, &tf.EncryptedSecret, &tf.Enabled, &tf.LastStep)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to get two-factor settings", err)
+	}
+
+	return tf, nil
+}
+
+// SetPendingSecret stores a new, not yet confirmed, secret. It never replaces
+// the secret of a user who already has two-factor enabled.
+func (r *twoFactorRepository) SetPendingSecret(ctx context.Context, userID string, encryptedSecret []byte) error {
+	const query = `
+		UPDATE users
+		SET encrypted_totp_secret = $2, totp_secret = NULL, totp_last_step = NULL, updated_at = NOW()
+		WHERE id = $1 AND NOT totp_enabled
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, encryptedSecret)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to store two-factor secret", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewConflictError(apperrors.ErrTwoFactorEnabled, "two-factor authentication is already enabled")
+	}
+
+	return nil
+}
+
+// Enable turns on two-factor authentication, recording the step of the
+// confirming code and replacing any recovery codes
+func (r *twoFactorRepository) Enable(ctx context.Context, userID string, step int64, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		UPDATE users
+		SET totp_enabled = TRUE, totp_last_step = $2, updated_at = NOW()
+		WHERE id = $1 AND encrypted_totp_secret IS NOT NULL AND NOT totp_enabled
+	`
+	result, err := tx.Exec(ctx, query, userID, step)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to enable two-factor authentication", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NewConflictError(apperrors.ErrTwoFactorEnabled, "two-factor authentication is already enabled")
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// Disable turns off two-factor authentication and deletes the secret and
+// recovery codes
+func (r *twoFactorRepository) Disable(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		UPDATE users
+		SET encrypted_totp_secret = NULL, totp_secret = NULL, totp_enabled = FALSE, totp_last_step = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return apperrors.NewDatabaseError("failed to disable two-factor authentication", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return apperrors.NewDatabaseError("failed to delete recovery codes", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// UseStep records step as the latest accepted TOTP step. It reports false if
+// that step or a later one was already used, so each code works only once.
+func (r *twoFactorRepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	const query = `
+		UPDATE users
+		SET totp_last_step = $2
+		WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2)
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, apperrors.NewDatabaseError("failed to record two-factor code", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// UseRecoveryCode marks an unused recovery code as used, reporting whether
+// there was one
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	const query = `
+		UPDATE user_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, apperrors.NewDatabaseError("failed to use recovery code", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// ReplaceRecoveryCodes deletes a user's recovery codes and stores new ones
+func (r *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return nil
+}
+
+// CountRecoveryCodes returns how many unused recovery codes a user has
+func (r *twoFactorRepository) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`, userID).Scan(&count)
+	if err != nil {
+		return 0, apperrors.NewDatabaseError("failed to count recovery codes", err)
+	}
+
+	return count, nil
+}
+
+// ListPlainSecrets returns the TOTP secrets by user ID that are still stored
+// in plain text, from before secrets were encrypted
+func (r *twoFactorRepository) ListPlainSecrets(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, totp_secret FROM users WHERE totp_secret IS NOT NULL`)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list two-factor secrets", err)
+	}
+	defer rows.Close()
+
+	secrets := make(map[string]string)
+	for rows.Next() {
+		var userID, secret string
+		if err := rows.Scan(&userID, &secret); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan two-factor secret", err)
+		}
+		secrets[userID] = secret
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to list two-factor secrets", err)
+	}
+
+	return secrets, nil
+}
+
+// EncryptPlainSecret replaces a user's plain text secret with its encrypted
+// form, unless it has changed since it was read
+func (r *twoFactorRepository) EncryptPlainSecret(ctx context.Context, userID, secret string, encryptedSecret []byte) error {
+	const query = `
+		UPDATE users
+		SET encrypted_totp_secret = $3, totp_secret = NULL
+		WHERE id = $1 AND totp_secret = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID, secret, encryptedSecret); err != nil {
+		return apperrors.NewDatabaseError("failed to encrypt two-factor secret", err)
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userID string, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return apperrors.NewDatabaseError("failed to delete recovery codes", err)
+	}
+
+	const insert = `
+		INSERT INTO user_recovery_codes (id, user_id, code_hash, created_at)
+		VALUES ($1, $2, $3, NOW())
+	`
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(ctx, insert, uuid.New().String(), userID, hash); err != nil {
+			return apperrors.NewDatabaseError("failed to store recovery code", err)
+		}
+	}
+
+	return nil
+}
@@ -3,7 +3,6 @@ package repository
 import (
 	"context"
 	"errors"
-	"log"
 	"strings"
 
 	"github.com/google/uuid"
@@ -19,8 +18,42 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, email, passwordHash string) (*domain.User, error)
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
-	UpdateUser(ctx context.Context, id string, email string) (*domain.User, error)
+	UpdateUser(ctx context.Context, id string, name string) (*domain.User, error)
+	UpdatePassword(ctx context.Context, id, passwordHash string) (*domain.User, error)
+	GetSessionState(ctx context.Context, id string) (int, bool, error)
 	ListUsers(ctx context.Context) ([]domain.User, error)
+	SearchUsers(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]domain.User, int, error)
+	UpdateRole(ctx context.Context, id, role string) (*domain.User, error)
+	SetTwoFactorRequired(ctx context.Context, id string, required bool) (*domain.User, error)
+	DeactivateUser(ctx context.Context, id string) (*domain.User, error)
+	ReactivateUser(ctx context.Context, id string) (*domain.User, error)
+	ResetPassword(ctx context.Context, id, passwordHash string) (*domain.User, error)
+	TransferWork(ctx context.Context, fromID, toID, assignedByID string) (*domain.WorkTransfer, error)
+}
+
+// userColumns are the columns scanUser reads, in order
+const userColumns = `id, email, COALESCE(name, ''), password_hash, created_at, updated_at, totp_enabled, two_factor_required, role, deactivated_at, session_generation`
+
+// scanUser scans a row selecting userColumns
+func scanUser(row pgx.Row) (*domain.User, error) {
+	user := &domain.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.PasswordHash,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.TwoFactorEnabled,
+		&user.TwoFactorRequired,
+		&user.Role,
+		&user.DeactivatedAt,
+		&user.SessionGeneration,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
 type userRepository struct {
@@ -33,60 +66,37 @@ func NewUserRepository(db *pgxpool.Pool) UserRepository {
 
 // CreateUser creates a new user in the database
 func (r *userRepository) CreateUser(ctx context.Context, email, passwordHash string) (*domain.User, error) {
-	log.Printf("[CreateUser] Starting creation for email: %s", email)
 	userID := uuid.New().String()
-	log.Printf("[CreateUser] Generated userID: %s", userID)
 	const query = `
 		INSERT INTO users (id, email, password_hash, name, created_at, updated_at)
 		VALUES ($1, $2, $3, '', NOW(), NOW())
-		RETURNING id, email, name, password_hash, created_at, updated_at
-	`
+		RETURNING ` + userColumns
 
-	user := &domain.User{}
-	err := r.db.QueryRow(ctx, query, userID, email, passwordHash).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRow(ctx, query, userID, email, passwordHash))
 
 	if err != nil {
-		log.Printf("[CreateUser] Error occurred: %v", err)
 		// Check for duplicate key error (PostgreSQL)
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 is unique_violation
 			if strings.Contains(pgErr.ConstraintName, "email") {
-				log.Printf("[CreateUser] Duplicate email error")
 				return nil, apperrors.NewConflictError(apperrors.ErrEmailExists, "email already exists")
 			}
 		}
-		log.Printf("[CreateUser] Database error")
 		return nil, apperrors.NewDatabaseError("failed to create user", err)
 	}
 
-	log.Printf("[CreateUser] User created successfully: %s", user.ID)
 	return user, nil
 }
 
 // GetUserByID retrieves a user by ID
 func (r *userRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	const query = `
-		SELECT id, email, name, password_hash, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE id = $1
 	`
 
-	user := &domain.User{}
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRow(ctx, query, id))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -101,20 +111,12 @@ func (r *userRepository) GetUserByID(ctx context.Context, id string) (*domain.Us
 // GetUserByEmail retrieves a user by email
 func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	const query = `
-		SELECT id, email, name, password_hash, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
 
-	user := &domain.User{}
-	err := r.db.QueryRow(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRow(ctx, query, email))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -126,11 +128,12 @@ func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 	return user, nil
 }
 
-// ListUsers retrieves all users
+// ListUsers retrieves all active users
 func (r *userRepository) ListUsers(ctx context.Context) ([]domain.User, error) {
 	const query = `
-		SELECT id, email, COALESCE(name, '') as name, password_hash, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
+		WHERE deactivated_at IS NULL
 		ORDER BY email ASC
 	`
 
@@ -142,19 +145,11 @@ func (r *userRepository) ListUsers(ctx context.Context) ([]domain.User, error) {
 
 	users := make([]domain.User, 0)
 	for rows.Next() {
-		var u domain.User
-		err := rows.Scan(
-			&u.ID,
-			&u.Email,
-			&u.Name,
-			&u.PasswordHash,
-			&u.CreatedAt,
-			&u.UpdatedAt,
-		)
+		u, err := scanUser(rows)
 		if err != nil {
 			return nil, apperrors.NewDatabaseError("failed to scan user", err)
 		}
-		users = append(users, u)
+		users = append(users, *u)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -170,18 +165,9 @@ func (r *userRepository) UpdateUser(ctx context.Context, id string, name string)
 		UPDATE users
 		SET name = $2, updated_at = NOW()
 		WHERE id = $1
-		RETURNING id, email, name, password_hash, created_at, updated_at
-	`
+		RETURNING ` + userColumns
 
-	user := &domain.User{}
-	err := r.db.QueryRow(ctx, query, id, name).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRow(ctx, query, id, name))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -195,3 +181,312 @@ func (r *userRepository) UpdateUser(ctx context.Context, id string, name string)
 
 	return user, nil
 }
+
+// UpdatePassword sets a user's password hash and revokes their sessions by
+// starting a new session generation
+func (r *userRepository) UpdatePassword(ctx context.Context, id, passwordHash string) (*domain.User, error) {
+	const query = `
+		UPDATE users
+		SET password_hash = $2, session_generation = session_generation + 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, id, passwordHash))
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to update password", err)
+	}
+
+	return user, nil
+}
+
+// GetSessionState returns a user's current session generation and whether
+// they are deactivated
+func (r *userRepository) GetSessionState(ctx context.Context, id string) (int, bool, error) {
+	const query = `SELECT session_generation, deactivated_at IS NOT NULL FROM users WHERE id = $1`
+
+	var generation int
+	var deactivated bool
+	if err := r.db.QueryRow(ctx, query, id).Scan(&generation, &deactivated); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return 0, false, apperrors.NewDatabaseError("failed to get user sessions", err)
+	}
+
+	return generation, deactivated, nil
+}
+
+// userFilterClause matches users against a domain.UserFilter passed as $1
+// (query), $2 (role) and $3 (status)
+const userFilterClause = `
+	WHERE ($1 = '' OR strpos(lower(email), lower($1)) > 0 OR strpos(lower(COALESCE(name, '')), lower($1)) > 0)
+	  AND ($2 = '' OR role = $2)
+	  AND ($3 = '' OR ($3 = 'deactivated') = (deactivated_at IS NOT NULL))
+`
+
+// SearchUsers retrieves users matching filter with pagination, deactivated
+// users included, ordered by email
+func (r *userRepository) SearchUsers(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]domain.User, int, error) {
+	var total int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM users`+userFilterClause, filter.Query, filter.Role, filter.Status).Scan(&total)
+	if err != nil {
+		return nil, 0, apperrors.NewDatabaseError("failed to count users", err)
+	}
+
+	const query = `SELECT ` + userColumns + ` FROM users` + userFilterClause + `
+		ORDER BY email ASC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.Query, filter.Role, filter.Status, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.NewDatabaseError("failed to search users", err)
+	}
+	defer rows.Close()
+
+	users := make([]domain.User, 0)
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, apperrors.NewDatabaseError("failed to scan user", err)
+		}
+		users = append(users, *u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, apperrors.NewDatabaseError("error iterating users", err)
+	}
+
+	return users, total, nil
+}
+
+// UpdateRole sets a user's role. It refuses to demote the last active admin.
+func (r *userRepository) UpdateRole(ctx context.Context, id, role string) (*domain.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if role != domain.RoleAdmin {
+		if err := ensureAdminRemains(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	const query = `
+		UPDATE users
+		SET role = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(ctx, query, id, role))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to update role", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return user, nil
+}
+
+// ensureAdminRemains returns a conflict error if id is the only active admin.
+// It locks the active admins until tx ends, so concurrent demotions and
+// deactivations are checked one after another and can't each leave the
+// other as the last admin.
+func ensureAdminRemains(ctx context.Context, tx pgx.Tx, id string) error {
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM users
+		WHERE role = $1 AND deactivated_at IS NULL
+		FOR UPDATE`, domain.RoleAdmin)
+	if err != nil {
+		return apperrors.NewDatabaseError("failed to lock admins", err)
+	}
+	defer rows.Close()
+
+	var adminIDs []string
+	for rows.Next() {
+		var adminID string
+		if err := rows.Scan(&adminID); err != nil {
+			return apperrors.NewDatabaseError("failed to scan admin", err)
+		}
+		adminIDs = append(adminIDs, adminID)
+	}
+	if err := rows.Err(); err != nil {
+		return apperrors.NewDatabaseError("failed to lock admins", err)
+	}
+
+	if len(adminIDs) == 1 && adminIDs[0] == id {
+		return apperrors.NewConflictError(apperrors.ErrLastAdmin, "at least one active admin must remain")
+	}
+	return nil
+}
+
+// SetTwoFactorRequired sets whether a user must use two-factor authentication
+func (r *userRepository) SetTwoFactorRequired(ctx context.Context, id string, required bool) (*domain.User, error) {
+	const query = `
+		UPDATE users
+		SET two_factor_required = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, id, required))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to update two-factor requirement", err)
+	}
+
+	return user, nil
+}
+
+// DeactivateUser stops a user signing in and revokes their sessions, so
+// reactivating them later doesn't revive old tokens. It refuses to deactivate
+// the last active admin.
+func (r *userRepository) DeactivateUser(ctx context.Context, id string) (*domain.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := ensureAdminRemains(ctx, tx, id); err != nil {
+		return nil, err
+	}
+
+	const query = `
+		UPDATE users
+		SET deactivated_at = NOW(), session_generation = session_generation + 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to deactivate user", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return user, nil
+}
+
+// ReactivateUser lets a deactivated user sign in again
+func (r *userRepository) ReactivateUser(ctx context.Context, id string) (*domain.User, error) {
+	const query = `
+		UPDATE users
+		SET deactivated_at = NULL, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to reactivate user", err)
+	}
+
+	return user, nil
+}
+
+// ResetPassword sets a user's password on an admin's behalf, revoking all
+// their sessions and discarding any pending email change
+func (r *userRepository) ResetPassword(ctx context.Context, id, passwordHash string) (*domain.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		UPDATE users
+		SET password_hash = $2, session_generation = session_generation + 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+
+	user, err := scanUser(tx.QueryRow(ctx, query, id, passwordHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+		}
+		return nil, apperrors.NewDatabaseError("failed to reset password", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM email_change_requests WHERE user_id = $1`, id); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to discard email change request", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return user, nil
+}
+
+// TransferWork hands a departing user's projects, and their assignments on
+// unfinished tasks and checklist items, to another user. assignedByID is
+// recorded as having made the task assignments.
+func (r *userRepository) TransferWork(ctx context.Context, fromID, toID, assignedByID string) (*domain.WorkTransfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	transfer := &domain.WorkTransfer{FromUserID: fromID, ToUserID: toID}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE projects
+		SET user_id = $2, updated_at = NOW()
+		WHERE user_id = $1
+	`, fromID, toID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to transfer projects", err)
+	}
+	transfer.ProjectsTransferred = int(result.RowsAffected())
+
+	result, err = tx.Exec(ctx, `
+		UPDATE tasks
+		SET assignee_id = $2, assigned_by_id = $3, updated_at = NOW()
+		WHERE assignee_id = $1 AND status <> 'DONE'
+	`, fromID, toID, assignedByID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to reassign tasks", err)
+	}
+	transfer.TasksReassigned = int(result.RowsAffected())
+
+	result, err = tx.Exec(ctx, `
+		UPDATE task_checklist_items ci
+		SET assignee_id = $2, updated_at = NOW()
+		FROM tasks t
+		WHERE ci.task_id = t.id
+		  AND ci.assignee_id = $1
+		  AND NOT ci.is_done
+		  AND t.status <> 'DONE'
+	`, fromID, toID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to reassign checklist items", err)
+	}
+	transfer.ChecklistItemsReassigned = int(result.RowsAffected())
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.NewDatabaseError("failed to commit transaction", err)
+	}
+
+	return transfer, nil
+}
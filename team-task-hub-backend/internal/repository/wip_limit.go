@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// WIPLimitRepository defines work-in-progress limit data access operations
+type WIPLimitRepository interface {
+	GetLimits(ctx context.Context, projectID string) (*domain.WIPLimits, error)
+	UpsertLimits(ctx context.Context, limits *domain.WIPLimits) (*domain.WIPLimits, error)
+	DeleteLimits(ctx context.Context, projectID string) error
+	CountByStatus(ctx context.Context, projectID string) (map[string]int, error)
+	CountInProgressByAssignee(ctx context.Context, projectID string) ([]domain.AssigneeWIPCount, error)
+	CountAssigneeInProgress(ctx context.Context, projectID, assigneeID string) (int, error)
+}
+
+type wipLimitRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWIPLimitRepository(db *pgxpool.Pool) WIPLimitRepository {
+	return &wipLimitRepository{db: db}
+}
+
+const wipLimitColumns = `project_id, status_limits, assignee_in_progress_limit, enforcement, created_at, updated_at`
+
+func scanWIPLimits(row pgx.Row) (*domain.WIPLimits, error) {
+	limits := &domain.WIPLimits{}
+	err := row.Scan(
+		&limits.ProjectID,
+		&limits.StatusLimits,
+		&limits.AssigneeInProgressLimit,
+		&limits.Enforcement,
+		&limits.CreatedAt,
+		&limits.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// GetLimits retrieves a project's WIP limits, or nil if none are configured
+func (r *wipLimitRepository) GetLimits(ctx context.Context, projectID string) (*domain.WIPLimits, error) {
+	limits, err := scanWIPLimits(r.db.QueryRow(ctx, `SELECT `+wipLimitColumns+` FROM project_wip_limits WHERE project_id = $1`, projectID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.NewDatabaseError("failed to get WIP limits", err)
+	}
+
+	return limits, nil
+}
+
+// UpsertLimits creates or replaces a project's WIP limits
+func (r *wipLimitRepository) UpsertLimits(ctx context.Context, limits *domain.WIPLimits) (*domain.WIPLimits, error) {
+	query := `
+		INSERT INTO project_wip_limits (project_id, status_limits, assignee_in_progress_limit, enforcement, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		ON CONFLICT (project_id) DO UPDATE
+		SET status_limits = EXCLUDED.status_limits,
+		    assignee_in_progress_limit = EXCLUDED.assignee_in_progress_limit,
+		    enforcement = EXCLUDED.enforcement,
+		    updated_at = NOW()
+		RETURNING ` + wipLimitColumns
+
+	saved, err := scanWIPLimits(r.db.QueryRow(ctx, query, limits.ProjectID, limits.StatusLimits, limits.AssigneeInProgressLimit, limits.Enforcement))
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to save WIP limits", err)
+	}
+
+	return saved, nil
+}
+
+// DeleteLimits removes a project's WIP limits
+func (r *wipLimitRepository) DeleteLimits(ctx context.Context, projectID string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM project_wip_limits WHERE project_id = $1`, projectID); err != nil {
+		return apperrors.NewDatabaseError("failed to delete WIP limits", err)
+	}
+
+	return nil
+}
+
+// CountByStatus counts a project's tasks in each status column
+func (r *wipLimitRepository) CountByStatus(ctx context.Context, projectID string) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, `SELECT status, COUNT(*) FROM tasks WHERE project_id = $1 GROUP BY status`, projectID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to count tasks by status", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan status count", err)
+		}
+		counts[status] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating status counts", err)
+	}
+
+	return counts, nil
+}
+
+// CountInProgressByAssignee counts each assignee's in-progress tasks in a project
+func (r *wipLimitRepository) CountInProgressByAssignee(ctx context.Context, projectID string) ([]domain.AssigneeWIPCount, error) {
+	const query = `
+		SELECT u.id, u.email, u.name, COUNT(*)
+		FROM tasks t
+		JOIN users u ON t.assignee_id = u.id
+		WHERE t.project_id = $1 AND t.status = 'IN_PROGRESS'
+		GROUP BY u.id, u.email, u.name
+		ORDER BY COUNT(*) DESC, u.email ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("failed to count in-progress tasks by assignee", err)
+	}
+	defer rows.Close()
+
+	counts := make([]domain.AssigneeWIPCount, 0)
+	for rows.Next() {
+		var c domain.AssigneeWIPCount
+		var email string
+		var name *string
+		if err := rows.Scan(&c.AssigneeID, &email, &name, &c.Count); err != nil {
+			return nil, apperrors.NewDatabaseError("failed to scan assignee count", err)
+		}
+		c.Assignee = &domain.User{ID: c.AssigneeID, Email: email, Name: derefString(name)}
+		counts = append(counts, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewDatabaseError("error iterating assignee counts", err)
+	}
+
+	return counts, nil
+}
+
+// CountAssigneeInProgress counts one assignee's in-progress tasks in a project
+func (r *wipLimitRepository) CountAssigneeInProgress(ctx context.Context, projectID, assigneeID string) (int, error) {
+	const query = `SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND assignee_id = $2 AND status = 'IN_PROGRESS'`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, projectID, assigneeID).Scan(&count); err != nil {
+		return 0, apperrors.NewDatabaseError("failed to count assignee in-progress tasks", err)
+	}
+
+	return count, nil
+}
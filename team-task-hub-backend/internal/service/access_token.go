@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// AccessTokenService defines personal access token operations
+type AccessTokenService interface {
+	CreateToken(ctx context.Context, userID, name string, scopes []string, expiresInDays *int) (*domain.CreatedAccessToken, error)
+	ListTokens(ctx context.Context, userID string) ([]domain.AccessToken, error)
+	RevokeToken(ctx context.Context, userID, tokenID string) error
+	Authenticate(ctx context.Context, token string) (*domain.AccessToken, error)
+}
+
+// AccessTokenPolicy limits the tokens a user can create
+type AccessTokenPolicy struct {
+	// MaxLifetime is the longest expiry, and the expiry of tokens created
+	// without one; zero allows tokens that never expire
+	MaxLifetime time.Duration
+	MaxPerUser  int
+}
+
+type accessTokenService struct {
+	accessTokenRepo repository.AccessTokenRepository
+	policy          AccessTokenPolicy
+}
+
+func NewAccessTokenService(accessTokenRepo repository.AccessTokenRepository, policy AccessTokenPolicy) AccessTokenService {
+	return &accessTokenService{
+		accessTokenRepo: accessTokenRepo,
+		policy:          policy,
+	}
+}
+
+// CreateToken creates a token for the user. The token itself is only in the
+// result; afterwards only its hash is kept.
+func (s *accessTokenService) CreateToken(ctx context.Context, userID, name string, scopes []string, expiresInDays *int) (*domain.CreatedAccessToken, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccessTokenService.CreateToken")
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrEmptyName, "token name cannot be empty")
+	}
+	if len(name) > 100 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "token name cannot exceed 100 characters")
+	}
+
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime, err := s.lifetime(expiresInDays)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.accessTokenRepo.CountTokensByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= s.policy.MaxPerUser {
+		return nil, apperrors.NewConflictError(apperrors.ErrTooManyTokens,
+			fmt.Sprintf("you can have at most %d access tokens; revoke one first", s.policy.MaxPerUser))
+	}
+
+	secret, prefix, err := utils.GenerateAccessToken()
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate access token", err)
+	}
+
+	token := &domain.AccessToken{
+		UserID: userID,
+		Name:   name,
+		Prefix: prefix,
+		Scopes: scopes,
+	}
+	if err := s.accessTokenRepo.CreateToken(ctx, token, utils.HashAccessToken(secret), lifetime); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "access token created", "pat_id", token.ID, "scopes", scopes)
+	return &domain.CreatedAccessToken{AccessToken: *token, Token: secret}, nil
+}
+
+// lifetime returns the expiry for a new token, from the days requested
+func (s *accessTokenService) lifetime(expiresInDays *int) (time.Duration, error) {
+	if expiresInDays == nil {
+		return s.policy.MaxLifetime, nil
+	}
+
+	if *expiresInDays < 1 {
+		return 0, apperrors.NewValidationError(apperrors.ErrInvalidInput, "expires_in_days must be at least 1")
+	}
+	lifetime := time.Duration(*expiresInDays) * 24 * time.Hour
+	if s.policy.MaxLifetime > 0 && lifetime > s.policy.MaxLifetime {
+		return 0, apperrors.NewValidationError(apperrors.ErrInvalidInput,
+			fmt.Sprintf("expires_in_days cannot exceed %d", int(s.policy.MaxLifetime/(24*time.Hour))))
+	}
+	return lifetime, nil
+}
+
+// normalizeScopes checks the requested scopes and returns them sorted
+// without duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "at least one scope is required")
+	}
+
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(domain.AccessTokenScopes, scope) {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput,
+				fmt.Sprintf("unknown scope %q; valid scopes are %s", scope, strings.Join(domain.AccessTokenScopes, ", ")))
+		}
+		normalized = append(normalized, scope)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+// ListTokens retrieves the user's tokens, without the tokens themselves
+func (s *accessTokenService) ListTokens(ctx context.Context, userID string) ([]domain.AccessToken, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccessTokenService.ListTokens")
+	defer span.End()
+
+	return s.accessTokenRepo.ListTokensByUserID(ctx, userID)
+}
+
+// RevokeToken deletes one of the user's tokens; it stops working at once
+func (s *accessTokenService) RevokeToken(ctx context.Context, userID, tokenID string) error {
+	ctx, span := tracing.StartSpan(ctx, "AccessTokenService.RevokeToken")
+	defer span.End()
+
+	if _, err := uuid.Parse(tokenID); err != nil {
+		return apperrors.NewNotFoundError(apperrors.ErrAccessTokenNotFound, "access token not found")
+	}
+
+	if err := s.accessTokenRepo.DeleteToken(ctx, tokenID, userID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "access token revoked", "pat_id", tokenID)
+	return nil
+}
+
+// Authenticate resolves a bearer token to its access token record, recording
+// when and from where it was used
+func (s *accessTokenService) Authenticate(ctx context.Context, token string) (*domain.AccessToken, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccessTokenService.Authenticate")
+	defer span.End()
+
+	accessToken, expired, err := s.accessTokenRepo.GetTokenByHash(ctx, utils.HashAccessToken(token))
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == apperrors.ErrAccessTokenNotFound {
+			return nil, apperrors.NewAuthError(apperrors.ErrInvalidToken, "invalid access token")
+		}
+		return nil, err
+	}
+	if expired {
+		return nil, apperrors.NewAuthError(apperrors.ErrTokenExpired, "access token has expired")
+	}
+
+	// A failure to record the use shouldn't fail the request
+	if err := s.accessTokenRepo.TouchToken(ctx, accessToken.ID, utils.ClientInfoFromContext(ctx).IP); err != nil {
+		slog.ErrorContext(ctx, "failed to record access token use", "pat_id", accessToken.ID, "error", err)
+	}
+
+	return accessToken, nil
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/mail"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// AccountService defines changes users make to their own sign-in details.
+// Each change revokes the user's other sessions and returns a fresh token
+// for the current one.
+type AccountService interface {
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*domain.LoginResult, error)
+	RequestEmailChange(ctx context.Context, userID, newEmail, password string) (*domain.EmailChangeRequest, error)
+	ConfirmEmailChange(ctx context.Context, userID, token string) (*domain.LoginResult, error)
+	ListAuditEvents(ctx context.Context, userID string, limit int) ([]domain.AuditEvent, error)
+}
+
+// EmailChangePolicy controls the confirmation link sent to a new address
+type EmailChangePolicy struct {
+	// ConfirmURL is the frontend page the link opens, with the token in the
+	// URL fragment
+	ConfirmURL string
+	TokenTTL   time.Duration
+}
+
+type accountService struct {
+	userRepo          repository.UserRepository
+	loginEventRepo    repository.LoginEventRepository
+	auditEventRepo    repository.AuditEventRepository
+	emailChangeRepo   repository.EmailChangeRepository
+	mailer            mail.Sender
+	loginPolicy       LoginPolicy
+	emailChangePolicy EmailChangePolicy
+}
+
+func NewAccountService(userRepo repository.UserRepository, loginEventRepo repository.LoginEventRepository, auditEventRepo repository.AuditEventRepository, emailChangeRepo repository.EmailChangeRepository, mailer mail.Sender, loginPolicy LoginPolicy, emailChangePolicy EmailChangePolicy) AccountService {
+	return &accountService{
+		userRepo:          userRepo,
+		loginEventRepo:    loginEventRepo,
+		auditEventRepo:    auditEventRepo,
+		emailChangeRepo:   emailChangeRepo,
+		mailer:            mailer,
+		loginPolicy:       loginPolicy,
+		emailChangePolicy: emailChangePolicy,
+	}
+}
+
+// ChangePassword replaces the user's password after checking the current one
+func (s *accountService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*domain.LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccountService.ChangePassword")
+	defer span.End()
+
+	if appErr := utils.ValidatePassword(newPassword); appErr != nil {
+		return nil, appErr
+	}
+	if newPassword == currentPassword {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "new password must be different from the current one")
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.confirmPassword(ctx, user, currentPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to hash password", err)
+	}
+
+	user, err = s.userRepo.UpdatePassword(ctx, userID, hashedPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "password changed", "user_id", userID)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID: userID,
+		Action: domain.AuditPasswordChanged,
+	})
+
+	return newSession(user)
+}
+
+// RequestEmailChange emails a confirmation link to newEmail after checking
+// the user's password. The address changes once ConfirmEmailChange is called
+// with the link's token.
+func (s *accountService) RequestEmailChange(ctx context.Context, userID, newEmail, password string) (*domain.EmailChangeRequest, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccountService.RequestEmailChange")
+	defer span.End()
+
+	newEmail = strings.TrimSpace(newEmail)
+	if appErr := utils.ValidateEmail(newEmail); appErr != nil {
+		return nil, appErr
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "new email must be different from the current one")
+	}
+	if err := s.confirmPassword(ctx, user, password); err != nil {
+		return nil, err
+	}
+
+	// Checked again on confirmation, as the address may be taken meanwhile
+	if _, err := s.userRepo.GetUserByEmail(ctx, newEmail); err == nil {
+		return nil, apperrors.NewConflictError(apperrors.ErrEmailExists, "email already exists")
+	} else if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != apperrors.ErrUserNotFound {
+		return nil, err
+	}
+
+	token, err := utils.GenerateConfirmationToken()
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate confirmation token", err)
+	}
+	request := &domain.EmailChangeRequest{
+		NewEmail:  newEmail,
+		ExpiresAt: time.Now().Add(s.emailChangePolicy.TokenTTL),
+	}
+	if err := s.emailChangeRepo.SaveRequest(ctx, userID, request, utils.HashConfirmationToken(token)); err != nil {
+		return nil, err
+	}
+
+	link := s.emailChangePolicy.ConfirmURL + "#" + url.Values{"token": {token}}.Encode()
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Someone asked to change the email address of the Team Task Hub account %s to this address.\n\n"+
+			"To confirm, sign in to that account and open this link before %s:\n\n%s\n\n"+
+			"If you didn't ask for this, ignore this email and the address won't change.\n",
+			user.Email, request.ExpiresAt.UTC().Format("2 Jan 2006 15:04 MST"), link),
+	})
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to send confirmation email", err)
+	}
+
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  userID,
+		Action:  domain.AuditEmailChangeRequested,
+		Details: map[string]string{"new_email": newEmail},
+	})
+
+	return request, nil
+}
+
+// ConfirmEmailChange switches the user to the address their pending request
+// was sent to, and lets the previous address know
+func (s *accountService) ConfirmEmailChange(ctx context.Context, userID, token string) (*domain.LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccountService.ConfirmEmailChange")
+	defer span.End()
+
+	if strings.TrimSpace(token) == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "token is required")
+	}
+
+	previous, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failed change, say to an address taken meanwhile, keeps the link usable
+	user, err := s.emailChangeRepo.ConfirmRequest(ctx, userID, utils.HashConfirmationToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "email changed", "user_id", userID)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  userID,
+		Action:  domain.AuditEmailChanged,
+		Details: map[string]string{"old_email": previous.Email, "new_email": user.Email},
+	})
+
+	// The previous owner of the address should hear about it if this wasn't them
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      previous.Email,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf("The email address of your Team Task Hub account was changed from %s to %s, "+
+			"and every other session was signed out.\n\n"+
+			"If you didn't do this, contact your administrator.\n",
+			previous.Email, user.Email),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to send email change notice", "error", err)
+	}
+
+	return newSession(user)
+}
+
+// ListAuditEvents returns the most recent changes to the user's account
+func (s *accountService) ListAuditEvents(ctx context.Context, userID string, limit int) ([]domain.AuditEvent, error) {
+	ctx, span := tracing.StartSpan(ctx, "AccountService.ListAuditEvents")
+	defer span.End()
+
+	if limit < 1 || limit > 100 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "limit must be between 1 and 100")
+	}
+
+	return s.auditEventRepo.ListEventsByUserID(ctx, userID, limit)
+}
+
+// confirmPassword checks a signed-in user's password before a sensitive
+// change. Wrong guesses count towards the login lockout, so a stolen session
+// can't be used to guess the password.
+func (s *accountService) confirmPassword(ctx context.Context, user *domain.User, password string) error {
+	if user.PasswordHash == "" {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "this account signs in with single sign-on and has no password")
+	}
+
+	client := utils.ClientInfoFromContext(ctx)
+	event := &domain.LoginEvent{
+		UserID:    &user.ID,
+		Email:     strings.ToLower(user.Email),
+		IPAddress: client.IP,
+		UserAgent: client.UserAgent,
+	}
+	if err := checkLoginBlocks(ctx, s.loginEventRepo, s.loginPolicy, event); err != nil {
+		return err
+	}
+
+	if !utils.VerifyPassword(user.PasswordHash, password) {
+		recordLoginEvent(ctx, s.loginEventRepo, event, domain.LoginFailureInvalidCredentials)
+		return apperrors.NewAuthError(apperrors.ErrInvalidPassword, "current password is incorrect")
+	}
+
+	return nil
+}
+
+// newSession issues the token that replaces the caller's revoked one, in the
+// session generation the change started
+func newSession(user *domain.User) (*domain.LoginResult, error) {
+	token, err := utils.GenerateToken(user.ID, user.Email, user.SessionGeneration)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate token", err)
+	}
+
+	return &domain.LoginResult{User: user, Token: token}, nil
+}
+
+// recordAuditEvent stores a change to an account, made by its user unless
+// event names another actor. A failure to record is logged rather than
+// failing the change, which has already been made.
+func recordAuditEvent(ctx context.Context, auditEventRepo repository.AuditEventRepository, event *domain.AuditEvent) {
+	if event.ActorID == nil {
+		event.ActorID = &event.UserID
+	}
+	client := utils.ClientInfoFromContext(ctx)
+	event.IPAddress = client.IP
+	event.UserAgent = client.UserAgent
+
+	if err := auditEventRepo.RecordEvent(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "failed to record audit event", "action", event.Action, "error", err)
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/mail"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// fakeEmailChangeRepo keeps each user's pending email change and applies it
+// to a fakeUserRepo
+type fakeEmailChangeRepo struct {
+	repository.EmailChangeRepository
+	users    *fakeUserRepo
+	requests map[string]fakeEmailChange
+}
+
+type fakeEmailChange struct {
+	request   domain.EmailChangeRequest
+	tokenHash string
+}
+
+func newFakeEmailChangeRepo(users *fakeUserRepo) *fakeEmailChangeRepo {
+	return &fakeEmailChangeRepo{users: users, requests: make(map[string]fakeEmailChange)}
+}
+
+func (r *fakeEmailChangeRepo) SaveRequest(ctx context.Context, userID string, request *domain.EmailChangeRequest, tokenHash string) error {
+	r.requests[userID] = fakeEmailChange{request: *request, tokenHash: tokenHash}
+	return nil
+}
+
+func (r *fakeEmailChangeRepo) ConfirmRequest(ctx context.Context, userID, tokenHash string) (*domain.User, error) {
+	pending, ok := r.requests[userID]
+	if !ok || pending.tokenHash != tokenHash {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidConfirmation, "confirmation link not found or already used")
+	}
+	delete(r.requests, userID)
+	if time.Now().After(pending.request.ExpiresAt) {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidConfirmation, "confirmation link expired")
+	}
+	return r.users.update(userID, func(user *domain.User) {
+		user.Email = pending.request.NewEmail
+		user.SessionGeneration++
+	})
+}
+
+// fakeMailer keeps the messages it was asked to send
+type fakeMailer struct {
+	sent []mail.Message
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg mail.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+var accountTestPolicy = LoginPolicy{
+	MaxAttempts:     3,
+	LockDuration:    time.Minute,
+	MaxLockDuration: time.Hour,
+}
+
+// newAccountTest returns an account service for ann@example.com, user u1,
+// with password "correct horse 1", and the user service that checks sessions
+func newAccountTest(t *testing.T) (AccountService, UserService, *fakeUserRepo, *fakeLoginEventRepo, *fakeAuditEventRepo, *fakeMailer) {
+	t.Helper()
+	hash, err := utils.HashPassword("correct horse 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := newFakeUserRepo(&domain.User{ID: "u1", Email: "ann@example.com", PasswordHash: hash, SessionGeneration: 1})
+	loginEvents := &fakeLoginEventRepo{}
+	audit := &fakeAuditEventRepo{}
+	mailer := &fakeMailer{}
+	accounts := NewAccountService(users, loginEvents, audit, newFakeEmailChangeRepo(users), mailer, accountTestPolicy, EmailChangePolicy{
+		ConfirmURL: "https://app.example.com/confirm-email",
+		TokenTTL:   time.Hour,
+	})
+	return accounts, NewUserService(users, loginEvents, nil, accountTestPolicy), users, loginEvents, audit, mailer
+}
+
+// assertSessionMoved checks that a token of the old generation is refused
+// and the one result carries is accepted
+func assertSessionMoved(t *testing.T, sessions UserService, result *domain.LoginResult, oldGeneration int) {
+	t.Helper()
+	ctx := context.Background()
+	if err := sessions.ValidateSession(ctx, "u1", oldGeneration); errorCode(err) != apperrors.ErrSessionRevoked {
+		t.Errorf("old session returned %v, want %s", err, apperrors.ErrSessionRevoked)
+	}
+	claims, appErr := utils.ValidateToken(result.Token)
+	if appErr != nil {
+		t.Fatalf("new token is invalid: %v", appErr)
+	}
+	if err := sessions.ValidateSession(ctx, claims.UserID, claims.SessionGeneration); err != nil {
+		t.Errorf("new session returned %v", err)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	accounts, sessions, users, loginEvents, audit, _ := newAccountTest(t)
+	ctx := context.Background()
+
+	result, err := accounts.ChangePassword(ctx, "u1", "correct horse 1", "battery staple 2")
+	if err != nil {
+		t.Fatalf("ChangePassword returned %v", err)
+	}
+	assertSessionMoved(t, sessions, result, 1)
+
+	user, _ := users.GetUserByID(ctx, "u1")
+	if !utils.VerifyPassword(user.PasswordHash, "battery staple 2") {
+		t.Error("the new password doesn't verify")
+	}
+	if len(audit.events) != 1 || audit.events[0].Action != domain.AuditPasswordChanged {
+		t.Errorf("audit events %+v, want one password change", audit.events)
+	}
+	if len(loginEvents.events) != 0 {
+		t.Errorf("recorded login events %+v for a right password", loginEvents.events)
+	}
+}
+
+func TestChangePasswordWithAWrongPassword(t *testing.T) {
+	accounts, _, users, loginEvents, audit, _ := newAccountTest(t)
+	ctx := context.Background()
+
+	// Wrong guesses count towards the login lockout
+	for i := 0; i < accountTestPolicy.MaxAttempts; i++ {
+		if _, err := accounts.ChangePassword(ctx, "u1", "guess", "battery staple 2"); errorCode(err) != apperrors.ErrInvalidPassword {
+			t.Fatalf("wrong password %d returned %v, want %s", i+1, err, apperrors.ErrInvalidPassword)
+		}
+	}
+	if _, err := accounts.ChangePassword(ctx, "u1", "correct horse 1", "battery staple 2"); errorCode(err) != apperrors.ErrTooManyAttempts {
+		t.Fatalf("right password while locked returned %v, want %s", err, apperrors.ErrTooManyAttempts)
+	}
+
+	user, _ := users.GetUserByID(ctx, "u1")
+	if user.SessionGeneration != 1 || !utils.VerifyPassword(user.PasswordHash, "correct horse 1") {
+		t.Error("the password changed or sessions were revoked")
+	}
+	want := []string{
+		domain.LoginFailureInvalidCredentials,
+		domain.LoginFailureInvalidCredentials,
+		domain.LoginFailureInvalidCredentials,
+		domain.LoginFailureLocked,
+	}
+	if got := loginEvents.failures(); !slices.Equal(got, want) {
+		t.Errorf("recorded failures %v, want %v", got, want)
+	}
+	if len(audit.events) != 0 {
+		t.Errorf("audit events %+v for a failed change", audit.events)
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	accounts, sessions, users, _, audit, mailer := newAccountTest(t)
+	ctx := context.Background()
+
+	if _, err := accounts.RequestEmailChange(ctx, "u1", "ann@new.example.com", "correct horse 1"); err != nil {
+		t.Fatalf("RequestEmailChange returned %v", err)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].To != "ann@new.example.com" {
+		t.Fatalf("sent %+v, want one message to the new address", mailer.sent)
+	}
+	token := confirmationToken(t, mailer.sent[0].Body)
+
+	// Nothing changes until the new address is confirmed
+	if user, _ := users.GetUserByID(ctx, "u1"); user.Email != "ann@example.com" || user.SessionGeneration != 1 {
+		t.Fatalf("user %+v changed before confirmation", user)
+	}
+	if _, err := accounts.ConfirmEmailChange(ctx, "u1", "not-the-token"); errorCode(err) != apperrors.ErrInvalidConfirmation {
+		t.Fatalf("wrong token returned %v, want %s", err, apperrors.ErrInvalidConfirmation)
+	}
+
+	result, err := accounts.ConfirmEmailChange(ctx, "u1", token)
+	if err != nil {
+		t.Fatalf("ConfirmEmailChange returned %v", err)
+	}
+	if result.User.Email != "ann@new.example.com" {
+		t.Errorf("email is %s after the change", result.User.Email)
+	}
+	assertSessionMoved(t, sessions, result, 1)
+
+	// The previous address hears about it
+	if len(mailer.sent) != 2 || mailer.sent[1].To != "ann@example.com" {
+		t.Errorf("sent %+v, want a notice to the previous address", mailer.sent)
+	}
+	actions := make([]string, 0, len(audit.events))
+	for _, event := range audit.events {
+		actions = append(actions, event.Action)
+	}
+	if want := []string{domain.AuditEmailChangeRequested, domain.AuditEmailChanged}; !slices.Equal(actions, want) {
+		t.Errorf("audit actions %v, want %v", actions, want)
+	}
+
+	// The link works once
+	if _, err := accounts.ConfirmEmailChange(ctx, "u1", token); errorCode(err) != apperrors.ErrInvalidConfirmation {
+		t.Errorf("reused token returned %v, want %s", err, apperrors.ErrInvalidConfirmation)
+	}
+}
+
+func TestRequestEmailChangeWithAWrongPassword(t *testing.T) {
+	accounts, _, _, loginEvents, _, mailer := newAccountTest(t)
+
+	_, err := accounts.RequestEmailChange(context.Background(), "u1", "ann@new.example.com", "guess")
+	if errorCode(err) != apperrors.ErrInvalidPassword {
+		t.Fatalf("RequestEmailChange returned %v, want %s", err, apperrors.ErrInvalidPassword)
+	}
+	if len(mailer.sent) != 0 {
+		t.Errorf("sent %+v without the right password", mailer.sent)
+	}
+	if got := loginEvents.failures(); !slices.Equal(got, []string{domain.LoginFailureInvalidCredentials}) {
+		t.Errorf("recorded failures %v, want one wrong password", got)
+	}
+}
+
+var confirmLink = regexp.MustCompile(`https://app\.example\.com/confirm-email#\S+`)
+
+// confirmationToken returns the token in the link of a confirmation email
+func confirmationToken(t *testing.T, body string) string {
+	t.Helper()
+	link, err := url.Parse(confirmLink.FindString(body))
+	if err != nil || link.Fragment == "" {
+		t.Fatalf("no confirmation link in %q", body)
+	}
+	values, err := url.ParseQuery(link.Fragment)
+	if err != nil || strings.TrimSpace(values.Get("token")) == "" {
+		t.Fatalf("no token in link %s", link)
+	}
+	return values.Get("token")
+}
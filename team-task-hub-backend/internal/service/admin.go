@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/mail"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// User statuses an admin can filter on
+const (
+	UserStatusActive      = "active"
+	UserStatusDeactivated = "deactivated"
+)
+
+// AdminService defines user administration. Every method but IsAdmin expects
+// adminID to have been checked with IsAdmin, and records changes in the
+// affected user's audit log with the admin as actor.
+type AdminService interface {
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+	ListUsers(ctx context.Context, filter domain.UserFilter, page, pageSize int) ([]domain.User, int, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	SetRole(ctx context.Context, adminID, userID, role string) (*domain.User, error)
+	SetTwoFactorRequired(ctx context.Context, adminID, userID string, required bool) (*domain.User, error)
+	DeactivateUser(ctx context.Context, adminID, userID string) (*domain.User, error)
+	ReactivateUser(ctx context.Context, adminID, userID string) (*domain.User, error)
+	ResetPassword(ctx context.Context, adminID, userID, newPassword string) (*domain.User, error)
+	TransferWork(ctx context.Context, adminID, fromUserID, toUserID string) (*domain.WorkTransfer, error)
+	ListAuditEvents(ctx context.Context, userID string, limit int) ([]domain.AuditEvent, error)
+}
+
+type adminService struct {
+	userRepo       repository.UserRepository
+	auditEventRepo repository.AuditEventRepository
+	mailer         mail.Sender
+}
+
+func NewAdminService(userRepo repository.UserRepository, auditEventRepo repository.AuditEventRepository, mailer mail.Sender) AdminService {
+	return &adminService{
+		userRepo:       userRepo,
+		auditEventRepo: auditEventRepo,
+		mailer:         mailer,
+	}
+}
+
+// IsAdmin reports whether userID is an active admin
+func (s *adminService) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.IsAdmin")
+	defer span.End()
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == apperrors.ErrUserNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return user.Role == domain.RoleAdmin && user.DeactivatedAt == nil, nil
+}
+
+// ListUsers searches all users, deactivated ones included, with pagination
+func (s *adminService) ListUsers(ctx context.Context, filter domain.UserFilter, page, pageSize int) ([]domain.User, int, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.ListUsers")
+	defer span.End()
+
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Role != "" && !validRole(filter.Role) {
+		return nil, 0, apperrors.NewValidationError(apperrors.ErrInvalidInput, "role must be member or admin")
+	}
+	if filter.Status != "" && filter.Status != UserStatusActive && filter.Status != UserStatusDeactivated {
+		return nil, 0, apperrors.NewValidationError(apperrors.ErrInvalidInput, "status must be active or deactivated")
+	}
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	offset := (page - 1) * pageSize
+
+	return s.userRepo.SearchUsers(ctx, filter, pageSize, offset)
+}
+
+// GetUser retrieves any user, deactivated or not
+func (s *adminService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.GetUser")
+	defer span.End()
+
+	return s.userRepo.GetUserByID(ctx, userID)
+}
+
+// SetRole changes a user's role. Admins can't change their own, and the last
+// active admin can't be demoted.
+func (s *adminService) SetRole(ctx context.Context, adminID, userID, role string) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.SetRole")
+	defer span.End()
+
+	if !validRole(role) {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "role must be member or admin")
+	}
+	if userID == adminID {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "you cannot change your own role")
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	previousRole := user.Role
+
+	user, err = s.userRepo.UpdateRole(ctx, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user role changed", "user_id", userID, "role", role, "admin_id", adminID)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  userID,
+		ActorID: &adminID,
+		Action:  domain.AuditRoleChanged,
+		Details: map[string]string{"old_role": previousRole, "new_role": role},
+	})
+
+	return user, nil
+}
+
+// SetTwoFactorRequired sets whether a user must use two-factor
+// authentication. A user who hasn't enrolled is asked to at their next
+// sign-in, and one who has can no longer turn it off.
+func (s *adminService) SetTwoFactorRequired(ctx context.Context, adminID, userID string, required bool) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.SetTwoFactorRequired")
+	defer span.End()
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorRequired == required {
+		return user, nil
+	}
+
+	user, err = s.userRepo.SetTwoFactorRequired(ctx, userID, required)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "two-factor requirement changed", "user_id", userID, "required", required, "admin_id", adminID)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  userID,
+		ActorID: &adminID,
+		Action:  domain.AuditTwoFactorRequirementChanged,
+		Details: map[string]string{"required": strconv.FormatBool(required)},
+	})
+
+	return user, nil
+}
+
+// DeactivateUser stops a user signing in and signs out their sessions and
+// access tokens. Their data is kept; TransferWork hands it to someone else.
+// The last active admin can't be deactivated.
+func (s *adminService) DeactivateUser(ctx context.Context, adminID, userID string) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.DeactivateUser")
+	defer span.End()
+
+	if userID == adminID {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "you cannot deactivate your own account")
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeactivatedAt != nil {
+		return user, nil
+	}
+
+	user, err = s.userRepo.DeactivateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user deactivated", "user_id", userID, "admin_id", adminID)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  userID,
+		ActorID: &adminID,
+		Action:  domain.AuditUserDeactivated,
+	})
+
+	return user, nil
+}
+
+// ReactivateUser lets a deactivated user sign in again. Sessions from before
+// the deactivation stay revoked.
+func (s *adminService) ReactivateUser(ctx context.Context, adminID, userID string) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.ReactivateUser")
+	defer span.End()
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeactivatedAt == nil {
+		return user, nil
+	}
+
+	user, err = s.userRepo.ReactivateUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user reactivated", "user_id", userID, "admin_id", adminID)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  userID,
+		ActorID: &adminID,
+		Action:  domain.AuditUserReactivated,
+	})
+
+	return user, nil
+}
+
+// ResetPassword sets a new password for a user who can't change it
+// themselves, signs out their sessions and lets them know by email
+func (s *adminService) ResetPassword(ctx context.Context, adminID, userID, newPassword string) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.ResetPassword")
+	defer span.End()
+
+	if appErr := utils.ValidatePassword(newPassword); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to hash password", err)
+	}
+
+	user, err := s.userRepo.ResetPassword(ctx, userID, hashedPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "password reset by admin", "user_id", userID, "admin_id", adminID)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  userID,
+		ActorID: &adminID,
+		Action:  domain.AuditPasswordReset,
+	})
+
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Your password was reset",
+		Body: "An administrator reset the password of your Team Task Hub account, " +
+			"and every session was signed out.\n\n" +
+			"Sign in with the password your administrator gives you, then change it.\n",
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to send password reset notice", "error", err)
+	}
+
+	return user, nil
+}
+
+// TransferWork hands a departing user's projects, and their assignments on
+// unfinished tasks and checklist items, to another active user
+func (s *adminService) TransferWork(ctx context.Context, adminID, fromUserID, toUserID string) (*domain.WorkTransfer, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.TransferWork")
+	defer span.End()
+
+	if strings.TrimSpace(toUserID) == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "to_user_id is required")
+	}
+	if toUserID == fromUserID {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "work must be transferred to a different user")
+	}
+
+	if _, err := s.userRepo.GetUserByID(ctx, fromUserID); err != nil {
+		return nil, err
+	}
+	to, err := s.userRepo.GetUserByID(ctx, toUserID)
+	if err != nil {
+		return nil, err
+	}
+	if to.DeactivatedAt != nil {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "work cannot be transferred to a deactivated user")
+	}
+
+	transfer, err := s.userRepo.TransferWork(ctx, fromUserID, toUserID, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "work transferred", "from_user_id", fromUserID, "to_user_id", toUserID, "admin_id", adminID,
+		"projects", transfer.ProjectsTransferred, "tasks", transfer.TasksReassigned, "checklist_items", transfer.ChecklistItemsReassigned)
+	recordAuditEvent(ctx, s.auditEventRepo, &domain.AuditEvent{
+		UserID:  fromUserID,
+		ActorID: &adminID,
+		Action:  domain.AuditWorkTransferred,
+		Details: map[string]string{
+			"to_user_id":                 toUserID,
+			"projects_transferred":       strconv.Itoa(transfer.ProjectsTransferred),
+			"tasks_reassigned":           strconv.Itoa(transfer.TasksReassigned),
+			"checklist_items_reassigned": strconv.Itoa(transfer.ChecklistItemsReassigned),
+		},
+	})
+
+	return transfer, nil
+}
+
+// ListAuditEvents returns the most recent changes to a user's account
+func (s *adminService) ListAuditEvents(ctx context.Context, userID string, limit int) ([]domain.AuditEvent, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminService.ListAuditEvents")
+	defer span.End()
+
+	if limit < 1 || limit > 100 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "limit must be between 1 and 100")
+	}
+
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.auditEventRepo.ListEventsByUserID(ctx, userID, limit)
+}
+
+func validRole(role string) bool {
+	return role == domain.RoleMember || role == domain.RoleAdmin
+}
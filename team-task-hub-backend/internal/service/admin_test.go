@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+// removeAdmin is how one admin takes away another's admin rights
+type removeAdmin func(svc AdminService, adminID, userID string) error
+
+var removeAdminTests = []struct {
+	name   string
+	remove removeAdmin
+}{
+	{name: "demote", remove: func(svc AdminService, adminID, userID string) error {
+		_, err := svc.SetRole(context.Background(), adminID, userID, domain.RoleMember)
+		return err
+	}},
+	{name: "deactivate", remove: func(svc AdminService, adminID, userID string) error {
+		_, err := svc.DeactivateUser(context.Background(), adminID, userID)
+		return err
+	}},
+}
+
+func TestAdminRemovingAnotherAdmin(t *testing.T) {
+	for _, tt := range removeAdminTests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newFakeUserRepo(
+				&domain.User{ID: "a1", Role: domain.RoleAdmin},
+				&domain.User{ID: "a2", Role: domain.RoleAdmin},
+			)
+			audit := &fakeAuditEventRepo{}
+			svc := NewAdminService(users, audit, nil)
+
+			if err := tt.remove(svc, "a1", "a1"); errorCode(err) != apperrors.ErrInvalidInput {
+				t.Errorf("removing yourself returned %v, want %s", err, apperrors.ErrInvalidInput)
+			}
+			if err := tt.remove(svc, "a1", "a2"); err != nil {
+				t.Fatalf("removing another admin returned %v", err)
+			}
+			if isAdmin, _ := svc.IsAdmin(context.Background(), "a2"); isAdmin {
+				t.Error("a2 is still an admin")
+			}
+			if len(audit.events) != 1 || *audit.events[0].ActorID != "a1" || audit.events[0].UserID != "a2" {
+				t.Errorf("audit events %+v, want one by a1 about a2", audit.events)
+			}
+		})
+	}
+}
+
+func TestAdminsRemovingEachOther(t *testing.T) {
+	// Each admin's own check passes, as neither removes themselves, so only
+	// the repository stops them both succeeding
+	for _, tt := range removeAdminTests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newFakeUserRepo(
+				&domain.User{ID: "a1", Role: domain.RoleAdmin},
+				&domain.User{ID: "a2", Role: domain.RoleAdmin},
+			)
+			audit := &fakeAuditEventRepo{}
+			svc := NewAdminService(users, audit, nil)
+
+			var wg sync.WaitGroup
+			errs := make([]error, 2)
+			for i, ids := range [][2]string{{"a1", "a2"}, {"a2", "a1"}} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs[i] = tt.remove(svc, ids[0], ids[1])
+				}()
+			}
+			wg.Wait()
+
+			succeeded := 0
+			for _, err := range errs {
+				switch {
+				case err == nil:
+					succeeded++
+				case errorCode(err) != apperrors.ErrLastAdmin:
+					t.Errorf("removal returned %v, want nil or %s", err, apperrors.ErrLastAdmin)
+				}
+			}
+			if succeeded != 1 {
+				t.Errorf("%d removals succeeded, want 1", succeeded)
+			}
+			if admins := users.activeAdmins(); admins != 1 {
+				t.Errorf("%d active admins left, want 1", admins)
+			}
+			if len(audit.events) != succeeded {
+				t.Errorf("recorded %d audit events for %d changes", len(audit.events), succeeded)
+			}
+		})
+	}
+}
+
+func TestAdminRemovingAMemberLeavesTheAdmin(t *testing.T) {
+	users := newFakeUserRepo(
+		&domain.User{ID: "a1", Role: domain.RoleAdmin},
+		&domain.User{ID: "m1"},
+	)
+	svc := NewAdminService(users, &fakeAuditEventRepo{}, nil)
+	ctx := context.Background()
+
+	if _, err := svc.DeactivateUser(ctx, "a1", "m1"); err != nil {
+		t.Fatalf("deactivating a member returned %v", err)
+	}
+	if _, err := svc.SetRole(ctx, "a1", "m1", domain.RoleAdmin); err != nil {
+		t.Fatalf("promoting a member returned %v", err)
+	}
+	// m1 is deactivated, so a1 is still the only active admin
+	if admins := users.activeAdmins(); admins != 1 {
+		t.Errorf("%d active admins, want 1", admins)
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// ChecklistService defines task checklist business logic operations
+type ChecklistService interface {
+	AddItem(ctx context.Context, taskID, content string, assigneeID *string) (*domain.ChecklistItem, error)
+	ListItems(ctx context.Context, taskID string) ([]domain.ChecklistItem, error)
+	UpdateItem(ctx context.Context, taskID, itemID string, content *string, isDone *bool, assigneeID *string) (*domain.ChecklistItem, error)
+	ToggleItem(ctx context.Context, taskID, itemID string) (*domain.ChecklistItem, error)
+	ReorderItems(ctx context.Context, taskID string, itemIDs []string) ([]domain.ChecklistItem, error)
+	DeleteItem(ctx context.Context, taskID, itemID string) error
+}
+
+type checklistService struct {
+	checklistRepo repository.ChecklistRepository
+	taskRepo      repository.TaskRepository
+}
+
+func NewChecklistService(checklistRepo repository.ChecklistRepository, taskRepo repository.TaskRepository) ChecklistService {
+	return &checklistService{checklistRepo: checklistRepo, taskRepo: taskRepo}
+}
+
+// AddItem appends an item to a task's checklist
+func (s *checklistService) AddItem(ctx context.Context, taskID, content string, assigneeID *string) (*domain.ChecklistItem, error) {
+	ctx, span := tracing.StartSpan(ctx, "ChecklistService.AddItem")
+	defer span.End()
+
+	if appErr := utils.ValidateChecklistContent(content); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	if assigneeID != nil && *assigneeID == "" {
+		assigneeID = nil
+	}
+
+	return s.checklistRepo.CreateItem(ctx, taskID, strings.TrimSpace(content), assigneeID)
+}
+
+// ListItems retrieves a task's checklist in order
+func (s *checklistService) ListItems(ctx context.Context, taskID string) ([]domain.ChecklistItem, error) {
+	ctx, span := tracing.StartSpan(ctx, "ChecklistService.ListItems")
+	defer span.End()
+
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.checklistRepo.ListItemsByTaskID(ctx, taskID)
+}
+
+// UpdateItem partially updates a checklist item; nil fields are left unchanged.
+// An empty assignee ID clears the assignee.
+func (s *checklistService) UpdateItem(ctx context.Context, taskID, itemID string, content *string, isDone *bool, assigneeID *string) (*domain.ChecklistItem, error) {
+	ctx, span := tracing.StartSpan(ctx, "ChecklistService.UpdateItem")
+	defer span.End()
+
+	item, err := s.getTaskItem(ctx, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	newContent := item.Content
+	if content != nil {
+		if appErr := utils.ValidateChecklistContent(*content); appErr != nil {
+			return nil, appErr
+		}
+		newContent = strings.TrimSpace(*content)
+	}
+
+	newIsDone := item.IsDone
+	if isDone != nil {
+		newIsDone = *isDone
+	}
+
+	newAssigneeID := item.AssigneeID
+	if assigneeID != nil {
+		if *assigneeID == "" {
+			newAssigneeID = nil
+		} else {
+			newAssigneeID = assigneeID
+		}
+	}
+
+	return s.checklistRepo.UpdateItem(ctx, itemID, newContent, newIsDone, newAssigneeID)
+}
+
+// ToggleItem flips the done flag of a checklist item
+func (s *checklistService) ToggleItem(ctx context.Context, taskID, itemID string) (*domain.ChecklistItem, error) {
+	ctx, span := tracing.StartSpan(ctx, "ChecklistService.ToggleItem")
+	defer span.End()
+
+	item, err := s.getTaskItem(ctx, taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.checklistRepo.UpdateItem(ctx, itemID, item.Content, !item.IsDone, item.AssigneeID)
+}
+
+// ReorderItems sets the checklist order to the given item IDs
+func (s *checklistService) ReorderItems(ctx context.Context, taskID string, itemIDs []string) ([]domain.ChecklistItem, error) {
+	ctx, span := tracing.StartSpan(ctx, "ChecklistService.ReorderItems")
+	defer span.End()
+
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	for _, id := range itemIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "item_ids must contain valid IDs")
+		}
+	}
+
+	if err := s.checklistRepo.ReorderItems(ctx, taskID, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return s.checklistRepo.ListItemsByTaskID(ctx, taskID)
+}
+
+// DeleteItem removes an item from a task's checklist
+func (s *checklistService) DeleteItem(ctx context.Context, taskID, itemID string) error {
+	ctx, span := tracing.StartSpan(ctx, "ChecklistService.DeleteItem")
+	defer span.End()
+
+	if _, err := s.getTaskItem(ctx, taskID, itemID); err != nil {
+		return err
+	}
+
+	return s.checklistRepo.DeleteItem(ctx, itemID)
+}
+
+// getTaskItem loads a checklist item and checks it belongs to the given task
+func (s *checklistService) getTaskItem(ctx context.Context, taskID, itemID string) (*domain.ChecklistItem, error) {
+	if itemID == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid checklist item ID")
+	}
+
+	item, err := s.checklistRepo.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.TaskID != taskID {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrChecklistItemNotFound, "checklist item not found")
+	}
+
+	return item, nil
+}
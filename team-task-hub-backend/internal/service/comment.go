@@ -6,7 +6,9 @@ import (
 
 	"github.com/launchventures/team-task-hub-backend/internal/domain"
 	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
 	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
 )
 
 // CommentService defines comment-related business logic operations
@@ -29,6 +31,9 @@ func NewCommentService(commentRepo repository.CommentRepository) CommentService
 
 // CreateComment creates a new comment with validation
 func (s *commentService) CreateComment(ctx context.Context, taskID, userID string, content string) (*domain.Comment, error) {
+	ctx, span := tracing.StartSpan(ctx, "CommentService.CreateComment")
+	defer span.End()
+
 	// Validate task ID and user ID
 	if taskID == "" || userID == "" {
 		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID or user ID")
@@ -49,12 +54,16 @@ func (s *commentService) CreateComment(ctx context.Context, taskID, userID strin
 	if err != nil {
 		return nil, err
 	}
+	metrics.CommentsPosted.Inc()
 
 	return comment, nil
 }
 
 // GetComment retrieves a comment by ID
 func (s *commentService) GetComment(ctx context.Context, id string) (*domain.Comment, error) {
+	ctx, span := tracing.StartSpan(ctx, "CommentService.GetComment")
+	defer span.End()
+
 	if id == "" {
 		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid comment ID")
 	}
@@ -69,6 +78,9 @@ func (s *commentService) GetComment(ctx context.Context, id string) (*domain.Com
 
 // ListComments retrieves all comments for a task with pagination
 func (s *commentService) ListComments(ctx context.Context, taskID string, page, pageSize int) ([]domain.Comment, int, error) {
+	ctx, span := tracing.StartSpan(ctx, "CommentService.ListComments")
+	defer span.End()
+
 	// Validate task ID
 	if taskID == "" {
 		return nil, 0, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
@@ -94,6 +106,9 @@ func (s *commentService) ListComments(ctx context.Context, taskID string, page,
 
 // ListRecentComments retrieves recent comments from all tasks with pagination
 func (s *commentService) ListRecentComments(ctx context.Context, page, pageSize int) ([]domain.Comment, int, error) {
+	ctx, span := tracing.StartSpan(ctx, "CommentService.ListRecentComments")
+	defer span.End()
+
 	if page < 1 {
 		page = 1
 	}
@@ -114,6 +129,9 @@ func (s *commentService) ListRecentComments(ctx context.Context, page, pageSize
 
 // UpdateComment updates a comment with validation
 func (s *commentService) UpdateComment(ctx context.Context, id string, content string) (*domain.Comment, error) {
+	ctx, span := tracing.StartSpan(ctx, "CommentService.UpdateComment")
+	defer span.End()
+
 	// Validate comment ID
 	if id == "" {
 		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid comment ID")
@@ -140,6 +158,9 @@ func (s *commentService) UpdateComment(ctx context.Context, id string, content s
 
 // DeleteComment deletes a comment
 func (s *commentService) DeleteComment(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "CommentService.DeleteComment")
+	defer span.End()
+
 	if id == "" {
 		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid comment ID")
 	}
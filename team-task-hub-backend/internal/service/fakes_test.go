@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+func TestMain(m *testing.M) {
+	utils.ConfigureJWT(utils.JWTOptions{
+		Keys:       utils.NewHMACKeySet("service-test-secret"),
+		Expiration: time.Hour,
+		Issuer:     "team-task-hub-test",
+		Audience:   "team-task-hub-test",
+	})
+	os.Exit(m.Run())
+}
+
+// The fakes below keep their data in memory. Each embeds its repository
+// interface, so calling a method a test doesn't expect panics.
+
+// fakeUserRepo stores users by ID
+type fakeUserRepo struct {
+	repository.UserRepository
+	mu    sync.Mutex
+	users map[string]*domain.User
+}
+
+func newFakeUserRepo(users ...*domain.User) *fakeUserRepo {
+	r := &fakeUserRepo{users: make(map[string]*domain.User)}
+	for _, user := range users {
+		if user.Role == "" {
+			user.Role = domain.RoleMember
+		}
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+func (r *fakeUserRepo) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepo) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+}
+
+func (r *fakeUserRepo) UpdatePassword(ctx context.Context, id, passwordHash string) (*domain.User, error) {
+	return r.update(id, func(user *domain.User) {
+		user.PasswordHash = passwordHash
+		user.SessionGeneration++
+	})
+}
+
+func (r *fakeUserRepo) GetSessionState(ctx context.Context, id string) (int, bool, error) {
+	user, err := r.GetUserByID(ctx, id)
+	if err != nil {
+		return 0, false, err
+	}
+	return user.SessionGeneration, user.DeactivatedAt != nil, nil
+}
+
+// UpdateRole and DeactivateUser hold the lock across the check and the
+// change, as the repository holds its transaction
+
+func (r *fakeUserRepo) UpdateRole(ctx context.Context, id, role string) (*domain.User, error) {
+	return r.removeAdmin(id, role != domain.RoleAdmin, func(user *domain.User) { user.Role = role })
+}
+
+func (r *fakeUserRepo) DeactivateUser(ctx context.Context, id string) (*domain.User, error) {
+	return r.removeAdmin(id, true, func(user *domain.User) {
+		now := time.Now()
+		user.DeactivatedAt = &now
+		user.SessionGeneration++
+	})
+}
+
+// removeAdmin applies fn to a user, refusing when removes is set and the user
+// is the last active admin
+func (r *fakeUserRepo) removeAdmin(id string, removes bool, fn func(*domain.User)) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+	}
+	if removes && r.activeAdminsLocked() == 1 && user.Role == domain.RoleAdmin && user.DeactivatedAt == nil {
+		return nil, apperrors.NewConflictError(apperrors.ErrLastAdmin, "at least one active admin must remain")
+	}
+	fn(user)
+	copied := *user
+	return &copied, nil
+}
+
+// activeAdmins counts the users who are active admins
+func (r *fakeUserRepo) activeAdmins() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.activeAdminsLocked()
+}
+
+func (r *fakeUserRepo) activeAdminsLocked() int {
+	count := 0
+	for _, user := range r.users {
+		if user.Role == domain.RoleAdmin && user.DeactivatedAt == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// update applies fn to a stored user and returns a copy of the result
+func (r *fakeUserRepo) update(id string, fn func(*domain.User)) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+	}
+	fn(user)
+	copied := *user
+	return &copied, nil
+}
+
+// fakeLoginEventRepo records sign-in attempts in order
+type fakeLoginEventRepo struct {
+	repository.LoginEventRepository
+	mu     sync.Mutex
+	events []domain.LoginEvent
+}
+
+func (r *fakeLoginEventRepo) RecordEvent(ctx context.Context, event *domain.LoginEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event.CreatedAt = time.Now()
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *fakeLoginEventRepo) GetFailureStreak(ctx context.Context, email string, lookback time.Duration) (int, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	var latest time.Time
+	for i := len(r.events) - 1; i >= 0; i-- {
+		event := r.events[i]
+		if event.Email != email {
+			continue
+		}
+		if event.Success {
+			break
+		}
+		if isGuessFailure(event) {
+			count++
+			if event.CreatedAt.After(latest) {
+				latest = event.CreatedAt
+			}
+		}
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return count, time.Since(latest), nil
+}
+
+func (r *fakeLoginEventRepo) CountFailuresByIP(ctx context.Context, ip string, window time.Duration) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, event := range r.events {
+		if event.IPAddress == ip && isGuessFailure(event) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// failures returns the failure reasons recorded, in order
+func (r *fakeLoginEventRepo) failures() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var reasons []string
+	for _, event := range r.events {
+		if !event.Success {
+			reasons = append(reasons, *event.FailureReason)
+		}
+	}
+	return reasons
+}
+
+// fakeAuditEventRepo records audit events in order
+type fakeAuditEventRepo struct {
+	repository.AuditEventRepository
+	mu     sync.Mutex
+	events []domain.AuditEvent
+}
+
+func (r *fakeAuditEventRepo) RecordEvent(ctx context.Context, event *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func isGuessFailure(event domain.LoginEvent) bool {
+	return !event.Success && slices.Contains(
+		[]string{domain.LoginFailureInvalidCredentials, domain.LoginFailureInvalidTwoFactor}, *event.FailureReason)
+}
+
+// errorCode returns the code of an AppError, or "" for nil and other errors
+func errorCode(err error) apperrors.ErrorCode {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		return appErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/oidc"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// IdentityProvider is an OpenID Connect provider users sign in with
+type IdentityProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	Exchange(ctx context.Context, code, verifier, nonce string) (*oidc.Claims, error)
+}
+
+// OIDCService defines single sign-on business logic operations
+type OIDCService interface {
+	StartLogin(ctx context.Context) (authURL, state string, err error)
+	CompleteLogin(ctx context.Context, state, code string) (*domain.LoginResult, error)
+}
+
+type oidcService struct {
+	provider       IdentityProvider
+	identityRepo   repository.IdentityRepository
+	userRepo       repository.UserRepository
+	loginEventRepo repository.LoginEventRepository
+	loginPolicy    LoginPolicy
+	allowedDomains []string
+	stateTTL       time.Duration
+}
+
+// NewOIDCService creates the service. loginPolicy decides, as for password
+// sign-ins, when a second factor is asked for.
+func NewOIDCService(provider IdentityProvider, identityRepo repository.IdentityRepository, userRepo repository.UserRepository, loginEventRepo repository.LoginEventRepository, loginPolicy LoginPolicy, allowedDomains []string, stateTTL time.Duration) OIDCService {
+	domains := make([]string, len(allowedDomains))
+	for i, d := range allowedDomains {
+		domains[i] = strings.ToLower(d)
+	}
+
+	return &oidcService{
+		provider:       provider,
+		identityRepo:   identityRepo,
+		userRepo:       userRepo,
+		loginEventRepo: loginEventRepo,
+		loginPolicy:    loginPolicy,
+		allowedDomains: domains,
+		stateTTL:       stateTTL,
+	}
+}
+
+// StartLogin begins a sign-in, returning the provider URL to send the user to
+// and the state the callback must carry
+func (s *oidcService) StartLogin(ctx context.Context) (string, string, error) {
+	ctx, span := tracing.StartSpan(ctx, "OIDCService.StartLogin")
+	defer span.End()
+
+	session := oidc.NewSession()
+	authURL, err := s.provider.AuthCodeURL(ctx, session.State, session.Nonce, session.Verifier)
+	if err != nil {
+		return "", "", apperrors.NewSSOProviderError("identity provider is unavailable", err)
+	}
+
+	state := &domain.OIDCLoginState{
+		State:        session.State,
+		CodeVerifier: session.Verifier,
+		Nonce:        session.Nonce,
+	}
+	if err := s.identityRepo.SaveLoginState(ctx, state, s.stateTTL); err != nil {
+		return "", "", err
+	}
+
+	return authURL, session.State, nil
+}
+
+// CompleteLogin redeems the provider's authorization code and signs the user
+// in. A provider account already linked signs in as its user; otherwise it is
+// linked to the user with the same verified email, or a new user is created.
+// Users who use two-factor authentication, or must, get the same challenge as
+// after a password, since linking by email would otherwise let the provider
+// account skip it.
+func (s *oidcService) CompleteLogin(ctx context.Context, state, code string) (*domain.LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "OIDCService.CompleteLogin")
+	defer span.End()
+
+	if code == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "authorization code is required")
+	}
+
+	loginState, err := s.identityRepo.ConsumeLoginState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, apperrors.NewSSOProviderError("failed to verify sign-in with identity provider", err)
+	}
+
+	email := strings.TrimSpace(claims.Email)
+	client := utils.ClientInfoFromContext(ctx)
+	event := &domain.LoginEvent{
+		Email:     strings.ToLower(email),
+		IPAddress: client.IP,
+		UserAgent: client.UserAgent,
+	}
+
+	// A domain allow-list is only as good as the email, so it needs verifying
+	if len(s.allowedDomains) > 0 {
+		if !claims.EmailVerified {
+			s.recordLogin(ctx, event, domain.LoginFailureSSOEmailUnverified)
+			return nil, emailUnverified()
+		}
+		if !s.domainAllowed(email) {
+			s.recordLogin(ctx, event, domain.LoginFailureSSODomain)
+			return nil, apperrors.NewAuthError(apperrors.ErrSSODomainNotAllowed, "your email domain is not allowed to sign in")
+		}
+	}
+
+	user, err := s.identityRepo.GetUserByIdentity(ctx, claims.Issuer, claims.Subject, email)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != apperrors.ErrUserNotFound {
+			return nil, err
+		}
+		if user, err = s.linkOrProvision(ctx, claims, email, event); err != nil {
+			return nil, err
+		}
+	}
+	event.UserID = &user.ID
+
+	if user.DeactivatedAt != nil {
+		s.recordLogin(ctx, event, domain.LoginFailureDeactivated)
+		return nil, accountDeactivated()
+	}
+
+	// The sign-in is recorded once the second factor is checked
+	if purpose := secondFactorPurpose(user, s.loginPolicy); purpose != "" {
+		return newChallenge(user, purpose, s.loginPolicy.ChallengeTTL)
+	}
+
+	token, err := utils.GenerateToken(user.ID, user.Email, user.SessionGeneration)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate token", err)
+	}
+
+	s.recordLogin(ctx, event, "")
+	return &domain.LoginResult{User: user, Token: token}, nil
+}
+
+// linkOrProvision finds a user for a provider account seen for the first
+// time. Only a verified email may claim an existing account or a new one.
+func (s *oidcService) linkOrProvision(ctx context.Context, claims *oidc.Claims, email string, event *domain.LoginEvent) (*domain.User, error) {
+	if !claims.EmailVerified || utils.ValidateEmail(email) != nil {
+		s.recordLogin(ctx, event, domain.LoginFailureSSOEmailUnverified)
+		return nil, emailUnverified()
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err == nil {
+		if err := s.identityRepo.LinkIdentity(ctx, user.ID, claims.Issuer, claims.Subject, email); err != nil {
+			return nil, err
+		}
+		slog.InfoContext(ctx, "linked identity to existing user", "user_id", user.ID, "issuer", claims.Issuer)
+		return user, nil
+	}
+	if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != apperrors.ErrUserNotFound {
+		return nil, err
+	}
+
+	user, err = s.identityRepo.CreateUserWithIdentity(ctx, email, claims.Name, claims.Issuer, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "provisioned user from identity provider", "user_id", user.ID, "issuer", claims.Issuer)
+	return user, nil
+}
+
+func (s *oidcService) domainAllowed(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	return slices.Contains(s.allowedDomains, strings.ToLower(email[at+1:]))
+}
+
+func (s *oidcService) recordLogin(ctx context.Context, event *domain.LoginEvent, failureReason string) {
+	recordLoginEvent(ctx, s.loginEventRepo, event, failureReason)
+}
+
+func emailUnverified() error {
+	return apperrors.NewAuthError(apperrors.ErrSSOEmailUnverified, "your identity provider has not verified your email address")
+}
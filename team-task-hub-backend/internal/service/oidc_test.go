@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/oidc"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+const testIssuer = "https://idp.example.com"
+
+// fakeIdentityProvider returns claims for the codes it was given
+type fakeIdentityProvider struct {
+	claims map[string]*oidc.Claims
+}
+
+func (p *fakeIdentityProvider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	return testIssuer + "/authorize?state=" + state, nil
+}
+
+func (p *fakeIdentityProvider) Exchange(ctx context.Context, code, verifier, nonce string) (*oidc.Claims, error) {
+	claims, ok := p.claims[code]
+	if !ok {
+		return nil, apperrors.NewAuthError(apperrors.ErrInvalidCredentials, "unknown code")
+	}
+	return claims, nil
+}
+
+// fakeIdentityRepo links provider accounts to users in a fakeUserRepo
+type fakeIdentityRepo struct {
+	repository.IdentityRepository
+	users      *fakeUserRepo
+	identities map[string]string
+	states     map[string]*domain.OIDCLoginState
+}
+
+func newFakeIdentityRepo(users *fakeUserRepo) *fakeIdentityRepo {
+	return &fakeIdentityRepo{
+		users:      users,
+		identities: make(map[string]string),
+		states:     make(map[string]*domain.OIDCLoginState),
+	}
+}
+
+func (r *fakeIdentityRepo) GetUserByIdentity(ctx context.Context, issuer, subject, email string) (*domain.User, error) {
+	userID, ok := r.identities[issuer+"|"+subject]
+	if !ok {
+		return nil, apperrors.NewNotFoundError(apperrors.ErrUserNotFound, "user not found")
+	}
+	return r.users.GetUserByID(ctx, userID)
+}
+
+func (r *fakeIdentityRepo) LinkIdentity(ctx context.Context, userID, issuer, subject, email string) error {
+	r.identities[issuer+"|"+subject] = userID
+	return nil
+}
+
+func (r *fakeIdentityRepo) CreateUserWithIdentity(ctx context.Context, email, name, issuer, subject string) (*domain.User, error) {
+	user := &domain.User{ID: "provisioned-" + subject, Email: email, Name: name, Role: domain.RoleMember}
+	r.users.users[user.ID] = user
+	r.identities[issuer+"|"+subject] = user.ID
+	return r.users.GetUserByID(ctx, user.ID)
+}
+
+func (r *fakeIdentityRepo) SaveLoginState(ctx context.Context, state *domain.OIDCLoginState, ttl time.Duration) error {
+	r.states[state.State] = state
+	return nil
+}
+
+func (r *fakeIdentityRepo) ConsumeLoginState(ctx context.Context, state string) (*domain.OIDCLoginState, error) {
+	loginState, ok := r.states[state]
+	if !ok {
+		return nil, apperrors.NewAuthError(apperrors.ErrInvalidSSOState, "sign-in session not found or already used")
+	}
+	delete(r.states, state)
+	return loginState, nil
+}
+
+func TestOIDCCompleteLogin(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *domain.User
+		linked         bool
+		claims         oidc.Claims
+		policy         LoginPolicy
+		allowedDomains []string
+		wantCode       apperrors.ErrorCode
+		wantPurpose    string
+		wantUserEmail  string
+	}{
+		{
+			name:          "linked account gets a token",
+			user:          &domain.User{ID: "u1", Email: "ann@example.com"},
+			linked:        true,
+			claims:        oidc.Claims{Subject: "s1", Email: "ann@example.com", EmailVerified: true},
+			wantUserEmail: "ann@example.com",
+		},
+		{
+			name:          "verified email links an existing account",
+			user:          &domain.User{ID: "u1", Email: "ann@example.com"},
+			claims:        oidc.Claims{Subject: "s1", Email: "ann@example.com", EmailVerified: true},
+			wantUserEmail: "ann@example.com",
+		},
+		{
+			name:     "unverified email can't link an existing account",
+			user:     &domain.User{ID: "u1", Email: "ann@example.com"},
+			claims:   oidc.Claims{Subject: "s1", Email: "ann@example.com"},
+			wantCode: apperrors.ErrSSOEmailUnverified,
+		},
+		{
+			name:          "new email provisions a user",
+			claims:        oidc.Claims{Subject: "s2", Email: "bob@example.com", EmailVerified: true, Name: "Bob"},
+			wantUserEmail: "bob@example.com",
+		},
+		{
+			name:           "domain outside the allow-list",
+			claims:         oidc.Claims{Subject: "s2", Email: "bob@elsewhere.com", EmailVerified: true},
+			allowedDomains: []string{"Example.com"},
+			wantCode:       apperrors.ErrSSODomainNotAllowed,
+		},
+		{
+			name:     "deactivated user",
+			user:     &domain.User{ID: "u1", Email: "ann@example.com", DeactivatedAt: &time.Time{}},
+			linked:   true,
+			claims:   oidc.Claims{Subject: "s1", Email: "ann@example.com", EmailVerified: true},
+			wantCode: apperrors.ErrAccountDeactivated,
+		},
+		{
+			name:        "enrolled user gets a two-factor challenge",
+			user:        &domain.User{ID: "u1", Email: "ann@example.com", TwoFactorEnabled: true},
+			claims:      oidc.Claims{Subject: "s1", Email: "ann@example.com", EmailVerified: true},
+			wantPurpose: utils.PurposeTwoFactor,
+		},
+		{
+			name:        "user required by an admin gets an enrollment challenge",
+			user:        &domain.User{ID: "u1", Email: "ann@example.com", TwoFactorRequired: true},
+			linked:      true,
+			claims:      oidc.Claims{Subject: "s1", Email: "ann@example.com", EmailVerified: true},
+			wantPurpose: utils.PurposeTwoFactorEnroll,
+		},
+		{
+			name:        "two-factor required of everyone",
+			claims:      oidc.Claims{Subject: "s2", Email: "bob@example.com", EmailVerified: true},
+			policy:      LoginPolicy{RequireTwoFactor: true},
+			wantPurpose: utils.PurposeTwoFactorEnroll,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newFakeUserRepo()
+			if tt.user != nil {
+				users = newFakeUserRepo(tt.user)
+			}
+			identities := newFakeIdentityRepo(users)
+			if tt.linked {
+				identities.LinkIdentity(context.Background(), tt.user.ID, testIssuer, tt.claims.Subject, tt.user.Email)
+			}
+			claims := tt.claims
+			claims.Issuer = testIssuer
+			provider := &fakeIdentityProvider{claims: map[string]*oidc.Claims{"code": &claims}}
+			loginEvents := &fakeLoginEventRepo{}
+
+			tt.policy.ChallengeTTL = 5 * time.Minute
+			svc := NewOIDCService(provider, identities, users, loginEvents, tt.policy, tt.allowedDomains, time.Minute)
+
+			ctx := context.Background()
+			_, state, err := svc.StartLogin(ctx)
+			if err != nil {
+				t.Fatalf("StartLogin returned %v", err)
+			}
+			result, err := svc.CompleteLogin(ctx, state, "code")
+
+			if tt.wantCode != "" {
+				if code := errorCode(err); code != tt.wantCode {
+					t.Fatalf("CompleteLogin returned %v, want %s", err, tt.wantCode)
+				}
+				if failures := loginEvents.failures(); len(failures) != 1 {
+					t.Errorf("recorded failures %v, want one", failures)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompleteLogin returned %v", err)
+			}
+
+			if tt.wantPurpose != "" {
+				if result.Token != "" {
+					t.Fatal("CompleteLogin issued an access token instead of a challenge")
+				}
+				challenge, appErr := utils.ValidateChallengeToken(result.ChallengeToken, tt.wantPurpose)
+				if appErr != nil {
+					t.Fatalf("challenge token is not a %s challenge: %v", tt.wantPurpose, appErr)
+				}
+				if result.TwoFactorRequired != (tt.wantPurpose == utils.PurposeTwoFactor) ||
+					result.EnrollmentRequired != (tt.wantPurpose == utils.PurposeTwoFactorEnroll) {
+					t.Errorf("result flags two_factor_required=%v enrollment_required=%v for %s",
+						result.TwoFactorRequired, result.EnrollmentRequired, tt.wantPurpose)
+				}
+				// The sign-in isn't recorded until the second factor is checked
+				if len(loginEvents.events) != 0 {
+					t.Errorf("recorded %d login events before the second factor", len(loginEvents.events))
+				}
+				if _, err := users.GetUserByID(ctx, challenge.UserID); err != nil {
+					t.Errorf("challenge is for unknown user %s", challenge.UserID)
+				}
+				return
+			}
+
+			claimsOut, appErr := utils.ValidateToken(result.Token)
+			if appErr != nil {
+				t.Fatalf("CompleteLogin returned an invalid token: %v", appErr)
+			}
+			if result.User.Email != tt.wantUserEmail || claimsOut.UserID != result.User.ID {
+				t.Errorf("signed in as %s (%s), want %s", result.User.Email, claimsOut.UserID, tt.wantUserEmail)
+			}
+			if len(loginEvents.events) != 1 || !loginEvents.events[0].Success {
+				t.Errorf("login events %+v, want one success", loginEvents.events)
+			}
+
+			// The provider account is linked, so the next sign-in finds the user by it
+			if _, err := identities.GetUserByIdentity(ctx, testIssuer, tt.claims.Subject, ""); err != nil {
+				t.Errorf("provider account was not linked: %v", err)
+			}
+		})
+	}
+}
+
+func TestOIDCCompleteLoginState(t *testing.T) {
+	users := newFakeUserRepo(&domain.User{ID: "u1", Email: "ann@example.com"})
+	provider := &fakeIdentityProvider{claims: map[string]*oidc.Claims{
+		"code": {Issuer: testIssuer, Subject: "s1", Email: "ann@example.com", EmailVerified: true},
+	}}
+	svc := NewOIDCService(provider, newFakeIdentityRepo(users), users, &fakeLoginEventRepo{}, LoginPolicy{}, nil, time.Minute)
+
+	ctx := context.Background()
+	if _, err := svc.CompleteLogin(ctx, "never-started", "code"); errorCode(err) != apperrors.ErrInvalidSSOState {
+		t.Errorf("unknown state returned %v, want %s", err, apperrors.ErrInvalidSSOState)
+	}
+
+	_, state, err := svc.StartLogin(ctx)
+	if err != nil {
+		t.Fatalf("StartLogin returned %v", err)
+	}
+	if _, err := svc.CompleteLogin(ctx, state, ""); errorCode(err) != apperrors.ErrInvalidInput {
+		t.Errorf("missing code returned %v, want %s", err, apperrors.ErrInvalidInput)
+	}
+	if _, err := svc.CompleteLogin(ctx, state, "code"); err != nil {
+		t.Fatalf("CompleteLogin returned %v", err)
+	}
+	// A state is good for one sign-in
+	if _, err := svc.CompleteLogin(ctx, state, "code"); errorCode(err) != apperrors.ErrInvalidSSOState {
+		t.Errorf("reused state returned %v, want %s", err, apperrors.ErrInvalidSSOState)
+	}
+}
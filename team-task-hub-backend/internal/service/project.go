@@ -2,16 +2,19 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/launchventures/team-task-hub-backend/internal/domain"
 	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
 	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
 	"github.com/launchventures/team-task-hub-backend/internal/utils"
 )
 
 // ProjectService defines project-related business logic operations
 type ProjectService interface {
-	CreateProject(ctx context.Context, userID string, name, description string) (*domain.Project, error)
+	CreateProject(ctx context.Context, userID string, name, description, templateID string) (*domain.Project, error)
 	GetProject(ctx context.Context, id string) (*domain.Project, error)
 	ListProjects(ctx context.Context, userID string, page, pageSize int) ([]domain.Project, int, error)
 	UpdateProject(ctx context.Context, id string, name, description string) (*domain.Project, error)
@@ -19,15 +22,23 @@ type ProjectService interface {
 }
 
 type projectService struct {
-	projectRepo repository.ProjectRepository
+	projectRepo  repository.ProjectRepository
+	templateRepo repository.TemplateRepository
 }
 
-func NewProjectService(projectRepo repository.ProjectRepository) ProjectService {
-	return &projectService{projectRepo: projectRepo}
+func NewProjectService(projectRepo repository.ProjectRepository, templateRepo repository.TemplateRepository) ProjectService {
+	return &projectService{projectRepo: projectRepo, templateRepo: templateRepo}
 }
 
-// CreateProject creates a new project with validation
-func (s *projectService) CreateProject(ctx context.Context, userID string, name, description string) (*domain.Project, error) {
+// CreateProject creates a new project with validation, optionally seeded from a project template
+func (s *projectService) CreateProject(ctx context.Context, userID string, name, description, templateID string) (*domain.Project, error) {
+	ctx, span := tracing.StartSpan(ctx, "ProjectService.CreateProject")
+	defer span.End()
+
+	if templateID != "" {
+		return s.createProjectFromTemplate(ctx, userID, name, description, templateID)
+	}
+
 	// Validate project name
 	if appErr := utils.ValidateProjectName(name); appErr != nil {
 		return nil, appErr
@@ -47,8 +58,60 @@ func (s *projectService) CreateProject(ctx context.Context, userID string, name,
 	return project, nil
 }
 
+// createProjectFromTemplate instantiates a project template, resolving seed task
+// due dates relative to now
+func (s *projectService) createProjectFromTemplate(ctx context.Context, userID string, name, description, templateID string) (*domain.Project, error) {
+	template, err := s.templateRepo.GetProjectTemplateByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = template.Name
+	}
+	if description == "" {
+		description = template.Description
+	}
+
+	if appErr := utils.ValidateProjectName(name); appErr != nil {
+		return nil, appErr
+	}
+
+	if len(description) > 1000 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "description must not exceed 1000 characters")
+	}
+
+	now := time.Now().UTC()
+	ranks := utils.EvenRanks(len(template.Tasks))
+	seedTasks := make([]domain.Task, 0, len(template.Tasks))
+	for i, t := range template.Tasks {
+		task := domain.Task{
+			Title:       t.Title,
+			Description: t.Description,
+			Priority:    t.Priority,
+			Rank:        ranks[i],
+		}
+		if t.DueOffsetDays != nil {
+			dueDate := now.AddDate(0, 0, *t.DueOffsetDays)
+			task.DueDate = &dueDate
+		}
+		seedTasks = append(seedTasks, task)
+	}
+
+	project, err := s.projectRepo.CreateProjectWithTasks(ctx, userID, userID, name, description, seedTasks)
+	if err != nil {
+		return nil, err
+	}
+	metrics.TasksCreated.WithLabelValues(metrics.TaskSourceProjectTemplate).Add(float64(len(seedTasks)))
+
+	return project, nil
+}
+
 // GetProject retrieves a project by ID
 func (s *projectService) GetProject(ctx context.Context, id string) (*domain.Project, error) {
+	ctx, span := tracing.StartSpan(ctx, "ProjectService.GetProject")
+	defer span.End()
+
 	if id == "" {
 		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid project ID")
 	}
@@ -63,6 +126,9 @@ func (s *projectService) GetProject(ctx context.Context, id string) (*domain.Pro
 
 // ListProjects retrieves all projects with pagination (shared across all users)
 func (s *projectService) ListProjects(ctx context.Context, userID string, page, pageSize int) ([]domain.Project, int, error) {
+	ctx, span := tracing.StartSpan(ctx, "ProjectService.ListProjects")
+	defer span.End()
+
 	// Validate pagination parameters
 	if page < 1 {
 		page = 1
@@ -83,6 +149,9 @@ func (s *projectService) ListProjects(ctx context.Context, userID string, page,
 
 // UpdateProject updates a project with validation
 func (s *projectService) UpdateProject(ctx context.Context, id string, name, description string) (*domain.Project, error) {
+	ctx, span := tracing.StartSpan(ctx, "ProjectService.UpdateProject")
+	defer span.End()
+
 	// Validate project name
 	if appErr := utils.ValidateProjectName(name); appErr != nil {
 		return nil, appErr
@@ -104,6 +173,9 @@ func (s *projectService) UpdateProject(ctx context.Context, id string, name, des
 
 // DeleteProject deletes a project
 func (s *projectService) DeleteProject(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "ProjectService.DeleteProject")
+	defer span.End()
+
 	if id == "" {
 		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid project ID")
 	}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+const (
+	// maxPreviewOccurrences caps how far ahead a preview can look
+	maxPreviewOccurrences = 50
+
+	// scheduledBatchSize bounds how many recurrences one scheduler pass advances
+	scheduledBatchSize = 100
+
+	// maxCatchUpOccurrences bounds how many missed occurrences of one
+	// recurrence a scheduler pass creates; later passes create the rest
+	maxCatchUpOccurrences = 100
+)
+
+// RecurrenceService defines recurring task business logic operations
+type RecurrenceService interface {
+	SetRecurrence(ctx context.Context, taskID, userID, rrule, generateOn string, dtstart *time.Time) (*domain.TaskRecurrence, error)
+	GetRecurrence(ctx context.Context, taskID string) (*domain.TaskRecurrence, error)
+	DeleteRecurrence(ctx context.Context, taskID string) error
+	PreviewOccurrences(ctx context.Context, taskID string, count int) ([]time.Time, error)
+	HandleTaskCompleted(ctx context.Context, taskID string) (*domain.Task, error)
+	GenerateScheduledOccurrences(ctx context.Context) (int, error)
+}
+
+type recurrenceService struct {
+	recurrenceRepo  repository.RecurrenceRepository
+	taskRepo        repository.TaskRepository
+	wipLimitService WIPLimitService
+}
+
+func NewRecurrenceService(recurrenceRepo repository.RecurrenceRepository, taskRepo repository.TaskRepository, wipLimitService WIPLimitService) RecurrenceService {
+	return &recurrenceService{recurrenceRepo: recurrenceRepo, taskRepo: taskRepo, wipLimitService: wipLimitService}
+}
+
+// SetRecurrence attaches or replaces a recurrence rule on a task. The rule is
+// anchored at dtstart, defaulting to the task's due date (or now).
+func (s *recurrenceService) SetRecurrence(ctx context.Context, taskID, userID, rrule, generateOn string, dtstart *time.Time) (*domain.TaskRecurrence, error) {
+	ctx, span := tracing.StartSpan(ctx, "RecurrenceService.SetRecurrence")
+	defer span.End()
+
+	if taskID == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
+	}
+
+	rule, appErr := utils.ParseRRule(rrule)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if generateOn == "" {
+		generateOn = "COMPLETION"
+	}
+	if appErr := utils.ValidateGenerateOn(generateOn); appErr != nil {
+		return nil, appErr
+	}
+
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now().UTC().Truncate(time.Second)
+	if dtstart != nil {
+		start = *dtstart
+	} else if task.DueDate != nil {
+		start = *task.DueDate
+	}
+
+	var createdByID *string
+	if userID != "" {
+		createdByID = &userID
+	}
+
+	rec := &domain.TaskRecurrence{
+		TaskID:              task.ID,
+		ProjectID:           task.ProjectID,
+		CreatedByID:         createdByID,
+		RRule:               rule.String(),
+		DTStart:             start,
+		GenerateOn:          generateOn,
+		CurrentOccurrenceAt: start,
+		NextOccurrenceAt:    rule.Nth(start, 2),
+	}
+
+	return s.recurrenceRepo.UpsertRecurrence(ctx, rec)
+}
+
+// GetRecurrence retrieves the recurrence rule of a task
+func (s *recurrenceService) GetRecurrence(ctx context.Context, taskID string) (*domain.TaskRecurrence, error) {
+	ctx, span := tracing.StartSpan(ctx, "RecurrenceService.GetRecurrence")
+	defer span.End()
+
+	if taskID == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
+	}
+
+	return s.recurrenceRepo.GetRecurrenceByTaskID(ctx, taskID)
+}
+
+// DeleteRecurrence stops a task from recurring; existing occurrences are kept
+func (s *recurrenceService) DeleteRecurrence(ctx context.Context, taskID string) error {
+	ctx, span := tracing.StartSpan(ctx, "RecurrenceService.DeleteRecurrence")
+	defer span.End()
+
+	if taskID == "" {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
+	}
+
+	return s.recurrenceRepo.DeleteRecurrenceByTaskID(ctx, taskID)
+}
+
+// PreviewOccurrences lists upcoming occurrences of a task's recurrence after
+// the current one. Counts above maxPreviewOccurrences are capped to it.
+func (s *recurrenceService) PreviewOccurrences(ctx context.Context, taskID string, count int) ([]time.Time, error) {
+	ctx, span := tracing.StartSpan(ctx, "RecurrenceService.PreviewOccurrences")
+	defer span.End()
+
+	if count < 1 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "count must be at least 1")
+	}
+	count = min(count, maxPreviewOccurrences)
+
+	rec, err := s.GetRecurrence(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, appErr := utils.ParseRRule(rec.RRule)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return rule.OccurrencesAfter(rec.DTStart, rec.CurrentOccurrenceAt, count), nil
+}
+
+// HandleTaskCompleted generates the next occurrence when a task that is the
+// current occurrence of a completion-driven recurrence is marked done
+func (s *recurrenceService) HandleTaskCompleted(ctx context.Context, taskID string) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "RecurrenceService.HandleTaskCompleted")
+	defer span.End()
+
+	rec, err := s.recurrenceRepo.GetRecurrenceByTaskID(ctx, taskID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == apperrors.ErrRecurrenceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if rec.GenerateOn != "COMPLETION" {
+		return nil, nil
+	}
+
+	return s.generateNext(ctx, rec)
+}
+
+// GenerateScheduledOccurrences advances every schedule-driven recurrence whose
+// current occurrence has been reached, returning how many tasks were created.
+// A recurrence that fell behind, say while the server was down, gets a task
+// for each occurrence it missed until its next occurrence is in the future.
+func (s *recurrenceService) GenerateScheduledOccurrences(ctx context.Context) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "RecurrenceService.GenerateScheduledOccurrences")
+	defer span.End()
+
+	now := time.Now().UTC()
+	recurrences, err := s.recurrenceRepo.ListDueScheduledRecurrences(ctx, now, scheduledBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range recurrences {
+		count, err := s.catchUp(ctx, &recurrences[i], now)
+		created += count
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to generate occurrence", "recurrence_id", recurrences[i].ID, "error", err)
+		}
+	}
+
+	return created, nil
+}
+
+// catchUp advances a scheduled recurrence while its current occurrence has
+// been reached, returning how many tasks it created
+func (s *recurrenceService) catchUp(ctx context.Context, rec *domain.TaskRecurrence, now time.Time) (int, error) {
+	created := 0
+	for created < maxCatchUpOccurrences && rec.NextOccurrenceAt != nil && !rec.CurrentOccurrenceAt.After(now) {
+		task, err := s.generateNext(ctx, rec)
+		if err != nil || task == nil {
+			return created, err
+		}
+		created++
+
+		if rec, err = s.recurrenceRepo.GetRecurrenceByTaskID(ctx, task.ID); err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+// generateNext copies the current occurrence into a new open task due at the
+// next occurrence, if the project's WIP limits allow it. Under WARN
+// enforcement the task carries the warnings.
+func (s *recurrenceService) generateNext(ctx context.Context, rec *domain.TaskRecurrence) (*domain.Task, error) {
+	if rec.NextOccurrenceAt == nil {
+		return nil, nil
+	}
+
+	rule, appErr := utils.ParseRRule(rec.RRule)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	current, err := s.taskRepo.GetTaskByID(ctx, rec.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := s.wipLimitService.CheckChange(ctx, current.ProjectID, "", "OPEN", nil, current.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The next occurrence goes to the bottom of the project's OPEN column
+	lastRank, err := s.taskRepo.GetLastRank(ctx, current.ProjectID, "OPEN")
+	if err != nil {
+		return nil, err
+	}
+
+	next := &domain.Task{
+		ProjectID:    current.ProjectID,
+		AssigneeID:   current.AssigneeID,
+		AssignedByID: current.AssignedByID,
+		CreatedByID:  rec.CreatedByID,
+		Title:        current.Title,
+		Description:  current.Description,
+		Priority:     current.Priority,
+		DueDate:      rec.NextOccurrenceAt,
+		Rank:         utils.RankBetween(lastRank, ""),
+	}
+	following := rule.Nth(rec.DTStart, rec.OccurrenceCount+2)
+
+	taskID, err := s.recurrenceRepo.AdvanceRecurrence(ctx, rec.ID, rec.TaskID, next, following)
+	if err != nil {
+		return nil, err
+	}
+	if taskID == "" {
+		return nil, nil
+	}
+	metrics.TasksCreated.WithLabelValues(metrics.TaskSourceRecurrence).Inc()
+
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	task.Warnings = warnings
+	return task, nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+)
+
+// fakeTaskRepo stores tasks by ID in creation order
+type fakeTaskRepo struct {
+	repository.TaskRepository
+	tasks []*domain.Task
+}
+
+func (r *fakeTaskRepo) add(task *domain.Task) {
+	if task.ID == "" {
+		task.ID = fmt.Sprintf("t%d", len(r.tasks)+1)
+	}
+	r.tasks = append(r.tasks, task)
+}
+
+func (r *fakeTaskRepo) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
+	for _, task := range r.tasks {
+		if task.ID == id {
+			copied := *task
+			return &copied, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError(apperrors.ErrTaskNotFound, "task not found")
+}
+
+func (r *fakeTaskRepo) GetLastRank(ctx context.Context, projectID, status string) (string, error) {
+	last := ""
+	for _, task := range r.tasks {
+		if task.ProjectID == projectID && task.Status == status && task.Rank > last {
+			last = task.Rank
+		}
+	}
+	return last, nil
+}
+
+// fakeRecurrenceRepo keeps recurrences by ID, creating occurrences in a
+// fakeTaskRepo
+type fakeRecurrenceRepo struct {
+	repository.RecurrenceRepository
+	tasks       *fakeTaskRepo
+	recurrences map[string]*domain.TaskRecurrence
+}
+
+func (r *fakeRecurrenceRepo) GetRecurrenceByTaskID(ctx context.Context, taskID string) (*domain.TaskRecurrence, error) {
+	for _, rec := range r.recurrences {
+		if rec.TaskID == taskID {
+			copied := *rec
+			return &copied, nil
+		}
+	}
+	return nil, apperrors.NewNotFoundError(apperrors.ErrRecurrenceNotFound, "recurrence not found")
+}
+
+func (r *fakeRecurrenceRepo) ListDueScheduledRecurrences(ctx context.Context, now time.Time, limit int) ([]domain.TaskRecurrence, error) {
+	var due []domain.TaskRecurrence
+	for _, rec := range r.recurrences {
+		if rec.GenerateOn == "SCHEDULE" && rec.NextOccurrenceAt != nil && !rec.CurrentOccurrenceAt.After(now) {
+			due = append(due, *rec)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeRecurrenceRepo) AdvanceRecurrence(ctx context.Context, recurrenceID, currentTaskID string, next *domain.Task, followingOccurrenceAt *time.Time) (string, error) {
+	rec := r.recurrences[recurrenceID]
+	if rec.TaskID != currentTaskID {
+		return "", nil
+	}
+	next.Status = "OPEN"
+	r.tasks.add(next)
+	rec.TaskID = next.ID
+	rec.CurrentOccurrenceAt = *next.DueDate
+	rec.NextOccurrenceAt = followingOccurrenceAt
+	rec.OccurrenceCount++
+	return next.ID, nil
+}
+
+// fakeWIPLimitRepo applies limits to the tasks in a fakeTaskRepo
+type fakeWIPLimitRepo struct {
+	repository.WIPLimitRepository
+	tasks  *fakeTaskRepo
+	limits *domain.WIPLimits
+}
+
+func (r *fakeWIPLimitRepo) GetLimits(ctx context.Context, projectID string) (*domain.WIPLimits, error) {
+	return r.limits, nil
+}
+
+func (r *fakeWIPLimitRepo) CountByStatus(ctx context.Context, projectID string) (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, task := range r.tasks.tasks {
+		if task.ProjectID == projectID {
+			counts[task.Status]++
+		}
+	}
+	return counts, nil
+}
+
+// newScheduledRecurrence returns a recurrence service with a daily scheduled
+// series that started days ago, at midnight UTC, and hasn't been advanced
+func newScheduledRecurrence(days int, limits *domain.WIPLimits) (RecurrenceService, *fakeRecurrenceRepo, *fakeTaskRepo) {
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -days)
+	second := start.AddDate(0, 0, 1)
+
+	tasks := &fakeTaskRepo{}
+	tasks.add(&domain.Task{ProjectID: "p1", Title: "Stand-up notes", Status: "OPEN", Priority: "MEDIUM", DueDate: &start, Rank: "m"})
+	recurrences := &fakeRecurrenceRepo{tasks: tasks, recurrences: map[string]*domain.TaskRecurrence{
+		"r1": {
+			ID:                  "r1",
+			TaskID:              tasks.tasks[0].ID,
+			ProjectID:           "p1",
+			RRule:               "FREQ=DAILY",
+			DTStart:             start,
+			GenerateOn:          "SCHEDULE",
+			CurrentOccurrenceAt: start,
+			NextOccurrenceAt:    &second,
+			OccurrenceCount:     1,
+		},
+	}}
+	wipLimits := NewWIPLimitService(&fakeWIPLimitRepo{tasks: tasks, limits: limits}, nil)
+	return NewRecurrenceService(recurrences, tasks, wipLimits), recurrences, tasks
+}
+
+func TestGenerateScheduledOccurrencesCatchesUp(t *testing.T) {
+	svc, recurrences, tasks := newScheduledRecurrence(5, nil)
+
+	created, err := svc.GenerateScheduledOccurrences(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateScheduledOccurrences returned %v", err)
+	}
+
+	// Days -4 to 0 were missed, and today's occurrence brings tomorrow's
+	if created != 6 || len(tasks.tasks) != 7 {
+		t.Fatalf("created %d tasks, %d in all; want 6 and 7", created, len(tasks.tasks))
+	}
+	for i, task := range tasks.tasks[1:] {
+		if want := tasks.tasks[0].DueDate.AddDate(0, 0, i+1); !task.DueDate.Equal(want) {
+			t.Errorf("occurrence %d is due %v, want %v", i+2, task.DueDate, want)
+		}
+	}
+	rec := recurrences.recurrences["r1"]
+	if now := time.Now(); !rec.NextOccurrenceAt.After(now) || !rec.CurrentOccurrenceAt.After(now) {
+		t.Errorf("recurrence left at %v, next %v; want both after now", rec.CurrentOccurrenceAt, rec.NextOccurrenceAt)
+	}
+
+	// Nothing more is due until tomorrow
+	if created, err := svc.GenerateScheduledOccurrences(context.Background()); err != nil || created != 0 {
+		t.Errorf("second pass created %d tasks, %v; want none", created, err)
+	}
+}
+
+func TestGenerateScheduledOccurrencesRespectsWIPLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		enforcement string
+		wantCreated int
+	}{
+		{name: "reject stops at the limit", enforcement: "REJECT", wantCreated: 2},
+		{name: "warn goes past the limit", enforcement: "WARN", wantCreated: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits := &domain.WIPLimits{ProjectID: "p1", StatusLimits: map[string]int{"OPEN": 3}, Enforcement: tt.enforcement}
+			svc, _, tasks := newScheduledRecurrence(5, limits)
+
+			created, err := svc.GenerateScheduledOccurrences(context.Background())
+			if err != nil {
+				t.Fatalf("GenerateScheduledOccurrences returned %v", err)
+			}
+			if created != tt.wantCreated || len(tasks.tasks) != tt.wantCreated+1 {
+				t.Errorf("created %d tasks, %d in all; want %d", created, len(tasks.tasks), tt.wantCreated)
+			}
+		})
+	}
+}
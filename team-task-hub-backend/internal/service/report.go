@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+)
+
+const (
+	// defaultStatsWeeks is the number of weeks of throughput shown on a dashboard
+	defaultStatsWeeks = 8
+
+	// maxStatsWeeks bounds the throughput history of one stats request
+	maxStatsWeeks = 52
+)
+
+// ReportService defines reporting business logic operations
+type ReportService interface {
+	GetProjectStats(ctx context.Context, projectID string, weeks int) (*domain.ProjectStats, error)
+	GetWorkload(ctx context.Context, userID, projectID string) ([]domain.WorkloadRow, error)
+}
+
+type reportService struct {
+	reportRepo  repository.ReportRepository
+	projectRepo repository.ProjectRepository
+}
+
+func NewReportService(reportRepo repository.ReportRepository, projectRepo repository.ProjectRepository) ReportService {
+	return &reportService{reportRepo: reportRepo, projectRepo: projectRepo}
+}
+
+// GetProjectStats computes a project's dashboard statistics with weeks weeks
+// of throughput history; zero weeks uses the default
+func (s *reportService) GetProjectStats(ctx context.Context, projectID string, weeks int) (*domain.ProjectStats, error) {
+	ctx, span := tracing.StartSpan(ctx, "ReportService.GetProjectStats")
+	defer span.End()
+
+	if weeks == 0 {
+		weeks = defaultStatsWeeks
+	}
+	if weeks < 1 || weeks > maxStatsWeeks {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "weeks must be between 1 and 52")
+	}
+
+	if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return s.reportRepo.GetProjectStats(ctx, projectID, weeks)
+}
+
+// GetWorkload reports each user's unfinished tasks in the projects userID can
+// see, optionally limited to one of them
+func (s *reportService) GetWorkload(ctx context.Context, userID, projectID string) ([]domain.WorkloadRow, error) {
+	ctx, span := tracing.StartSpan(ctx, "ReportService.GetWorkload")
+	defer span.End()
+
+	if projectID != "" {
+		if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.reportRepo.GetWorkload(ctx, userID, projectID)
+}
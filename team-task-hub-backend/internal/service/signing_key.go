@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	"github.com/launchventures/team-task-hub-backend/internal/keys"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+)
+
+// SigningKeyService defines JWT signing key rotation operations
+type SigningKeyService interface {
+	RotateKeys(ctx context.Context) error
+}
+
+// KeyRotationPolicy schedules signing keys
+type KeyRotationPolicy struct {
+	Algorithm string
+	// Interval is how long each key signs; PrePublish is how long a new key
+	// is published before it starts
+	Interval   time.Duration
+	PrePublish time.Duration
+	// Retention is how long a superseded key is kept for verification: the
+	// lifetime of the longest-lived token it may have signed
+	Retention time.Duration
+}
+
+type signingKeyService struct {
+	signingKeyRepo repository.SigningKeyRepository
+	ring           *keys.Ring
+	sealer         *keys.Sealer
+	policy         KeyRotationPolicy
+}
+
+// NewSigningKeyService creates the key rotation service. Private keys are
+// stored sealed by sealer.
+func NewSigningKeyService(signingKeyRepo repository.SigningKeyRepository, ring *keys.Ring, sealer *keys.Sealer, policy KeyRotationPolicy) SigningKeyService {
+	return &signingKeyService{
+		signingKeyRepo: signingKeyRepo,
+		ring:           ring,
+		sealer:         sealer,
+		policy:         policy,
+	}
+}
+
+// RotateKeys adds the next key once the current one is due for replacement,
+// deletes keys no token can still use, and loads the result into the ring.
+// Every instance runs it; the database decides which instance's key is kept.
+func (s *signingKeyService) RotateKeys(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "SigningKeyService.RotateKeys")
+	defer span.End()
+
+	stored, err := s.signingKeyRepo.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	activatesAt, due := s.nextActivation(stored, time.Now())
+	if due {
+		var latestID *string
+		if len(stored) > 0 {
+			latestID = &stored[0].ID
+		}
+		if err := s.addKey(ctx, activatesAt, latestID); err != nil {
+			return err
+		}
+	}
+
+	deleted, err := s.signingKeyRepo.DeleteSupersededKeys(ctx, s.policy.Retention)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		slog.InfoContext(ctx, "deleted superseded signing keys", "count", deleted)
+	}
+
+	// Reload when the stored keys changed, here or on another instance
+	if due || deleted > 0 {
+		if stored, err = s.signingKeyRepo.ListKeys(ctx); err != nil {
+			return err
+		}
+	}
+	return s.load(stored)
+}
+
+// nextActivation decides whether a new key is due, given the stored keys
+// newest first, and when it should start signing
+func (s *signingKeyService) nextActivation(stored []domain.SigningKey, now time.Time) (time.Time, bool) {
+	if len(stored) == 0 {
+		// Nothing can have cached a key yet, so the first one signs at once
+		return now, true
+	}
+
+	latest := stored[0]
+	earliest := now.Add(s.policy.PrePublish)
+	switch {
+	case latest.Algorithm != s.policy.Algorithm:
+		// The configured algorithm changed; switch after pre-publishing
+		return earliest, true
+	case !now.Before(latest.ActivatesAt.Add(s.policy.Interval - s.policy.PrePublish)):
+		next := latest.ActivatesAt.Add(s.policy.Interval)
+		if next.Before(earliest) {
+			next = earliest
+		}
+		return next, true
+	}
+	return time.Time{}, false
+}
+
+// addKey generates and stores a key activating at activatesAt, unless another
+// instance has already added one
+func (s *signingKeyService) addKey(ctx context.Context, activatesAt time.Time, latestID *string) error {
+	key, err := keys.Generate(s.policy.Algorithm, activatesAt)
+	if err != nil {
+		return err
+	}
+	der, err := key.MarshalPrivate()
+	if err != nil {
+		return err
+	}
+	sealed, err := s.sealer.Seal(key.ID, der)
+	if err != nil {
+		return err
+	}
+
+	inserted, err := s.signingKeyRepo.InsertKeyAfter(ctx, &domain.SigningKey{
+		ID:                  key.ID,
+		Algorithm:           key.Algorithm,
+		EncryptedPrivateKey: sealed,
+		ActivatesAt:         key.ActivatesAt,
+	}, latestID)
+	if err != nil {
+		return err
+	}
+	if inserted {
+		slog.InfoContext(ctx, "added signing key", "kid", key.ID, "algorithm", key.Algorithm, "activates_at", key.ActivatesAt)
+	}
+	return nil
+}
+
+// load decrypts the stored keys and replaces the ring with them
+func (s *signingKeyService) load(stored []domain.SigningKey) error {
+	ring := make([]*keys.Key, 0, len(stored))
+	for _, k := range stored {
+		der, err := s.sealer.Open(k.ID, k.EncryptedPrivateKey)
+		if err != nil {
+			return err
+		}
+		key, err := keys.Parse(k.ID, k.Algorithm, der, k.ActivatesAt)
+		if err != nil {
+			return err
+		}
+		ring = append(ring, key)
+	}
+	if len(ring) == 0 {
+		return fmt.Errorf("no signing keys stored")
+	}
+
+	s.ring.Set(ring)
+	return nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// SprintService defines sprint business logic operations
+type SprintService interface {
+	CreateSprint(ctx context.Context, projectID, userID, name, goal string, startDate, endDate time.Time) (*domain.Sprint, error)
+	GetSprint(ctx context.Context, id string) (*domain.Sprint, error)
+	ListSprints(ctx context.Context, projectID string) ([]domain.Sprint, error)
+	UpdateSprint(ctx context.Context, id, name, goal string, startDate, endDate time.Time) (*domain.Sprint, error)
+	DeleteSprint(ctx context.Context, id string) error
+	StartSprint(ctx context.Context, id string) (*domain.Sprint, error)
+	CompleteSprint(ctx context.Context, id, rolloverSprintID string) (*domain.SprintCompletion, error)
+	SetTaskSprint(ctx context.Context, taskID string, sprintID *string) (*domain.Task, error)
+	Burndown(ctx context.Context, id string) ([]domain.BurndownPoint, error)
+}
+
+type sprintService struct {
+	sprintRepo  repository.SprintRepository
+	projectRepo repository.ProjectRepository
+	taskRepo    repository.TaskRepository
+}
+
+func NewSprintService(sprintRepo repository.SprintRepository, projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository) SprintService {
+	return &sprintService{sprintRepo: sprintRepo, projectRepo: projectRepo, taskRepo: taskRepo}
+}
+
+// CreateSprint creates a planned sprint in a project
+func (s *sprintService) CreateSprint(ctx context.Context, projectID, userID, name, goal string, startDate, endDate time.Time) (*domain.Sprint, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.CreateSprint")
+	defer span.End()
+
+	if appErr := validateSprint(name, startDate, endDate); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	sprint := &domain.Sprint{
+		ProjectID:   projectID,
+		CreatedByID: &userID,
+		Name:        strings.TrimSpace(name),
+		Goal:        strings.TrimSpace(goal),
+		StartDate:   startDate,
+		EndDate:     endDate,
+	}
+
+	return s.sprintRepo.CreateSprint(ctx, sprint)
+}
+
+// GetSprint retrieves a sprint by ID
+func (s *sprintService) GetSprint(ctx context.Context, id string) (*domain.Sprint, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.GetSprint")
+	defer span.End()
+
+	if id == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid sprint ID")
+	}
+
+	return s.sprintRepo.GetSprintByID(ctx, id)
+}
+
+// ListSprints retrieves a project's sprints
+func (s *sprintService) ListSprints(ctx context.Context, projectID string) ([]domain.Sprint, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.ListSprints")
+	defer span.End()
+
+	if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return s.sprintRepo.ListSprintsByProjectID(ctx, projectID)
+}
+
+// UpdateSprint updates a sprint that has not been completed
+func (s *sprintService) UpdateSprint(ctx context.Context, id, name, goal string, startDate, endDate time.Time) (*domain.Sprint, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.UpdateSprint")
+	defer span.End()
+
+	if appErr := validateSprint(name, startDate, endDate); appErr != nil {
+		return nil, appErr
+	}
+
+	sprint, err := s.sprintRepo.GetSprintByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.Status == "COMPLETED" {
+		return nil, apperrors.NewConflictError(apperrors.ErrInvalidTransition, "a completed sprint cannot be changed")
+	}
+
+	return s.sprintRepo.UpdateSprint(ctx, id, strings.TrimSpace(name), strings.TrimSpace(goal), startDate, endDate)
+}
+
+// DeleteSprint deletes a sprint; its tasks return to the backlog
+func (s *sprintService) DeleteSprint(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.DeleteSprint")
+	defer span.End()
+
+	if id == "" {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid sprint ID")
+	}
+
+	return s.sprintRepo.DeleteSprint(ctx, id)
+}
+
+// StartSprint starts a planned sprint; a project can only have one active sprint
+func (s *sprintService) StartSprint(ctx context.Context, id string) (*domain.Sprint, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.StartSprint")
+	defer span.End()
+
+	if _, err := s.sprintRepo.GetSprintByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.sprintRepo.StartSprint(ctx, id)
+}
+
+// CompleteSprint completes an active sprint and rolls its unfinished tasks over.
+// Tasks move to rolloverSprintID when given, otherwise to the project's next
+// planned sprint, otherwise to the backlog.
+func (s *sprintService) CompleteSprint(ctx context.Context, id, rolloverSprintID string) (*domain.SprintCompletion, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.CompleteSprint")
+	defer span.End()
+
+	sprint, err := s.sprintRepo.GetSprintByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.Status != "ACTIVE" {
+		return nil, apperrors.NewConflictError(apperrors.ErrInvalidTransition, "only an active sprint can be completed")
+	}
+
+	var target *string
+	if rolloverSprintID != "" {
+		if rolloverSprintID == id {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "unfinished tasks cannot roll over into the sprint being completed")
+		}
+		next, err := s.sprintRepo.GetSprintByID(ctx, rolloverSprintID)
+		if err != nil {
+			return nil, err
+		}
+		if next.ProjectID != sprint.ProjectID || next.Status == "COMPLETED" {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "rollover sprint must be an open sprint in the same project")
+		}
+		target = &next.ID
+	} else {
+		sprints, err := s.sprintRepo.ListSprintsByProjectID(ctx, sprint.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range sprints {
+			if candidate.Status == "PLANNED" {
+				target = &candidate.ID
+				break
+			}
+		}
+	}
+
+	completed, moved, err := s.sprintRepo.CompleteSprint(ctx, id, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SprintCompletion{
+		Sprint:           completed,
+		RolledOverTasks:  moved,
+		RolloverSprintID: target,
+	}, nil
+}
+
+// SetTaskSprint moves a task into an open sprint of its project, or back to
+// the backlog when sprintID is nil or empty
+func (s *sprintService) SetTaskSprint(ctx context.Context, taskID string, sprintID *string) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.SetTaskSprint")
+	defer span.End()
+
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprintID == nil || *sprintID == "" {
+		return s.taskRepo.UpdateSprint(ctx, taskID, nil)
+	}
+
+	sprint, err := s.sprintRepo.GetSprintByID(ctx, *sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.ProjectID != task.ProjectID {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "sprint belongs to a different project")
+	}
+
+	if sprint.Status == "COMPLETED" {
+		return nil, apperrors.NewConflictError(apperrors.ErrInvalidTransition, "tasks cannot be added to a completed sprint")
+	}
+
+	return s.taskRepo.UpdateSprint(ctx, taskID, &sprint.ID)
+}
+
+// Burndown returns daily scope and completion for a sprint, from its start
+// date up to its end date or today, whichever is earlier
+func (s *sprintService) Burndown(ctx context.Context, id string) ([]domain.BurndownPoint, error) {
+	ctx, span := tracing.StartSpan(ctx, "SprintService.Burndown")
+	defer span.End()
+
+	sprint, err := s.sprintRepo.GetSprintByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	to := sprint.EndDate
+	if sprint.CompletedAt != nil && sprint.CompletedAt.Before(to) {
+		to = *sprint.CompletedAt
+	}
+	if today := time.Now().UTC(); today.Before(to) {
+		to = today
+	}
+
+	if to.Before(sprint.StartDate) {
+		return []domain.BurndownPoint{}, nil
+	}
+
+	return s.sprintRepo.Burndown(ctx, id, sprint.StartDate, to)
+}
+
+func validateSprint(name string, startDate, endDate time.Time) *apperrors.AppError {
+	if appErr := utils.ValidateSprintName(name); appErr != nil {
+		return appErr
+	}
+
+	if startDate.IsZero() || endDate.IsZero() {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "start_date and end_date are required")
+	}
+
+	if endDate.Before(startDate) {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "end_date must not be before start_date")
+	}
+
+	return nil
+}
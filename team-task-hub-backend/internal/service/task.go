@@ -2,36 +2,48 @@ package service
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/launchventures/team-task-hub-backend/internal/domain"
 	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
 	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
 	"github.com/launchventures/team-task-hub-backend/internal/utils"
 )
 
 // TaskService defines task-related business logic operations
 type TaskService interface {
-	CreateTask(ctx context.Context, projectID, createdByID string, title, description, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
+	CreateTask(ctx context.Context, source, projectID, createdByID string, title, description, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
 	GetTask(ctx context.Context, id string) (*domain.Task, error)
-	ListTasks(ctx context.Context, projectID string, page, pageSize int, status, priority string) ([]domain.Task, int, error)
+	ListTasks(ctx context.Context, projectID string, page, pageSize int, status, priority, sprintID string) ([]domain.Task, int, error)
 	ListAssignedTasks(ctx context.Context, userID string, page, pageSize int, status, priority string) ([]domain.Task, int, error)
 	UpdateTask(ctx context.Context, id string, title, description, status, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
 	AssignTask(ctx context.Context, taskID, userID, assignedByID string) error
 	UnassignTask(ctx context.Context, taskID string) error
+	UpdateEstimates(ctx context.Context, taskID string, originalMinutes, remainingMinutes, storyPoints *int) (*domain.Task, error)
+	MoveTask(ctx context.Context, taskID, status, beforeID, afterID string) (*domain.Task, error)
 	DeleteTask(ctx context.Context, id string) error
 }
 
 type taskService struct {
-	taskRepo repository.TaskRepository
+	taskRepo          repository.TaskRepository
+	recurrenceService RecurrenceService
+	wipLimitService   WIPLimitService
 }
 
-func NewTaskService(taskRepo repository.TaskRepository) TaskService {
-	return &taskService{taskRepo: taskRepo}
+func NewTaskService(taskRepo repository.TaskRepository, recurrenceService RecurrenceService, wipLimitService WIPLimitService) TaskService {
+	return &taskService{taskRepo: taskRepo, recurrenceService: recurrenceService, wipLimitService: wipLimitService}
 }
 
-// CreateTask creates a new task with validation
-func (s *taskService) CreateTask(ctx context.Context, projectID, createdByID string, title, description, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
+// CreateTask creates a new task with validation. source is the
+// metrics.TaskSource value the task is counted under.
+func (s *taskService) CreateTask(ctx context.Context, source, projectID, createdByID string, title, description, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.CreateTask")
+	defer span.End()
+
 	// Validate task title
 	if appErr := utils.ValidateTaskTitle(title); appErr != nil {
 		return nil, appErr
@@ -50,17 +62,34 @@ func (s *taskService) CreateTask(ctx context.Context, projectID, createdByID str
 	// Tasks are created with "OPEN" status by default
 	status := "OPEN"
 
+	// Check the project's WIP limits for the new task's column
+	warnings, err := s.wipLimitService.CheckChange(ctx, projectID, "", status, nil, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// New tasks go to the bottom of their column
+	lastRank, err := s.taskRepo.GetLastRank(ctx, projectID, status)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create task in database
-	task, err := s.taskRepo.CreateTask(ctx, projectID, createdByID, title, description, status, priority, assigneeID, dueDate)
+	task, err := s.taskRepo.CreateTask(ctx, projectID, createdByID, title, description, status, priority, utils.RankBetween(lastRank, ""), assigneeID, dueDate)
 	if err != nil {
 		return nil, err
 	}
+	metrics.TasksCreated.WithLabelValues(source).Inc()
+	task.Warnings = warnings
 
 	return task, nil
 }
 
 // GetTask retrieves a task by ID
 func (s *taskService) GetTask(ctx context.Context, id string) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.GetTask")
+	defer span.End()
+
 	if id == "" {
 		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
 	}
@@ -73,8 +102,12 @@ func (s *taskService) GetTask(ctx context.Context, id string) (*domain.Task, err
 	return task, nil
 }
 
-// ListTasks retrieves all tasks for a project with optional filters and pagination
-func (s *taskService) ListTasks(ctx context.Context, projectID string, page, pageSize int, status, priority string) ([]domain.Task, int, error) {
+// ListTasks retrieves all tasks for a project with optional filters and pagination.
+// sprintID filters to one sprint, or to the backlog when it is "none".
+func (s *taskService) ListTasks(ctx context.Context, projectID string, page, pageSize int, status, priority, sprintID string) ([]domain.Task, int, error) {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.ListTasks")
+	defer span.End()
+
 	// Validate pagination parameters
 	if page < 1 {
 		page = 1
@@ -97,9 +130,16 @@ func (s *taskService) ListTasks(ctx context.Context, projectID string, page, pag
 		}
 	}
 
+	// Validate sprint if provided
+	if sprintID != "" && sprintID != "none" {
+		if _, err := uuid.Parse(sprintID); err != nil {
+			return nil, 0, apperrors.NewValidationError(apperrors.ErrInvalidInput, "sprint_id must be a sprint ID or \"none\"")
+		}
+	}
+
 	offset := (page - 1) * pageSize
 
-	tasks, total, err := s.taskRepo.ListTasksByProjectID(ctx, projectID, pageSize, offset, status, priority)
+	tasks, total, err := s.taskRepo.ListTasksByProjectID(ctx, projectID, pageSize, offset, status, priority, sprintID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -109,6 +149,9 @@ func (s *taskService) ListTasks(ctx context.Context, projectID string, page, pag
 
 // ListAssignedTasks retrieves all tasks assigned to a user with optional filters and pagination
 func (s *taskService) ListAssignedTasks(ctx context.Context, userID string, page, pageSize int, status, priority string) ([]domain.Task, int, error) {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.ListAssignedTasks")
+	defer span.End()
+
 	if page < 1 {
 		page = 1
 	}
@@ -129,6 +172,9 @@ func (s *taskService) ListAssignedTasks(ctx context.Context, userID string, page
 
 // UpdateTask updates a task with validation
 func (s *taskService) UpdateTask(ctx context.Context, id string, title, description, status, priority string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.UpdateTask")
+	defer span.End()
+
 	// Get current task first to support partial updates
 	currentTask, err := s.taskRepo.GetTaskByID(ctx, id)
 	if err != nil {
@@ -180,22 +226,68 @@ func (s *taskService) UpdateTask(ctx context.Context, id string, title, descript
 		finalDueDate = currentTask.DueDate
 	}
 
+	// Check the project's WIP limits when the task changes column or assignee
+	finalAssigneeID := currentTask.AssigneeID
+	if assigneeID != nil {
+		finalAssigneeID = assigneeID
+	}
+	warnings, err := s.wipLimitService.CheckChange(ctx, currentTask.ProjectID, currentTask.Status, status, currentTask.AssigneeID, finalAssigneeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A task whose status changes goes to the bottom of its new column
+	rank := ""
+	if currentTask.Status != status {
+		lastRank, err := s.taskRepo.GetLastRank(ctx, currentTask.ProjectID, status)
+		if err != nil {
+			return nil, err
+		}
+		rank = utils.RankBetween(lastRank, "")
+	}
+
 	// Update task in database
-	task, err := s.taskRepo.UpdateTask(ctx, id, title, description, status, priority, assigneeID, finalDueDate)
+	task, err := s.taskRepo.UpdateTask(ctx, id, title, description, status, priority, rank, assigneeID, finalDueDate)
 	if err != nil {
 		return nil, err
 	}
+	if currentTask.Status != status {
+		metrics.StatusTransitions.WithLabelValues(currentTask.Status, status).Inc()
+	}
 
+	// Completing the current occurrence of a recurring task spawns the next one.
+	// The update itself has succeeded, so a generation failure is only logged.
+	if currentTask.Status != "DONE" && status == "DONE" {
+		if _, err := s.recurrenceService.HandleTaskCompleted(ctx, id); err != nil {
+			slog.ErrorContext(ctx, "failed to generate next occurrence", "task_id", id, "error", err)
+		}
+	}
+
+	task.Warnings = warnings
 	return task, nil
 }
 
 // AssignTask assigns a task to a user
 func (s *taskService) AssignTask(ctx context.Context, taskID, userID, assignedByID string) error {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.AssignTask")
+	defer span.End()
+
 	if taskID == "" || userID == "" || assignedByID == "" {
 		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID, user ID, or assigned by ID")
 	}
 
-	_, err := s.taskRepo.AssignTaskToUser(ctx, taskID, userID, assignedByID)
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	// Under WARN enforcement the assignment goes ahead; there is no task in the
+	// response to carry the warning
+	if _, err := s.wipLimitService.CheckChange(ctx, task.ProjectID, task.Status, task.Status, task.AssigneeID, &userID); err != nil {
+		return err
+	}
+
+	_, err = s.taskRepo.AssignTaskToUser(ctx, taskID, userID, assignedByID)
 	if err != nil {
 		return err
 	}
@@ -205,6 +297,9 @@ func (s *taskService) AssignTask(ctx context.Context, taskID, userID, assignedBy
 
 // UnassignTask clears the assignee and assigned_by for a task
 func (s *taskService) UnassignTask(ctx context.Context, taskID string) error {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.UnassignTask")
+	defer span.End()
+
 	if taskID == "" {
 		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
 	}
@@ -212,8 +307,183 @@ func (s *taskService) UnassignTask(ctx context.Context, taskID string) error {
 	return s.taskRepo.UnassignTask(ctx, taskID)
 }
 
+// UpdateEstimates sets a task's original and remaining estimates in minutes and
+// its story points; a nil value leaves that estimate unchanged
+func (s *taskService) UpdateEstimates(ctx context.Context, taskID string, originalMinutes, remainingMinutes, storyPoints *int) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.UpdateEstimates")
+	defer span.End()
+
+	if taskID == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
+	}
+
+	if (originalMinutes != nil && *originalMinutes < 0) || (remainingMinutes != nil && *remainingMinutes < 0) || (storyPoints != nil && *storyPoints < 0) {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "estimates must not be negative")
+	}
+
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if originalMinutes == nil {
+		originalMinutes = task.OriginalEstimateMinutes
+	}
+	if remainingMinutes == nil {
+		remainingMinutes = task.RemainingEstimateMinutes
+	}
+	if storyPoints == nil {
+		storyPoints = task.StoryPoints
+	}
+
+	return s.taskRepo.UpdateEstimates(ctx, taskID, originalMinutes, remainingMinutes, storyPoints)
+}
+
+// MoveTask moves a task to a status column, placing it between the tasks
+// beforeID (above it) and afterID (below it). Either neighbour may be empty;
+// with neither the task goes to the bottom of the column.
+func (s *taskService) MoveTask(ctx context.Context, taskID, status, beforeID, afterID string) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.MoveTask")
+	defer span.End()
+
+	task, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		status = task.Status
+	} else if appErr := utils.ValidateStatus(status); appErr != nil {
+		return nil, appErr
+	}
+
+	if beforeID == taskID || afterID == taskID {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "a task cannot be its own neighbour")
+	}
+
+	warnings, err := s.wipLimitService.CheckChange(ctx, task.ProjectID, task.Status, status, task.AssigneeID, task.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+
+	rank, err := s.rankForMove(ctx, task, status, beforeID, afterID)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := s.taskRepo.MoveTask(ctx, taskID, status, rank)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status != status {
+		metrics.StatusTransitions.WithLabelValues(task.Status, status).Inc()
+	}
+
+	if len(rank) > utils.MaxRankLength {
+		if err := s.rebalanceColumn(ctx, task.ProjectID, status); err != nil {
+			return nil, err
+		}
+		if moved, err = s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+			return nil, err
+		}
+	}
+
+	if task.Status != "DONE" && status == "DONE" {
+		if _, err := s.recurrenceService.HandleTaskCompleted(ctx, taskID); err != nil {
+			slog.ErrorContext(ctx, "failed to generate next occurrence", "task_id", taskID, "error", err)
+		}
+	}
+
+	moved.Warnings = warnings
+	return moved, nil
+}
+
+// rankForMove computes a rank between the requested neighbours. Neighbours
+// that share a rank (possible after concurrent inserts) leave no room, so the
+// column is rebalanced once and the ranks are read again.
+func (s *taskService) rankForMove(ctx context.Context, task *domain.Task, status, beforeID, afterID string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		before, after, err := s.neighbourRanks(ctx, task, status, beforeID, afterID)
+		if err != nil {
+			return "", err
+		}
+
+		if (after == "" && afterID == "") || before < after {
+			return utils.RankBetween(before, after), nil
+		}
+
+		if before > after {
+			return "", apperrors.NewValidationError(apperrors.ErrInvalidInput, "before_id must be above after_id in the column")
+		}
+
+		if attempt > 0 {
+			return "", apperrors.NewInternalError("failed to make room between neighbouring tasks", nil)
+		}
+
+		if err := s.rebalanceColumn(ctx, task.ProjectID, status); err != nil {
+			return "", err
+		}
+	}
+}
+
+// neighbourRanks resolves the ranks the moved task must sit between, filling
+// in a missing neighbour from the column itself
+func (s *taskService) neighbourRanks(ctx context.Context, task *domain.Task, status, beforeID, afterID string) (string, string, error) {
+	neighbourRank := func(id string) (string, error) {
+		neighbour, err := s.taskRepo.GetTaskByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if neighbour.ProjectID != task.ProjectID || neighbour.Status != status {
+			return "", apperrors.NewValidationError(apperrors.ErrInvalidInput, "neighbouring tasks must be in the target column of the same project")
+		}
+		return neighbour.Rank, nil
+	}
+
+	var before, after string
+	var err error
+
+	switch {
+	case beforeID != "" && afterID != "":
+		if before, err = neighbourRank(beforeID); err != nil {
+			return "", "", err
+		}
+		after, err = neighbourRank(afterID)
+	case beforeID != "":
+		if before, err = neighbourRank(beforeID); err != nil {
+			return "", "", err
+		}
+		after, err = s.taskRepo.GetAdjacentRank(ctx, task.ProjectID, status, before, task.ID, true)
+	case afterID != "":
+		if after, err = neighbourRank(afterID); err != nil {
+			return "", "", err
+		}
+		before, err = s.taskRepo.GetAdjacentRank(ctx, task.ProjectID, status, after, task.ID, false)
+	default:
+		before, err = s.taskRepo.GetLastRank(ctx, task.ProjectID, status)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return before, after, nil
+}
+
+// rebalanceColumn spreads the ranks of a status column evenly, keeping its order
+func (s *taskService) rebalanceColumn(ctx context.Context, projectID, status string) error {
+	ids, err := s.taskRepo.ListColumnTaskIDs(ctx, projectID, status)
+	if err != nil {
+		return err
+	}
+
+	return s.taskRepo.UpdateRanks(ctx, ids, utils.EvenRanks(len(ids)))
+}
+
 // DeleteTask deletes a task
 func (s *taskService) DeleteTask(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "TaskService.DeleteTask")
+	defer span.End()
+
 	if id == "" {
 		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid task ID")
 	}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+)
+
+func (r *fakeTaskRepo) UpdateTask(ctx context.Context, id string, title, description, status, priority, rank string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
+	for _, task := range r.tasks {
+		if task.ID != id {
+			continue
+		}
+		task.Title, task.Description, task.Status, task.Priority = title, description, status, priority
+		if rank != "" {
+			task.Rank = rank
+		}
+		if assigneeID != nil {
+			task.AssigneeID = assigneeID
+		}
+		task.DueDate = dueDate
+		break
+	}
+	return r.GetTaskByID(ctx, id)
+}
+
+// fakeRecurrenceService records the tasks reported completed
+type fakeRecurrenceService struct {
+	RecurrenceService
+	completed []string
+}
+
+func (s *fakeRecurrenceService) HandleTaskCompleted(ctx context.Context, taskID string) (*domain.Task, error) {
+	s.completed = append(s.completed, taskID)
+	return nil, nil
+}
+
+func TestUpdateTaskStatusMovesToTheBottomOfTheColumn(t *testing.T) {
+	tasks := &fakeTaskRepo{}
+	tasks.add(&domain.Task{ProjectID: "p1", Title: "Done already", Status: "DONE", Priority: "LOW", Rank: "t"})
+	tasks.add(&domain.Task{ProjectID: "p1", Title: "Ship it", Status: "IN_PROGRESS", Priority: "HIGH", Rank: "m"})
+	recurrences := &fakeRecurrenceService{}
+	svc := NewTaskService(tasks, recurrences, NewWIPLimitService(&fakeWIPLimitRepo{tasks: tasks}, nil))
+
+	// The fake has no MoveTask, so status and rank must be written together
+	task, err := svc.UpdateTask(context.Background(), "t2", "", "", "DONE", "", nil, nil)
+	if err != nil {
+		t.Fatalf("UpdateTask returned %v", err)
+	}
+	if task.Status != "DONE" || task.Rank <= "t" {
+		t.Errorf("task is %s with rank %q, want DONE below rank \"t\"", task.Status, task.Rank)
+	}
+	if len(recurrences.completed) != 1 || recurrences.completed[0] != "t2" {
+		t.Errorf("completed tasks reported %v, want [t2]", recurrences.completed)
+	}
+
+	// Changing anything else keeps the task where it is
+	rank := task.Rank
+	task, err = svc.UpdateTask(context.Background(), "t2", "Shipped", "", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("UpdateTask returned %v", err)
+	}
+	if task.Title != "Shipped" || task.Rank != rank {
+		t.Errorf("task %q moved to rank %q", task.Title, task.Rank)
+	}
+	if len(recurrences.completed) != 1 {
+		t.Errorf("completion reported again: %v", recurrences.completed)
+	}
+}
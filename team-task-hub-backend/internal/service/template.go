@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+const (
+	// maxTemplateTasks bounds the number of seed tasks in a project template
+	maxTemplateTasks = 200
+)
+
+// TemplateService defines task and project template business logic operations
+type TemplateService interface {
+	CreateTaskTemplate(ctx context.Context, userID, name, title, description, priority string, checklist []string) (*domain.TaskTemplate, error)
+	GetTaskTemplate(ctx context.Context, id string) (*domain.TaskTemplate, error)
+	ListTaskTemplates(ctx context.Context) ([]domain.TaskTemplate, error)
+	DeleteTaskTemplate(ctx context.Context, id string) error
+	InstantiateTaskTemplate(ctx context.Context, templateID, projectID, userID string, assigneeID *string, dueDate *time.Time) (*domain.Task, error)
+	CreateProjectTemplate(ctx context.Context, userID, name, description string, tasks []domain.ProjectTemplateTask) (*domain.ProjectTemplate, error)
+	CreateProjectTemplateFromProject(ctx context.Context, projectID, userID, name string) (*domain.ProjectTemplate, error)
+	GetProjectTemplate(ctx context.Context, id string) (*domain.ProjectTemplate, error)
+	ListProjectTemplates(ctx context.Context) ([]domain.ProjectTemplate, error)
+	DeleteProjectTemplate(ctx context.Context, id string) error
+}
+
+type templateService struct {
+	templateRepo  repository.TemplateRepository
+	projectRepo   repository.ProjectRepository
+	taskRepo      repository.TaskRepository
+	checklistRepo repository.ChecklistRepository
+	taskService   TaskService
+}
+
+func NewTemplateService(templateRepo repository.TemplateRepository, projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository, checklistRepo repository.ChecklistRepository, taskService TaskService) TemplateService {
+	return &templateService{
+		templateRepo:  templateRepo,
+		projectRepo:   projectRepo,
+		taskRepo:      taskRepo,
+		checklistRepo: checklistRepo,
+		taskService:   taskService,
+	}
+}
+
+// CreateTaskTemplate creates a reusable task template with validation
+func (s *templateService) CreateTaskTemplate(ctx context.Context, userID, name, title, description, priority string, checklist []string) (*domain.TaskTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.CreateTaskTemplate")
+	defer span.End()
+
+	if appErr := utils.ValidateTemplateName(name); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := utils.ValidateTaskTitle(title); appErr != nil {
+		return nil, appErr
+	}
+
+	if len(description) > 2000 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "description must not exceed 2000 characters")
+	}
+
+	if priority == "" {
+		priority = "MEDIUM"
+	}
+	if appErr := utils.ValidatePriority(priority); appErr != nil {
+		return nil, appErr
+	}
+
+	items := make([]string, 0, len(checklist))
+	for _, item := range checklist {
+		if appErr := utils.ValidateChecklistContent(item); appErr != nil {
+			return nil, appErr
+		}
+		items = append(items, strings.TrimSpace(item))
+	}
+
+	return s.templateRepo.CreateTaskTemplate(ctx, &domain.TaskTemplate{
+		CreatedByID: &userID,
+		Name:        strings.TrimSpace(name),
+		Title:       title,
+		Description: description,
+		Priority:    priority,
+		Checklist:   items,
+	})
+}
+
+// GetTaskTemplate retrieves a task template by ID
+func (s *templateService) GetTaskTemplate(ctx context.Context, id string) (*domain.TaskTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.GetTaskTemplate")
+	defer span.End()
+
+	if id == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid template ID")
+	}
+
+	return s.templateRepo.GetTaskTemplateByID(ctx, id)
+}
+
+// ListTaskTemplates retrieves all task templates
+func (s *templateService) ListTaskTemplates(ctx context.Context) ([]domain.TaskTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.ListTaskTemplates")
+	defer span.End()
+
+	return s.templateRepo.ListTaskTemplates(ctx)
+}
+
+// DeleteTaskTemplate deletes a task template
+func (s *templateService) DeleteTaskTemplate(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.DeleteTaskTemplate")
+	defer span.End()
+
+	if id == "" {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid template ID")
+	}
+
+	return s.templateRepo.DeleteTaskTemplate(ctx, id)
+}
+
+// InstantiateTaskTemplate creates a task in a project from a task template.
+// Creation goes through TaskService so the usual task rules apply.
+func (s *templateService) InstantiateTaskTemplate(ctx context.Context, templateID, projectID, userID string, assigneeID *string, dueDate *time.Time) (*domain.Task, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.InstantiateTaskTemplate")
+	defer span.End()
+
+	template, err := s.GetTaskTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectID == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "project_id is required")
+	}
+
+	if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskService.CreateTask(ctx, metrics.TaskSourceTaskTemplate, projectID, userID, template.Title, template.Description, template.Priority, assigneeID, dueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(template.Checklist) > 0 {
+		if err := s.checklistRepo.CreateItems(ctx, task.ID, template.Checklist); err != nil {
+			return nil, err
+		}
+		return s.taskRepo.GetTaskByID(ctx, task.ID)
+	}
+
+	return task, nil
+}
+
+// CreateProjectTemplate creates a project template from an explicit list of seed tasks
+func (s *templateService) CreateProjectTemplate(ctx context.Context, userID, name, description string, tasks []domain.ProjectTemplateTask) (*domain.ProjectTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.CreateProjectTemplate")
+	defer span.End()
+
+	if appErr := utils.ValidateTemplateName(name); appErr != nil {
+		return nil, appErr
+	}
+
+	if len(description) > 1000 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "description must not exceed 1000 characters")
+	}
+
+	if len(tasks) > maxTemplateTasks {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "project template has too many tasks")
+	}
+
+	for i := range tasks {
+		if appErr := utils.ValidateTaskTitle(tasks[i].Title); appErr != nil {
+			return nil, appErr
+		}
+		if len(tasks[i].Description) > 2000 {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "description must not exceed 2000 characters")
+		}
+		if tasks[i].Priority == "" {
+			tasks[i].Priority = "MEDIUM"
+		}
+		if appErr := utils.ValidatePriority(tasks[i].Priority); appErr != nil {
+			return nil, appErr
+		}
+		if tasks[i].DueOffsetDays != nil && *tasks[i].DueOffsetDays < 0 {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "due_offset_days must not be negative")
+		}
+	}
+
+	return s.templateRepo.CreateProjectTemplate(ctx, &domain.ProjectTemplate{
+		CreatedByID: &userID,
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		Tasks:       tasks,
+	})
+}
+
+// CreateProjectTemplateFromProject captures an existing project's tasks as a
+// project template. Due dates become offsets from the project's creation date.
+func (s *templateService) CreateProjectTemplateFromProject(ctx context.Context, projectID, userID, name string) (*domain.ProjectTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.CreateProjectTemplateFromProject")
+	defer span.End()
+
+	if projectID == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid project ID")
+	}
+
+	project, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = project.Name
+	}
+
+	projectTasks := make([]domain.Task, 0)
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		page, total, err := s.taskRepo.ListTasksByProjectID(ctx, projectID, pageSize, offset, "", "", "")
+		if err != nil {
+			return nil, err
+		}
+		projectTasks = append(projectTasks, page...)
+		if offset+pageSize >= total || len(page) == 0 {
+			break
+		}
+	}
+
+	// Seed tasks keep the order in which they were created in the source project
+	sort.SliceStable(projectTasks, func(i, j int) bool {
+		return projectTasks[i].CreatedAt.Before(projectTasks[j].CreatedAt)
+	})
+
+	tasks := make([]domain.ProjectTemplateTask, 0, len(projectTasks))
+	for _, t := range projectTasks {
+		task := domain.ProjectTemplateTask{
+			Title:       t.Title,
+			Description: t.Description,
+			Priority:    t.Priority,
+		}
+		if t.DueDate != nil {
+			days := int(t.DueDate.Sub(project.CreatedAt).Hours() / 24)
+			if days < 0 {
+				days = 0
+			}
+			task.DueOffsetDays = &days
+		}
+		tasks = append(tasks, task)
+	}
+
+	return s.CreateProjectTemplate(ctx, userID, name, project.Description, tasks)
+}
+
+// GetProjectTemplate retrieves a project template with its seed tasks
+func (s *templateService) GetProjectTemplate(ctx context.Context, id string) (*domain.ProjectTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.GetProjectTemplate")
+	defer span.End()
+
+	if id == "" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid template ID")
+	}
+
+	return s.templateRepo.GetProjectTemplateByID(ctx, id)
+}
+
+// ListProjectTemplates retrieves all project templates
+func (s *templateService) ListProjectTemplates(ctx context.Context) ([]domain.ProjectTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.ListProjectTemplates")
+	defer span.End()
+
+	return s.templateRepo.ListProjectTemplates(ctx)
+}
+
+// DeleteProjectTemplate deletes a project template
+func (s *templateService) DeleteProjectTemplate(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "TemplateService.DeleteProjectTemplate")
+	defer span.End()
+
+	if id == "" {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid template ID")
+	}
+
+	return s.templateRepo.DeleteProjectTemplate(ctx, id)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+)
+
+const (
+	// maxTimeEntryDuration bounds a single manually logged entry
+	maxTimeEntryDuration = 24 * time.Hour
+
+	// maxTimesheetRange bounds the period covered by one timesheet report
+	maxTimesheetRange = 366 * 24 * time.Hour
+)
+
+// TimeEntryService defines time tracking business logic operations
+type TimeEntryService interface {
+	LogTime(ctx context.Context, taskID, userID string, startedAt, endedAt *time.Time, durationMinutes int, note string) (*domain.TimeEntry, error)
+	StartTimer(ctx context.Context, taskID, userID, note string) (*domain.TimeEntry, error)
+	StopTimer(ctx context.Context, userID string) (*domain.TimeEntry, error)
+	GetRunningTimer(ctx context.Context, userID string) (*domain.TimeEntry, error)
+	ListTaskEntries(ctx context.Context, taskID string) ([]domain.TimeEntry, error)
+	DeleteEntry(ctx context.Context, entryID, userID string) error
+	Timesheet(ctx context.Context, filter domain.TimesheetFilter) ([]domain.TimesheetRow, error)
+}
+
+type timeEntryService struct {
+	timeEntryRepo repository.TimeEntryRepository
+	taskRepo      repository.TaskRepository
+}
+
+func NewTimeEntryService(timeEntryRepo repository.TimeEntryRepository, taskRepo repository.TaskRepository) TimeEntryService {
+	return &timeEntryService{timeEntryRepo: timeEntryRepo, taskRepo: taskRepo}
+}
+
+// LogTime records a completed period of work. Either started_at and ended_at,
+// or a duration are required; a duration alone is logged as ending now.
+func (s *timeEntryService) LogTime(ctx context.Context, taskID, userID string, startedAt, endedAt *time.Time, durationMinutes int, note string) (*domain.TimeEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimeEntryService.LogTime")
+	defer span.End()
+
+	if appErr := validateTimeNote(note); appErr != nil {
+		return nil, appErr
+	}
+
+	var start, end time.Time
+	switch {
+	case startedAt != nil && endedAt != nil:
+		start, end = *startedAt, *endedAt
+	case durationMinutes > 0:
+		end = time.Now().UTC()
+		if endedAt != nil {
+			end = *endedAt
+		}
+		start = end.Add(-time.Duration(durationMinutes) * time.Minute)
+		if startedAt != nil {
+			start = *startedAt
+			end = start.Add(time.Duration(durationMinutes) * time.Minute)
+		}
+	default:
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "either started_at and ended_at or duration_minutes is required")
+	}
+
+	if !end.After(start) {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "ended_at must be after started_at")
+	}
+	if end.Sub(start) > maxTimeEntryDuration {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "a time entry cannot exceed 24 hours")
+	}
+
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.timeEntryRepo.CreateEntry(ctx, taskID, userID, start, end, strings.TrimSpace(note))
+}
+
+// StartTimer starts a timer on a task; a user can only have one running timer
+func (s *timeEntryService) StartTimer(ctx context.Context, taskID, userID, note string) (*domain.TimeEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimeEntryService.StartTimer")
+	defer span.End()
+
+	if appErr := validateTimeNote(note); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.timeEntryRepo.StartTimer(ctx, taskID, userID, strings.TrimSpace(note))
+}
+
+// StopTimer stops the user's running timer
+func (s *timeEntryService) StopTimer(ctx context.Context, userID string) (*domain.TimeEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimeEntryService.StopTimer")
+	defer span.End()
+
+	return s.timeEntryRepo.StopTimer(ctx, userID)
+}
+
+// GetRunningTimer retrieves the user's running timer
+func (s *timeEntryService) GetRunningTimer(ctx context.Context, userID string) (*domain.TimeEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimeEntryService.GetRunningTimer")
+	defer span.End()
+
+	return s.timeEntryRepo.GetRunningTimer(ctx, userID)
+}
+
+// ListTaskEntries retrieves all time logged on a task
+func (s *timeEntryService) ListTaskEntries(ctx context.Context, taskID string) ([]domain.TimeEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimeEntryService.ListTaskEntries")
+	defer span.End()
+
+	if _, err := s.taskRepo.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.timeEntryRepo.ListEntriesByTaskID(ctx, taskID)
+}
+
+// DeleteEntry deletes one of the user's own time entries
+func (s *timeEntryService) DeleteEntry(ctx context.Context, entryID, userID string) error {
+	ctx, span := tracing.StartSpan(ctx, "TimeEntryService.DeleteEntry")
+	defer span.End()
+
+	if entryID == "" {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "invalid time entry ID")
+	}
+
+	entry, err := s.timeEntryRepo.GetEntryByID(ctx, entryID)
+	if err != nil {
+		return err
+	}
+
+	if entry.UserID != userID {
+		return apperrors.NewAuthError(apperrors.ErrForbidden, "you can only delete your own time entries")
+	}
+
+	return s.timeEntryRepo.DeleteEntry(ctx, entryID)
+}
+
+// Timesheet aggregates logged hours over a period, grouped by any of user, project and day
+func (s *timeEntryService) Timesheet(ctx context.Context, filter domain.TimesheetFilter) ([]domain.TimesheetRow, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimeEntryService.Timesheet")
+	defer span.End()
+
+	if !filter.To.After(filter.From) {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "to must be after from")
+	}
+	if filter.To.Sub(filter.From) > maxTimesheetRange {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "timesheet range cannot exceed one year")
+	}
+
+	seen := make(map[string]bool)
+	groupBy := make([]string, 0, len(filter.GroupBy))
+	for _, dimension := range filter.GroupBy {
+		dimension = strings.ToLower(strings.TrimSpace(dimension))
+		if dimension != "user" && dimension != "project" && dimension != "day" {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "group_by must be a combination of user, project and day")
+		}
+		if !seen[dimension] {
+			seen[dimension] = true
+			groupBy = append(groupBy, dimension)
+		}
+	}
+	if len(groupBy) == 0 {
+		groupBy = []string{"user", "project", "day"}
+	}
+	filter.GroupBy = groupBy
+
+	return s.timeEntryRepo.Timesheet(ctx, filter)
+}
+
+func validateTimeNote(note string) *apperrors.AppError {
+	if len(note) > 1000 {
+		return apperrors.NewValidationError(apperrors.ErrInvalidInput, "note must not exceed 1000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/keys"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// recoveryCodeCount is how many recovery codes a user gets at a time
+const recoveryCodeCount = 10
+
+// TwoFactorService defines TOTP two-factor authentication operations
+type TwoFactorService interface {
+	GetStatus(ctx context.Context, userID string) (*domain.TwoFactorStatus, error)
+	Enroll(ctx context.Context, userID string) (*domain.TwoFactorEnrollment, error)
+	Confirm(ctx context.Context, userID, code string) ([]string, error)
+	Disable(ctx context.Context, userID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+	Verify(ctx context.Context, userID, code string) error
+}
+
+type twoFactorService struct {
+	twoFactorRepo  repository.TwoFactorRepository
+	userRepo       repository.UserRepository
+	loginEventRepo repository.LoginEventRepository
+	loginPolicy    LoginPolicy
+	sealer         *keys.Sealer
+	issuer         string
+}
+
+// NewTwoFactorService creates the service. issuer labels the account in
+// authenticator apps. loginPolicy's RequireTwoFactor stops users from turning
+// two-factor off, and its lockout applies to codes checked for changes.
+// Secrets are stored sealed by sealer.
+func NewTwoFactorService(twoFactorRepo repository.TwoFactorRepository, userRepo repository.UserRepository, loginEventRepo repository.LoginEventRepository, loginPolicy LoginPolicy, sealer *keys.Sealer, issuer string) TwoFactorService {
+	return &twoFactorService{
+		twoFactorRepo:  twoFactorRepo,
+		userRepo:       userRepo,
+		loginEventRepo: loginEventRepo,
+		loginPolicy:    loginPolicy,
+		sealer:         sealer,
+		issuer:         issuer,
+	}
+}
+
+// GetStatus reports whether the user has two-factor enabled
+func (s *twoFactorService) GetStatus(ctx context.Context, userID string) (*domain.TwoFactorStatus, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.GetStatus")
+	defer span.End()
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tf, err := s.twoFactorRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.TwoFactorStatus{Enabled: tf.Enabled, Required: s.loginPolicy.RequireTwoFactor || user.TwoFactorRequired}
+	if tf.Enabled {
+		status.RecoveryCodesRemaining, err = s.twoFactorRepo.CountRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// Enroll generates a new secret for the user to add to an authenticator app.
+// Two-factor stays off until a code from the app is confirmed.
+func (s *twoFactorService) Enroll(ctx context.Context, userID string) (*domain.TwoFactorEnrollment, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Enroll")
+	defer span.End()
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, apperrors.NewConflictError(apperrors.ErrTwoFactorEnabled, "two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate two-factor secret", err)
+	}
+
+	sealed, err := s.sealer.Seal(userID, []byte(secret))
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to encrypt two-factor secret", err)
+	}
+	if err := s.twoFactorRepo.SetPendingSecret(ctx, userID, sealed); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorEnrollment{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables two-factor once the user proves their authenticator works,
+// returning recovery codes to show them once
+func (s *twoFactorService) Confirm(ctx context.Context, userID, code string) ([]string, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Confirm")
+	defer span.End()
+
+	tf, err := s.twoFactorRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, apperrors.NewConflictError(apperrors.ErrTwoFactorEnabled, "two-factor authentication is already enabled")
+	}
+	if len(tf.EncryptedSecret) == 0 {
+		return nil, apperrors.NewConflictError(apperrors.ErrTwoFactorDisabled, "start two-factor enrollment first")
+	}
+	secret, err := s.openSecret(tf)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := utils.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return nil, invalidTwoFactorCode()
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactorRepo.Enable(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns two-factor off after checking a current code, unless it is
+// required of everyone or an admin required it of this user
+func (s *twoFactorService) Disable(ctx context.Context, userID, code string) error {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Disable")
+	defer span.End()
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if s.loginPolicy.RequireTwoFactor || user.TwoFactorRequired {
+		return apperrors.NewAuthError(apperrors.ErrTwoFactorRequired, "two-factor authentication is required and cannot be disabled")
+	}
+
+	if err := s.confirmCode(ctx, user, code); err != nil {
+		return err
+	}
+
+	return s.twoFactorRepo.Disable(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking a
+// current code
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.RegenerateRecoveryCodes")
+	defer span.End()
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.confirmCode(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Verify checks a TOTP code or an unused recovery code. Each TOTP code and
+// recovery code is accepted only once.
+func (s *twoFactorService) Verify(ctx context.Context, userID, code string) error {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Verify")
+	defer span.End()
+
+	tf, err := s.twoFactorRepo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !tf.Enabled || len(tf.EncryptedSecret) == 0 {
+		return apperrors.NewConflictError(apperrors.ErrTwoFactorDisabled, "two-factor authentication is not enabled")
+	}
+	secret, err := s.openSecret(tf)
+	if err != nil {
+		return err
+	}
+
+	if step, ok := utils.ValidateTOTP(secret, code, time.Now()); ok {
+		fresh, err := s.twoFactorRepo.UseStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return invalidTwoFactorCode()
+		}
+		return nil
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(ctx, userID, utils.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return invalidTwoFactorCode()
+	}
+
+	return nil
+}
+
+// confirmCode checks a signed-in user's code before a change to their
+// two-factor settings. Wrong codes count towards the login lockout, as at
+// sign-in, so a stolen session can't be used to guess codes.
+func (s *twoFactorService) confirmCode(ctx context.Context, user *domain.User, code string) error {
+	client := utils.ClientInfoFromContext(ctx)
+	event := &domain.LoginEvent{
+		UserID:    &user.ID,
+		Email:     strings.ToLower(user.Email),
+		IPAddress: client.IP,
+		UserAgent: client.UserAgent,
+	}
+	if err := checkLoginBlocks(ctx, s.loginEventRepo, s.loginPolicy, event); err != nil {
+		return err
+	}
+
+	if err := s.Verify(ctx, user.ID, code); err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == apperrors.ErrInvalidTwoFactor {
+			recordLoginEvent(ctx, s.loginEventRepo, event, domain.LoginFailureInvalidTwoFactor)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// openSecret decrypts a user's TOTP secret
+func (s *twoFactorService) openSecret(tf *domain.TwoFactor) (string, error) {
+	secret, err := s.sealer.Open(tf.UserID, tf.EncryptedSecret)
+	if err != nil {
+		return "", apperrors.NewInternalError("failed to decrypt two-factor secret", err)
+	}
+	return string(secret), nil
+}
+
+// EncryptPlainTOTPSecrets encrypts the TOTP secrets stored in plain text
+// before secrets were sealed, returning how many it encrypted. It runs at
+// startup; when instances start together, only the first to encrypt a
+// secret stores it.
+func EncryptPlainTOTPSecrets(ctx context.Context, twoFactorRepo repository.TwoFactorRepository, sealer *keys.Sealer) (int, error) {
+	secrets, err := twoFactorRepo.ListPlainSecrets(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for userID, secret := range secrets {
+		sealed, err := sealer.Seal(userID, []byte(secret))
+		if err != nil {
+			return 0, err
+		}
+		if err := twoFactorRepo.EncryptPlainSecret(ctx, userID, secret, sealed); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(secrets), nil
+}
+
+// newRecoveryCodes returns fresh recovery codes and their hashes
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, apperrors.NewInternalError("failed to generate recovery codes", err)
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
+
+func invalidTwoFactorCode() error {
+	return apperrors.NewAuthError(apperrors.ErrInvalidTwoFactor, "invalid two-factor code")
+}
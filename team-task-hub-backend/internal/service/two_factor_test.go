@@ -0,0 +1,266 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/keys"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+var testSealer = func() *keys.Sealer {
+	sealer, err := keys.NewSealer(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	if err != nil {
+		panic(err)
+	}
+	return sealer
+}()
+
+// fakeTwoFactorRepo keeps two-factor settings and recovery code hashes by
+// user, and secrets left in plain text by older builds
+type fakeTwoFactorRepo struct {
+	repository.TwoFactorRepository
+	settings      map[string]*domain.TwoFactor
+	recoveryCodes map[string][]string
+	plainSecrets  map[string]string
+}
+
+func newFakeTwoFactorRepo() *fakeTwoFactorRepo {
+	return &fakeTwoFactorRepo{
+		settings:      make(map[string]*domain.TwoFactor),
+		recoveryCodes: make(map[string][]string),
+		plainSecrets:  make(map[string]string),
+	}
+}
+
+func (r *fakeTwoFactorRepo) GetTwoFactor(ctx context.Context, userID string) (*domain.TwoFactor, error) {
+	tf, ok := r.settings[userID]
+	if !ok {
+		return &domain.TwoFactor{UserID: userID}, nil
+	}
+	copied := *tf
+	return &copied, nil
+}
+
+func (r *fakeTwoFactorRepo) SetPendingSecret(ctx context.Context, userID string, encryptedSecret []byte) error {
+	r.settings[userID] = &domain.TwoFactor{UserID: userID, EncryptedSecret: encryptedSecret}
+	return nil
+}
+
+func (r *fakeTwoFactorRepo) ListPlainSecrets(ctx context.Context) (map[string]string, error) {
+	return maps.Clone(r.plainSecrets), nil
+}
+
+func (r *fakeTwoFactorRepo) EncryptPlainSecret(ctx context.Context, userID, secret string, encryptedSecret []byte) error {
+	if r.plainSecrets[userID] != secret {
+		return nil
+	}
+	delete(r.plainSecrets, userID)
+	r.settings[userID] = &domain.TwoFactor{UserID: userID, EncryptedSecret: encryptedSecret, Enabled: true}
+	return nil
+}
+
+func (r *fakeTwoFactorRepo) Disable(ctx context.Context, userID string) error {
+	delete(r.settings, userID)
+	delete(r.recoveryCodes, userID)
+	return nil
+}
+
+func (r *fakeTwoFactorRepo) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	tf := r.settings[userID]
+	if tf.LastStep != nil && *tf.LastStep >= step {
+		return false, nil
+	}
+	tf.LastStep = &step
+	return true, nil
+}
+
+func (r *fakeTwoFactorRepo) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	i := slices.Index(r.recoveryCodes[userID], codeHash)
+	if i < 0 {
+		return false, nil
+	}
+	r.recoveryCodes[userID] = slices.Delete(r.recoveryCodes[userID], i, i+1)
+	return true, nil
+}
+
+func (r *fakeTwoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	r.recoveryCodes[userID] = codeHashes
+	return nil
+}
+
+func (r *fakeTwoFactorRepo) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	return len(r.recoveryCodes[userID]), nil
+}
+
+// enable turns two-factor on for userID with the given recovery codes
+func (r *fakeTwoFactorRepo) enable(userID string, recoveryCodes ...string) {
+	secret, _ := utils.GenerateTOTPSecret()
+	sealed, _ := testSealer.Seal(userID, []byte(secret))
+	r.settings[userID] = &domain.TwoFactor{UserID: userID, EncryptedSecret: sealed, Enabled: true}
+	for _, code := range recoveryCodes {
+		r.recoveryCodes[userID] = append(r.recoveryCodes[userID], utils.HashRecoveryCode(code))
+	}
+}
+
+var twoFactorTestPolicy = LoginPolicy{
+	MaxAttempts:     3,
+	LockDuration:    time.Minute,
+	MaxLockDuration: time.Hour,
+}
+
+func TestTwoFactorChangesNeedACode(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(svc TwoFactorService, code string) error
+	}{
+		{name: "disable", change: func(svc TwoFactorService, code string) error {
+			return svc.Disable(context.Background(), "u1", code)
+		}},
+		{name: "regenerate recovery codes", change: func(svc TwoFactorService, code string) error {
+			_, err := svc.RegenerateRecoveryCodes(context.Background(), "u1", code)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twoFactor := newFakeTwoFactorRepo()
+			twoFactor.enable("u1", "aaaaa-bbbbb")
+			loginEvents := &fakeLoginEventRepo{}
+			svc := NewTwoFactorService(twoFactor, newFakeUserRepo(&domain.User{ID: "u1", Email: "Ann@example.com"}), loginEvents, twoFactorTestPolicy, testSealer, "Team Task Hub")
+
+			// Wrong codes fail and count towards the lockout
+			for i := 0; i < twoFactorTestPolicy.MaxAttempts; i++ {
+				if err := tt.change(svc, "000000"); errorCode(err) != apperrors.ErrInvalidTwoFactor {
+					t.Fatalf("wrong code %d returned %v, want %s", i+1, err, apperrors.ErrInvalidTwoFactor)
+				}
+			}
+			// Once locked, even the right code is refused without being checked
+			if err := tt.change(svc, "aaaaa-bbbbb"); errorCode(err) != apperrors.ErrTooManyAttempts {
+				t.Fatalf("right code while locked returned %v, want %s", err, apperrors.ErrTooManyAttempts)
+			}
+
+			want := []string{
+				domain.LoginFailureInvalidTwoFactor,
+				domain.LoginFailureInvalidTwoFactor,
+				domain.LoginFailureInvalidTwoFactor,
+				domain.LoginFailureLocked,
+			}
+			if got := loginEvents.failures(); !slices.Equal(got, want) {
+				t.Errorf("recorded failures %v, want %v", got, want)
+			}
+			if loginEvents.events[0].Email != "ann@example.com" {
+				t.Errorf("recorded email %q, want it lower-cased as at sign-in", loginEvents.events[0].Email)
+			}
+			if len(twoFactor.recoveryCodes["u1"]) != 1 {
+				t.Error("the recovery code was used up while locked")
+			}
+		})
+	}
+}
+
+func TestTwoFactorChangesWithARightCode(t *testing.T) {
+	twoFactor := newFakeTwoFactorRepo()
+	twoFactor.enable("u1", "aaaaa-bbbbb", "ccccc-ddddd")
+	svc := NewTwoFactorService(twoFactor, newFakeUserRepo(&domain.User{ID: "u1", Email: "ann@example.com"}), &fakeLoginEventRepo{}, twoFactorTestPolicy, testSealer, "Team Task Hub")
+	ctx := context.Background()
+
+	codes, err := svc.RegenerateRecoveryCodes(ctx, "u1", "aaaaa-bbbbb")
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes returned %v", err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Errorf("got %d recovery codes, want %d", len(codes), recoveryCodeCount)
+	}
+	// The old codes are replaced
+	if err := svc.Disable(ctx, "u1", "ccccc-ddddd"); errorCode(err) != apperrors.ErrInvalidTwoFactor {
+		t.Errorf("old recovery code returned %v, want %s", err, apperrors.ErrInvalidTwoFactor)
+	}
+
+	if err := svc.Disable(ctx, "u1", codes[0]); err != nil {
+		t.Fatalf("Disable returned %v", err)
+	}
+	if status, _ := svc.GetStatus(ctx, "u1"); status.Enabled {
+		t.Error("two-factor is still enabled")
+	}
+}
+
+func TestTwoFactorDisableWhenRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   *domain.User
+		policy LoginPolicy
+	}{
+		{name: "required of everyone", user: &domain.User{ID: "u1"}, policy: LoginPolicy{RequireTwoFactor: true}},
+		{name: "required by an admin", user: &domain.User{ID: "u1", TwoFactorRequired: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twoFactor := newFakeTwoFactorRepo()
+			twoFactor.enable("u1", "aaaaa-bbbbb")
+			svc := NewTwoFactorService(twoFactor, newFakeUserRepo(tt.user), &fakeLoginEventRepo{}, tt.policy, testSealer, "Team Task Hub")
+			ctx := context.Background()
+
+			if err := svc.Disable(ctx, "u1", "aaaaa-bbbbb"); errorCode(err) != apperrors.ErrTwoFactorRequired {
+				t.Fatalf("Disable returned %v, want %s", err, apperrors.ErrTwoFactorRequired)
+			}
+			status, err := svc.GetStatus(ctx, "u1")
+			if err != nil {
+				t.Fatalf("GetStatus returned %v", err)
+			}
+			if !status.Enabled || !status.Required {
+				t.Errorf("status %+v, want enabled and required", status)
+			}
+		})
+	}
+}
+
+func TestTwoFactorEnrollSealsTheSecret(t *testing.T) {
+	twoFactor := newFakeTwoFactorRepo()
+	svc := NewTwoFactorService(twoFactor, newFakeUserRepo(&domain.User{ID: "u1", Email: "ann@example.com"}), &fakeLoginEventRepo{}, twoFactorTestPolicy, testSealer, "Team Task Hub")
+
+	enrollment, err := svc.Enroll(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("Enroll returned %v", err)
+	}
+	stored := twoFactor.settings["u1"].EncryptedSecret
+	if bytes.Contains(stored, []byte(enrollment.Secret)) {
+		t.Fatal("the secret is stored in plain text")
+	}
+	secret, err := testSealer.Open("u1", stored)
+	if err != nil || string(secret) != enrollment.Secret {
+		t.Fatalf("stored secret opens to %q, %v; want %q", secret, err, enrollment.Secret)
+	}
+	// The secret is bound to its user, so it can't be copied to another row
+	if _, err := testSealer.Open("u2", stored); err == nil {
+		t.Error("the secret opened for another user")
+	}
+}
+
+func TestEncryptPlainTOTPSecrets(t *testing.T) {
+	twoFactor := newFakeTwoFactorRepo()
+	twoFactor.plainSecrets["u1"] = "JBSWY3DPEHPK3PXP"
+	twoFactor.plainSecrets["u2"] = "KRSXG5CTMVRXEZLU"
+
+	count, err := EncryptPlainTOTPSecrets(context.Background(), twoFactor, testSealer)
+	if err != nil {
+		t.Fatalf("EncryptPlainTOTPSecrets returned %v", err)
+	}
+	if count != 2 || len(twoFactor.plainSecrets) != 0 {
+		t.Fatalf("encrypted %d secrets, %d left in plain text; want 2 and 0", count, len(twoFactor.plainSecrets))
+	}
+	secret, err := testSealer.Open("u1", twoFactor.settings["u1"].EncryptedSecret)
+	if err != nil || string(secret) != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("encrypted secret opens to %q, %v", secret, err)
+	}
+}
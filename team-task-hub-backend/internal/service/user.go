@@ -2,103 +2,377 @@ package service
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/launchventures/team-task-hub-backend/internal/domain"
 	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/metrics"
 	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
 	"github.com/launchventures/team-task-hub-backend/internal/utils"
 )
 
 // UserService defines user-related business logic operations
 type UserService interface {
-	SignUp(ctx context.Context, email, password string) (*domain.User, string, error)
-	Login(ctx context.Context, email, password string) (*domain.User, string, error)
+	SignUp(ctx context.Context, email, password string) (*domain.LoginResult, error)
+	Login(ctx context.Context, email, password string) (*domain.LoginResult, error)
+	VerifyTwoFactor(ctx context.Context, challengeToken, code string) (*domain.LoginResult, error)
+	StartTwoFactorEnrollment(ctx context.Context, challengeToken string) (*domain.TwoFactorEnrollment, error)
 	GetProfile(ctx context.Context, userID string) (*domain.User, error)
-	UpdateProfile(ctx context.Context, userID string, email string) (*domain.User, error)
+	UpdateProfile(ctx context.Context, userID string, name string) (*domain.User, error)
 	ListUsers(ctx context.Context) ([]domain.User, error)
+	ListLoginEvents(ctx context.Context, userID string, limit int) ([]domain.LoginEvent, error)
+	ValidateSession(ctx context.Context, userID string, sessionGeneration int) error
+}
+
+// LoginPolicy limits password guessing against accounts and from client IPs
+type LoginPolicy struct {
+	// MaxAttempts consecutive failures lock an account for LockDuration;
+	// every further MaxAttempts failures double it, up to MaxLockDuration
+	MaxAttempts     int
+	LockDuration    time.Duration
+	MaxLockDuration time.Duration
+	// IPMaxAttempts failures from one IP within IPWindow block it; zero
+	// disables the IP check
+	IPMaxAttempts int
+	IPWindow      time.Duration
+
+	// RequireTwoFactor makes users without two-factor enroll at sign-in
+	RequireTwoFactor bool
+	// ChallengeTTL is how long a sign-in challenge token stays valid
+	ChallengeTTL time.Duration
+}
+
+// failureLookback bounds how far back failed logins count towards a lockout
+const failureLookback = 24 * time.Hour
+
+// lockDuration returns how long an account stays locked after failures
+// consecutive failed logins, or zero if it isn't locked
+func (p LoginPolicy) lockDuration(failures int) time.Duration {
+	if failures < p.MaxAttempts {
+		return 0
+	}
+	doublings := failures/p.MaxAttempts - 1
+	if doublings > 30 {
+		return p.MaxLockDuration
+	}
+	return time.Duration(math.Min(float64(p.LockDuration)*math.Pow(2, float64(doublings)), float64(p.MaxLockDuration)))
 }
 
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	loginEventRepo   repository.LoginEventRepository
+	twoFactorService TwoFactorService
+	loginPolicy      LoginPolicy
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
-	return &userService{userRepo: userRepo}
+func NewUserService(userRepo repository.UserRepository, loginEventRepo repository.LoginEventRepository, twoFactorService TwoFactorService, loginPolicy LoginPolicy) UserService {
+	return &userService{
+		userRepo:         userRepo,
+		loginEventRepo:   loginEventRepo,
+		twoFactorService: twoFactorService,
+		loginPolicy:      loginPolicy,
+	}
 }
 
-// SignUp creates a new user account
-func (s *userService) SignUp(ctx context.Context, email, password string) (*domain.User, string, error) {
-	// Validate inputs
-	log.Printf("[Service.SignUp] Starting signup for email: %s", email)
+// dummyPasswordHash is checked against when the email is unknown, so a login
+// for a missing account takes as long as a wrong password
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := utils.HashPassword("dummy password for timing")
+	return hash
+})
+
+// SignUp creates a new user account. When two-factor authentication is
+// required the result is an enrollment challenge instead of a token.
+func (s *userService) SignUp(ctx context.Context, email, password string) (*domain.LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.SignUp")
+	defer span.End()
 
+	// Validate inputs
 	if appErr := utils.ValidateEmail(email); appErr != nil {
-		log.Printf("[Service.SignUp] Email validation failed: %v", appErr)
-		return nil, "", appErr
+		return nil, appErr
 	}
 
 	if appErr := utils.ValidatePassword(password); appErr != nil {
-		log.Printf("[Service.SignUp] Password validation failed: %v", appErr)
-		return nil, "", appErr
+		return nil, appErr
 	}
 
 	// Hash password
-	log.Printf("[Service.SignUp] Hashing password")
 	hashedPassword, err := utils.HashPassword(password)
 	if err != nil {
-		log.Printf("[Service.SignUp] Hash error: %v", err)
-		return nil, "", apperrors.NewInternalError("failed to hash password", err)
+		return nil, apperrors.NewInternalError("failed to hash password", err)
 	}
 
 	// Create user in database
-	log.Printf("[Service.SignUp] Creating user in database")
 	user, err := s.userRepo.CreateUser(ctx, email, hashedPassword)
 	if err != nil {
-		log.Printf("[Service.SignUp] CreateUser error: %v, Type: %T", err, err)
-		return nil, "", err
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "user signed up", "user_id", user.ID)
+
+	if s.loginPolicy.RequireTwoFactor {
+		return s.challenge(user, utils.PurposeTwoFactorEnroll)
 	}
 
 	// Generate JWT token
-	log.Printf("[Service.SignUp] Generating token")
-	token, err := utils.GenerateToken(user.ID, user.Email)
+	token, err := utils.GenerateToken(user.ID, user.Email, user.SessionGeneration)
 	if err != nil {
-		log.Printf("[Service.SignUp] Token generation error: %v", err)
-		return nil, "", apperrors.NewInternalError("failed to generate token", err)
+		return nil, apperrors.NewInternalError("failed to generate token", err)
 	}
 
-	log.Printf("[Service.SignUp] SignUp successful for user: %s", user.ID)
-	return user, token, nil
+	return &domain.LoginResult{User: user, Token: token}, nil
 }
 
-// Login authenticates a user and returns a JWT token
-func (s *userService) Login(ctx context.Context, email, password string) (*domain.User, string, error) {
+// Login checks a user's password. Users without two-factor authentication
+// get an access token; the others get a challenge token for VerifyTwoFactor.
+func (s *userService) Login(ctx context.Context, email, password string) (*domain.LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.Login")
+	defer span.End()
+
 	// Validate inputs
 	if appErr := utils.ValidateEmail(email); appErr != nil {
-		return nil, "", appErr
+		return nil, appErr
 	}
 
-	// Get user by email
+	client := utils.ClientInfoFromContext(ctx)
+	event := &domain.LoginEvent{
+		Email:     strings.ToLower(strings.TrimSpace(email)),
+		IPAddress: client.IP,
+		UserAgent: client.UserAgent,
+	}
+
+	// Refuse before checking the password while the IP or account is blocked
+	if err := s.checkLoginBlocks(ctx, event); err != nil {
+		return nil, err
+	}
+
+	// Get user by email; an unknown email fails exactly like a wrong password
 	user, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
-		return nil, "", err
+		if appErr, ok := err.(*apperrors.AppError); !ok || appErr.Code != apperrors.ErrUserNotFound {
+			return nil, err
+		}
+		utils.VerifyPassword(dummyPasswordHash(), password)
+		s.recordLogin(ctx, event, domain.LoginFailureInvalidCredentials)
+		return nil, invalidCredentials()
 	}
+	event.UserID = &user.ID
 
 	// Verify password
 	if !utils.VerifyPassword(user.PasswordHash, password) {
-		return nil, "", apperrors.NewAuthError(apperrors.ErrInvalidPassword, "invalid password")
+		s.recordLogin(ctx, event, domain.LoginFailureInvalidCredentials)
+		return nil, invalidCredentials()
+	}
+
+	// Only said once the password is right, so it doesn't reveal the account
+	if user.DeactivatedAt != nil {
+		s.recordLogin(ctx, event, domain.LoginFailureDeactivated)
+		return nil, accountDeactivated()
+	}
+
+	// The sign-in is recorded once the second factor is checked
+	if purpose := secondFactorPurpose(user, s.loginPolicy); purpose != "" {
+		return s.challenge(user, purpose)
 	}
 
 	// Generate JWT token
-	token, err := utils.GenerateToken(user.ID, user.Email)
+	token, err := utils.GenerateToken(user.ID, user.Email, user.SessionGeneration)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate token", err)
+	}
+
+	s.recordLogin(ctx, event, "")
+	return &domain.LoginResult{User: user, Token: token}, nil
+}
+
+// VerifyTwoFactor completes a sign-in by exchanging a challenge token and a
+// TOTP or recovery code for an access token. For an enrollment challenge the
+// code confirms the new authenticator and the result carries recovery codes.
+func (s *userService) VerifyTwoFactor(ctx context.Context, challengeToken, code string) (*domain.LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.VerifyTwoFactor")
+	defer span.End()
+
+	claims, appErr := utils.ValidateChallengeToken(challengeToken, utils.PurposeTwoFactor)
+	if appErr != nil {
+		if claims, appErr = utils.ValidateChallengeToken(challengeToken, utils.PurposeTwoFactorEnroll); appErr != nil {
+			return nil, appErr
+		}
+	}
+	// A password change since the challenge was issued voids it
+	if err := s.ValidateSession(ctx, claims.UserID, claims.SessionGeneration); err != nil {
+		return nil, err
+	}
+
+	client := utils.ClientInfoFromContext(ctx)
+	event := &domain.LoginEvent{
+		UserID:    &claims.UserID,
+		Email:     strings.ToLower(claims.Email),
+		IPAddress: client.IP,
+		UserAgent: client.UserAgent,
+	}
+
+	// Code guesses count towards the same lockout as password guesses
+	if err := s.checkLoginBlocks(ctx, event); err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	var err error
+	if claims.Purpose == utils.PurposeTwoFactorEnroll {
+		recoveryCodes, err = s.twoFactorService.Confirm(ctx, claims.UserID, code)
+	} else {
+		err = s.twoFactorService.Verify(ctx, claims.UserID, code)
+	}
 	if err != nil {
-		return nil, "", apperrors.NewInternalError("failed to generate token", err)
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == apperrors.ErrInvalidTwoFactor {
+			s.recordLogin(ctx, event, domain.LoginFailureInvalidTwoFactor)
+		}
+		return nil, err
 	}
 
-	return user, token, nil
+	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateToken(user.ID, user.Email, user.SessionGeneration)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate token", err)
+	}
+
+	s.recordLogin(ctx, event, "")
+	return &domain.LoginResult{User: user, Token: token, RecoveryCodes: recoveryCodes}, nil
+}
+
+// StartTwoFactorEnrollment generates an authenticator secret for a user who
+// must enroll before signing in
+func (s *userService) StartTwoFactorEnrollment(ctx context.Context, challengeToken string) (*domain.TwoFactorEnrollment, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.StartTwoFactorEnrollment")
+	defer span.End()
+
+	claims, appErr := utils.ValidateChallengeToken(challengeToken, utils.PurposeTwoFactorEnroll)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if err := s.ValidateSession(ctx, claims.UserID, claims.SessionGeneration); err != nil {
+		return nil, err
+	}
+
+	return s.twoFactorService.Enroll(ctx, claims.UserID)
+}
+
+// challenge returns a challenge token for the next sign-in step
+func (s *userService) challenge(user *domain.User, purpose string) (*domain.LoginResult, error) {
+	return newChallenge(user, purpose, s.loginPolicy.ChallengeTTL)
+}
+
+// secondFactorPurpose returns the challenge a sign-in must pass once the
+// user's first factor, a password or the identity provider, is checked: a
+// code when they use two-factor, enrollment when it is required of them, or
+// none
+func secondFactorPurpose(user *domain.User, policy LoginPolicy) string {
+	switch {
+	case user.TwoFactorEnabled:
+		return utils.PurposeTwoFactor
+	case policy.RequireTwoFactor || user.TwoFactorRequired:
+		return utils.PurposeTwoFactorEnroll
+	}
+	return ""
+}
+
+func newChallenge(user *domain.User, purpose string, ttl time.Duration) (*domain.LoginResult, error) {
+	token, expiresAt, err := utils.GenerateChallengeToken(user.ID, user.Email, purpose, user.SessionGeneration, ttl)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to generate challenge token", err)
+	}
+
+	return &domain.LoginResult{
+		TwoFactorRequired:  purpose == utils.PurposeTwoFactor,
+		EnrollmentRequired: purpose == utils.PurposeTwoFactorEnroll,
+		ChallengeToken:     token,
+		ChallengeExpiresAt: &expiresAt,
+	}, nil
+}
+
+// checkLoginBlocks returns an error if the client IP or the account has too
+// many recent failed logins, recording the refused attempt
+func (s *userService) checkLoginBlocks(ctx context.Context, event *domain.LoginEvent) error {
+	return checkLoginBlocks(ctx, s.loginEventRepo, s.loginPolicy, event)
+}
+
+func checkLoginBlocks(ctx context.Context, loginEventRepo repository.LoginEventRepository, policy LoginPolicy, event *domain.LoginEvent) error {
+	if policy.IPMaxAttempts > 0 && event.IPAddress != "" {
+		failures, err := loginEventRepo.CountFailuresByIP(ctx, event.IPAddress, policy.IPWindow)
+		if err != nil {
+			return err
+		}
+		if failures >= policy.IPMaxAttempts {
+			recordLoginEvent(ctx, loginEventRepo, event, domain.LoginFailureIPBlocked)
+			return tooManyAttempts(policy.IPWindow)
+		}
+	}
+
+	failures, sinceLast, err := loginEventRepo.GetFailureStreak(ctx, event.Email, failureLookback)
+	if err != nil {
+		return err
+	}
+	if remaining := policy.lockDuration(failures) - sinceLast; remaining > 0 {
+		recordLoginEvent(ctx, loginEventRepo, event, domain.LoginFailureLocked)
+		return tooManyAttempts(remaining)
+	}
+
+	return nil
+}
+
+// recordLogin stores the attempt with failureReason, or as a success when it
+// is empty
+func (s *userService) recordLogin(ctx context.Context, event *domain.LoginEvent, failureReason string) {
+	recordLoginEvent(ctx, s.loginEventRepo, event, failureReason)
+}
+
+// recordLoginEvent stores a sign-in attempt and counts it in the metrics. A
+// failure to record is logged rather than failing the login.
+func recordLoginEvent(ctx context.Context, loginEventRepo repository.LoginEventRepository, event *domain.LoginEvent, failureReason string) {
+	event.Success = failureReason == ""
+	result := "success"
+	if !event.Success {
+		event.FailureReason = &failureReason
+		result = failureReason
+	}
+	metrics.LoginAttempts.WithLabelValues(result).Inc()
+
+	if err := loginEventRepo.RecordEvent(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "failed to record login event", "error", err)
+	}
+	if !event.Success {
+		slog.WarnContext(ctx, "login failed", "reason", failureReason, "client_ip", event.IPAddress)
+	}
+}
+
+func invalidCredentials() error {
+	return apperrors.NewAuthError(apperrors.ErrInvalidCredentials, "invalid email or password")
+}
+
+func accountDeactivated() error {
+	return apperrors.NewAuthError(apperrors.ErrAccountDeactivated, "this account has been deactivated; contact your administrator")
+}
+
+func tooManyAttempts(retryAfter time.Duration) error {
+	return apperrors.NewRateLimitError(apperrors.ErrTooManyAttempts,
+		fmt.Sprintf("too many failed login attempts, try again in %d seconds", int(math.Ceil(retryAfter.Seconds()))))
 }
 
 // GetProfile retrieves the current user's profile
 func (s *userService) GetProfile(ctx context.Context, userID string) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.GetProfile")
+	defer span.End()
+
 	user, err := s.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -109,6 +383,9 @@ func (s *userService) GetProfile(ctx context.Context, userID string) (*domain.Us
 
 // UpdateProfile updates the current user's profile
 func (s *userService) UpdateProfile(ctx context.Context, userID string, name string) (*domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.UpdateProfile")
+	defer span.End()
+
 	// Update user in database
 	user, err := s.userRepo.UpdateUser(ctx, userID, name)
 	if err != nil {
@@ -120,6 +397,9 @@ func (s *userService) UpdateProfile(ctx context.Context, userID string, name str
 
 // ListUsers retrieves all users (for assignee selection)
 func (s *userService) ListUsers(ctx context.Context) ([]domain.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.ListUsers")
+	defer span.End()
+
 	users, err := s.userRepo.ListUsers(ctx)
 	if err != nil {
 		return nil, err
@@ -127,3 +407,39 @@ func (s *userService) ListUsers(ctx context.Context) ([]domain.User, error) {
 
 	return users, nil
 }
+
+// ListLoginEvents returns the user's most recent sign-in attempts
+func (s *userService) ListLoginEvents(ctx context.Context, userID string, limit int) ([]domain.LoginEvent, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.ListLoginEvents")
+	defer span.End()
+
+	if limit < 1 || limit > 100 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "limit must be between 1 and 100")
+	}
+
+	return s.loginEventRepo.ListEventsByUserID(ctx, userID, limit)
+}
+
+// ValidateSession checks that a session or challenge token issued in
+// sessionGeneration still stands: its user exists, is active and hasn't had
+// their sessions revoked since
+func (s *userService) ValidateSession(ctx context.Context, userID string, sessionGeneration int) error {
+	ctx, span := tracing.StartSpan(ctx, "UserService.ValidateSession")
+	defer span.End()
+
+	generation, deactivated, err := s.userRepo.GetSessionState(ctx, userID)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == apperrors.ErrUserNotFound {
+			return apperrors.NewAuthError(apperrors.ErrInvalidToken, "invalid token")
+		}
+		return err
+	}
+	if deactivated {
+		return accountDeactivated()
+	}
+	if sessionGeneration != generation {
+		return apperrors.NewAuthError(apperrors.ErrSessionRevoked, "this session was signed out by a password or email change, or by an administrator; sign in again")
+	}
+
+	return nil
+}
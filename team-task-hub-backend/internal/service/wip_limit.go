@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/launchventures/team-task-hub-backend/internal/domain"
+	apperrors "github.com/launchventures/team-task-hub-backend/internal/errors"
+	"github.com/launchventures/team-task-hub-backend/internal/repository"
+	"github.com/launchventures/team-task-hub-backend/internal/tracing"
+	"github.com/launchventures/team-task-hub-backend/internal/utils"
+)
+
+// boardStatuses are the board columns in display order
+var boardStatuses = []string{"OPEN", "IN_PROGRESS", "DONE"}
+
+// WIPLimitService defines work-in-progress limit business logic operations
+type WIPLimitService interface {
+	GetLimits(ctx context.Context, projectID string) (*domain.WIPLimits, error)
+	SetLimits(ctx context.Context, projectID string, statusLimits map[string]int, assigneeInProgressLimit *int, enforcement string) (*domain.WIPLimits, error)
+	DeleteLimits(ctx context.Context, projectID string) error
+	GetBoardCounts(ctx context.Context, projectID string) (*domain.BoardCounts, error)
+	CheckChange(ctx context.Context, projectID, fromStatus, toStatus string, fromAssigneeID, toAssigneeID *string) ([]string, error)
+}
+
+type wipLimitService struct {
+	wipLimitRepo repository.WIPLimitRepository
+	projectRepo  repository.ProjectRepository
+}
+
+func NewWIPLimitService(wipLimitRepo repository.WIPLimitRepository, projectRepo repository.ProjectRepository) WIPLimitService {
+	return &wipLimitService{wipLimitRepo: wipLimitRepo, projectRepo: projectRepo}
+}
+
+// GetLimits retrieves a project's WIP limits; a project without limits gets an empty set
+func (s *wipLimitService) GetLimits(ctx context.Context, projectID string) (*domain.WIPLimits, error) {
+	ctx, span := tracing.StartSpan(ctx, "WIPLimitService.GetLimits")
+	defer span.End()
+
+	if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.wipLimitRepo.GetLimits(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limits == nil {
+		limits = &domain.WIPLimits{ProjectID: projectID, StatusLimits: map[string]int{}, Enforcement: "REJECT"}
+	}
+
+	return limits, nil
+}
+
+// SetLimits replaces a project's WIP limits
+func (s *wipLimitService) SetLimits(ctx context.Context, projectID string, statusLimits map[string]int, assigneeInProgressLimit *int, enforcement string) (*domain.WIPLimits, error) {
+	ctx, span := tracing.StartSpan(ctx, "WIPLimitService.SetLimits")
+	defer span.End()
+
+	if enforcement == "" {
+		enforcement = "REJECT"
+	}
+	if enforcement != "REJECT" && enforcement != "WARN" {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "enforcement must be REJECT or WARN")
+	}
+
+	if statusLimits == nil {
+		statusLimits = map[string]int{}
+	}
+	for status, limit := range statusLimits {
+		if appErr := utils.ValidateStatus(status); appErr != nil {
+			return nil, appErr
+		}
+		if limit < 1 {
+			return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "WIP limits must be at least 1")
+		}
+	}
+
+	if assigneeInProgressLimit != nil && *assigneeInProgressLimit < 1 {
+		return nil, apperrors.NewValidationError(apperrors.ErrInvalidInput, "WIP limits must be at least 1")
+	}
+
+	if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return s.wipLimitRepo.UpsertLimits(ctx, &domain.WIPLimits{
+		ProjectID:               projectID,
+		StatusLimits:            statusLimits,
+		AssigneeInProgressLimit: assigneeInProgressLimit,
+		Enforcement:             enforcement,
+	})
+}
+
+// DeleteLimits removes a project's WIP limits
+func (s *wipLimitService) DeleteLimits(ctx context.Context, projectID string) error {
+	ctx, span := tracing.StartSpan(ctx, "WIPLimitService.DeleteLimits")
+	defer span.End()
+
+	if _, err := s.projectRepo.GetProjectByID(ctx, projectID); err != nil {
+		return err
+	}
+
+	return s.wipLimitRepo.DeleteLimits(ctx, projectID)
+}
+
+// GetBoardCounts reports how full each column and each assignee's in-progress list is
+func (s *wipLimitService) GetBoardCounts(ctx context.Context, projectID string) (*domain.BoardCounts, error) {
+	ctx, span := tracing.StartSpan(ctx, "WIPLimitService.GetBoardCounts")
+	defer span.End()
+
+	limits, err := s.GetLimits(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	statusCounts, err := s.wipLimitRepo.CountByStatus(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := s.wipLimitRepo.CountInProgressByAssignee(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &domain.BoardCounts{
+		Columns:     make([]domain.ColumnCount, 0, len(boardStatuses)),
+		Assignees:   assignees,
+		Enforcement: limits.Enforcement,
+	}
+
+	for _, status := range boardStatuses {
+		column := domain.ColumnCount{Status: status, Count: statusCounts[status]}
+		if limit, ok := limits.StatusLimits[status]; ok {
+			column.Limit = &limit
+			column.Exceeded = column.Count > limit
+		}
+		counts.Columns = append(counts.Columns, column)
+	}
+
+	for i := range counts.Assignees {
+		if limits.AssigneeInProgressLimit != nil {
+			counts.Assignees[i].Limit = limits.AssigneeInProgressLimit
+			counts.Assignees[i].Exceeded = counts.Assignees[i].Count > *limits.AssigneeInProgressLimit
+		}
+	}
+
+	return counts, nil
+}
+
+// CheckChange checks whether a task entering toStatus (from fromStatus, or
+// being created when fromStatus is empty) and held by toAssigneeID would
+// exceed the project's WIP limits. Under REJECT enforcement a violation is
+// returned as ErrWIPLimitExceeded; under WARN it is returned as warnings.
+func (s *wipLimitService) CheckChange(ctx context.Context, projectID, fromStatus, toStatus string, fromAssigneeID, toAssigneeID *string) ([]string, error) {
+	ctx, span := tracing.StartSpan(ctx, "WIPLimitService.CheckChange")
+	defer span.End()
+
+	limits, err := s.wipLimitRepo.GetLimits(ctx, projectID)
+	if err != nil || limits == nil {
+		return nil, err
+	}
+
+	var violations []string
+
+	if limit, ok := limits.StatusLimits[toStatus]; ok && toStatus != fromStatus {
+		counts, err := s.wipLimitRepo.CountByStatus(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if counts[toStatus] >= limit {
+			violations = append(violations, fmt.Sprintf("%s column is at its WIP limit of %d", toStatus, limit))
+		}
+	}
+
+	if limits.AssigneeInProgressLimit != nil && toStatus == "IN_PROGRESS" && toAssigneeID != nil && *toAssigneeID != "" {
+		alreadyCounted := fromStatus == "IN_PROGRESS" && fromAssigneeID != nil && *fromAssigneeID == *toAssigneeID
+		if !alreadyCounted {
+			count, err := s.wipLimitRepo.CountAssigneeInProgress(ctx, projectID, *toAssigneeID)
+			if err != nil {
+				return nil, err
+			}
+			if count >= *limits.AssigneeInProgressLimit {
+				violations = append(violations, fmt.Sprintf("assignee is at the in-progress WIP limit of %d", *limits.AssigneeInProgressLimit))
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	if limits.Enforcement == "WARN" {
+		return violations, nil
+	}
+
+	return nil, apperrors.NewConflictError(apperrors.ErrWIPLimitExceeded, strings.Join(violations, "; "))
+}
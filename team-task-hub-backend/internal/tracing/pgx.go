@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer is a pgx tracer that records a client span for every query
+type QueryTracer struct{}
+
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{}
+}
+
+// TraceQueryStart starts a span for the query as a child of the caller's span
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		// Queries outside any trace (e.g. pool health checks) are not recorded
+		return ctx
+	}
+
+	ctx, _ = otel.Tracer(instrumentationName).Start(ctx, "db.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(data.SQL),
+			semconv.DBNamespace(conn.Config().Database),
+		),
+	)
+	return ctx
+}
+
+// TraceQueryEnd ends the query's span, recording any error
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	defer span.End()
+
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type clientKey struct{}
+
+// ClientInfo identifies where a request came from
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// ClientIP returns the request's client address. With trustProxy the address
+// is taken from the last X-Forwarded-For entry, the one added by our own
+// proxy; earlier entries are client-controlled.
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// WithClientInfo returns a context carrying the request's client details
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientKey{}, info)
+}
+
+// ClientInfoFromContext returns the client details carried by ctx, if any
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientKey{}).(ClientInfo)
+	return info
+}
@@ -2,66 +2,186 @@ package utils
 
 import (
 	"context"
+	errs "errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/launchventures/team-task-hub-backend/internal/errors"
 )
 
-const (
-	TokenExpiration = 24 * time.Hour
-)
+// KeySet signs and verifies tokens
+type KeySet interface {
+	// SigningKey returns the key new tokens are signed with, its ID for the
+	// kid header (empty for none) and its signing method
+	SigningKey() (kid string, method jwt.SigningMethod, key any, err error)
+	// VerificationKey returns the key a token with the kid header and alg
+	// was signed with
+	VerificationKey(kid, alg string) (any, error)
+	// Algorithms lists the algorithms tokens may be signed with
+	Algorithms() []string
+}
 
-// getJWTSecret loads JWT secret from environment or uses default
-func getJWTSecret() string {
-	if secret := os.Getenv("JWT_SECRET"); secret != "" {
-		return secret
-	}
-	return "your-secret-key-change-in-production"
+// JWTOptions configure token signing and validation
+type JWTOptions struct {
+	Keys KeySet
+	// Expiration is how long access tokens stay valid
+	Expiration time.Duration
+	// Issuer and Audience are set as the iss and aud claims and required
+	// when validating
+	Issuer   string
+	Audience string
+	// LegacySecret, when set, also accepts HS256 tokens signed with it
+	LegacySecret string
+}
+
+// jwtOptions are set from the application config at startup by ConfigureJWT
+var jwtOptions = JWTOptions{Expiration: 24 * time.Hour}
+
+// ConfigureJWT sets the keys and claims used to sign and verify tokens
+func ConfigureJWT(opts JWTOptions) {
+	jwtOptions = opts
+}
+
+// NewHMACKeySet returns a key set signing with a shared HS256 secret
+func NewHMACKeySet(secret string) KeySet {
+	return hmacKeySet([]byte(secret))
+}
+
+type hmacKeySet []byte
+
+func (k hmacKeySet) SigningKey() (string, jwt.SigningMethod, any, error) {
+	return "", jwt.SigningMethodHS256, []byte(k), nil
 }
 
-var JWTSecret = getJWTSecret()
+func (k hmacKeySet) VerificationKey(kid, alg string) (any, error) {
+	return []byte(k), nil
+}
+
+func (k hmacKeySet) Algorithms() []string {
+	return []string{jwt.SigningMethodHS256.Alg()}
+}
 
 type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
+	// Purpose is empty for access tokens and names the step a challenge
+	// token is good for
+	Purpose string `json:"purpose,omitempty"`
+	// SessionGeneration is the user's session generation when the token was
+	// issued; the token is revoked once the user's moves on
+	SessionGeneration int `json:"sgen,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a JWT token for a user
-func GenerateToken(userID string, email string) (string, error) {
+// Challenge token purposes
+const (
+	// PurposeTwoFactor tokens are exchanged with a TOTP or recovery code
+	PurposeTwoFactor = "two_factor"
+	// PurposeTwoFactorEnroll tokens let a user who must use two-factor
+	// authentication enroll before their first full sign-in
+	PurposeTwoFactorEnroll = "two_factor_enroll"
+)
+
+// GenerateToken creates a JWT token for a user in their current session
+// generation
+func GenerateToken(userID string, email string, sessionGeneration int) (string, error) {
+	tokenString, _, err := signToken(userID, email, "", sessionGeneration, jwtOptions.Expiration)
+	return tokenString, err
+}
+
+// GenerateChallengeToken creates a short-lived token for completing a
+// sign-in step. It is not accepted as an access token.
+func GenerateChallengeToken(userID, email, purpose string, sessionGeneration int, ttl time.Duration) (string, time.Time, error) {
+	return signToken(userID, email, purpose, sessionGeneration, ttl)
+}
+
+// signToken signs a token valid for ttl with the current signing key
+func signToken(userID, email, purpose string, sessionGeneration int, ttl time.Duration) (string, time.Time, error) {
+	kid, method, key, err := jwtOptions.Keys.SigningKey()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:  userID,
+		Email:   email,
+		Purpose: purpose,
+
+		SessionGeneration: sessionGeneration,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExpiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    jwtOptions.Issuer,
+			Audience:  jwt.ClaimStrings{jwtOptions.Audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(JWTSecret))
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	tokenString, err := token.SignedString(key)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
 	}
 
-	return tokenString, nil
+	return tokenString, expiresAt, nil
+}
+
+// ValidateChallengeToken verifies a challenge token issued for purpose
+func ValidateChallengeToken(tokenString, purpose string) (*JWTClaims, *errors.AppError) {
+	claims, appErr := parseToken(tokenString)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if claims.Purpose != purpose {
+		return nil, errors.NewAuthError(errors.ErrInvalidToken, "invalid challenge token")
+	}
+	return claims, nil
 }
 
-// ValidateToken verifies a JWT token and returns claims
+// ValidateToken verifies an access token and returns claims
 func ValidateToken(tokenString string) (*JWTClaims, *errors.AppError) {
+	claims, appErr := parseToken(tokenString)
+	if appErr != nil {
+		return nil, appErr
+	}
+	// Challenge tokens must not grant access to the API
+	if claims.Purpose != "" {
+		return nil, errors.NewAuthError(errors.ErrInvalidToken, "invalid token")
+	}
+	return claims, nil
+}
+
+func parseToken(tokenString string) (*JWTClaims, *errors.AppError) {
 	claims := &JWTClaims{}
 
+	algorithms := jwtOptions.Keys.Algorithms()
+	options := []jwt.ParserOption{jwt.WithExpirationRequired()}
+	legacy := jwtOptions.LegacySecret != "" && isHS256(tokenString)
+	if legacy {
+		// Tokens from before the switch have no iss or aud claims
+		algorithms = []string{jwt.SigningMethodHS256.Alg()}
+	} else {
+		options = append(options, jwt.WithIssuer(jwtOptions.Issuer), jwt.WithAudience(jwtOptions.Audience))
+	}
+	options = append(options, jwt.WithValidMethods(algorithms))
+
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		if legacy {
+			return []byte(jwtOptions.LegacySecret), nil
 		}
-		return []byte(JWTSecret), nil
-	})
+		kid, _ := token.Header["kid"].(string)
+		return jwtOptions.Keys.VerificationKey(kid, token.Method.Alg())
+	}, options...)
 
 	if err != nil {
+		if errs.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.NewAuthError(errors.ErrTokenExpired, "token has expired")
+		}
 		return nil, errors.NewAuthError(errors.ErrInvalidToken, "invalid token")
 	}
 
@@ -69,13 +189,15 @@ func ValidateToken(tokenString string) (*JWTClaims, *errors.AppError) {
 		return nil, errors.NewAuthError(errors.ErrInvalidToken, "token is not valid")
 	}
 
-	if claims.ExpiresAt != nil && claims.ExpiresAt.Before(time.Now()) {
-		return nil, errors.NewAuthError(errors.ErrTokenExpired, "token has expired")
-	}
-
 	return claims, nil
 }
 
+// isHS256 reports whether a token's header says it is signed with HS256
+func isHS256(tokenString string) bool {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &jwt.RegisteredClaims{})
+	return err == nil && token.Method.Alg() == jwt.SigningMethodHS256.Alg()
+}
+
 // ExtractUserIDFromToken extracts user ID from JWT token string
 func ExtractUserIDFromToken(tokenString string) (string, *errors.AppError) {
 	claims, appErr := ValidateToken(tokenString)
@@ -0,0 +1,100 @@
+package utils
+
+import "strings"
+
+// Ranks order tasks within a board column. A rank is a base-36 fraction
+// written without the leading "0." and without trailing zeros, so plain
+// byte-wise string comparison matches numeric order and there is always
+// room for another rank between two different ranks.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// MaxRankLength is the rank length past which a column should be rebalanced
+const MaxRankLength = 32
+
+// RankBetween returns a rank strictly between before and after. An empty
+// before means the start of the column and an empty after means its end.
+// before must sort lower than after.
+func RankBetween(before, after string) string {
+	if after == "" {
+		return rankMidpoint(before, "", false)
+	}
+	return rankMidpoint(before, after, true)
+}
+
+// rankMidpoint follows the fractional indexing approach: skip the common
+// prefix, then pick a digit halfway between the first differing digits, or
+// extend the lower bound when the digits are adjacent.
+func rankMidpoint(a, b string, bounded bool) string {
+	if bounded {
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + rankMidpoint(rest, b[n:], true)
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(rankDigits, a[0])
+	}
+	digitB := len(rankDigits)
+	if bounded {
+		digitB = strings.IndexByte(rankDigits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(rankDigits[(digitA+digitB+1)/2])
+	}
+
+	if bounded && len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(rankDigits[digitA]) + rankMidpoint(rest, "", false)
+}
+
+func rankDigitAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return '0'
+}
+
+// EvenRanks returns n ascending ranks spread evenly across the rank space,
+// leaving room for many inserts between neighbours. It is used to rebalance
+// a column whose ranks have grown too long.
+func EvenRanks(n int) []string {
+	base := int64(len(rankDigits))
+
+	// Pick the shortest width that leaves at least one digit of room between neighbours
+	width := 1
+	space := base
+	for space/int64(n+1) < base {
+		width++
+		space *= base
+	}
+	step := space / int64(n+1)
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := step * int64(i+1)
+		digits := make([]byte, width)
+		for d := width - 1; d >= 0; d-- {
+			digits[d] = rankDigits[value%base]
+			value /= base
+		}
+		ranks[i] = strings.TrimRight(string(digits), "0")
+	}
+
+	return ranks
+}
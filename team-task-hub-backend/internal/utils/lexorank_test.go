@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{name: "empty column", before: "", after: "", want: "i"},
+		{name: "end of column", before: "i", after: "", want: "r"},
+		{name: "start of column", before: "", after: "i", want: "9"},
+		{name: "room between digits", before: "a", after: "k", want: "f"},
+		{name: "adjacent digits extend the lower rank", before: "a", after: "b", want: "ai"},
+		{name: "common prefix", before: "a5", after: "a9", want: "a7"},
+		{name: "after extends before", before: "a", after: "a1", want: "a0i"},
+		{name: "before is longer", before: "az", after: "b", want: "azi"},
+		{name: "after has more digits", before: "a", after: "b5", want: "b"},
+		{name: "last digit", before: "z", after: "", want: "zi"},
+		{name: "first digit", before: "", after: "1", want: "0i"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RankBetween(tt.before, tt.after)
+			if got != tt.want {
+				t.Errorf("RankBetween(%q, %q) = %q, want %q", tt.before, tt.after, got, tt.want)
+			}
+			assertRankBetween(t, tt.before, tt.after, got)
+		})
+	}
+}
+
+func TestRankBetweenExhaustion(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		// next narrows the gap between before and after around the new rank
+		next func(before, after, rank string) (string, string)
+	}{
+		{name: "always after the same task", before: "a", after: "b", next: func(before, _, rank string) (string, string) { return before, rank }},
+		{name: "always before the same task", before: "a", after: "b", next: func(_, after, rank string) (string, string) { return rank, after }},
+		{name: "always at the start", before: "", after: "a", next: func(before, _, rank string) (string, string) { return before, rank }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := tt.before, tt.after
+
+			inserts := 0
+			rank := ""
+			for len(rank) <= MaxRankLength {
+				if inserts == 10000 {
+					t.Fatalf("rank still %d long after %d inserts", len(rank), inserts)
+				}
+				rank = RankBetween(before, after)
+				assertRankBetween(t, before, after, rank)
+				before, after = tt.next(before, after, rank)
+				inserts++
+			}
+
+			// Rebalancing the column leaves room again
+			ranks := EvenRanks(inserts)
+			assertEvenRanks(t, ranks)
+			if len(ranks[len(ranks)-1]) > MaxRankLength/4 {
+				t.Errorf("rebalanced ranks are still %d long", len(ranks[len(ranks)-1]))
+			}
+		})
+	}
+}
+
+func TestEvenRanks(t *testing.T) {
+	tests := []struct {
+		n         int
+		wantWidth int
+	}{
+		{n: 1, wantWidth: 2},
+		{n: 2, wantWidth: 2},
+		{n: 34, wantWidth: 2},
+		{n: 35, wantWidth: 3},
+		{n: 1000, wantWidth: 3},
+		{n: 5000, wantWidth: 4},
+	}
+
+	for _, tt := range tests {
+		ranks := EvenRanks(tt.n)
+		if len(ranks) != tt.n {
+			t.Fatalf("EvenRanks(%d) returned %d ranks", tt.n, len(ranks))
+		}
+		assertEvenRanks(t, ranks)
+		for _, rank := range ranks {
+			if len(rank) > tt.wantWidth {
+				t.Errorf("EvenRanks(%d) returned %q, longer than %d", tt.n, rank, tt.wantWidth)
+			}
+		}
+	}
+
+	if ranks := EvenRanks(0); len(ranks) != 0 {
+		t.Errorf("EvenRanks(0) = %v, want none", ranks)
+	}
+}
+
+// assertRankBetween checks rank is a well-formed rank strictly between before
+// and after, where empty bounds are the ends of the column
+func assertRankBetween(t *testing.T, before, after, rank string) {
+	t.Helper()
+	if rank == "" || strings.HasSuffix(rank, "0") || strings.Trim(rank, rankDigits) != "" {
+		t.Fatalf("RankBetween(%q, %q) = %q is not a valid rank", before, after, rank)
+	}
+	if rank <= before || (after != "" && rank >= after) {
+		t.Fatalf("RankBetween(%q, %q) = %q is out of order", before, after, rank)
+	}
+}
+
+// assertEvenRanks checks ranks ascend with room between neighbours
+func assertEvenRanks(t *testing.T, ranks []string) {
+	t.Helper()
+	for i, rank := range ranks {
+		if i == 0 {
+			assertRankBetween(t, "", "", rank)
+			continue
+		}
+		assertRankBetween(t, ranks[i-1], "", rank)
+		if between := RankBetween(ranks[i-1], rank); len(between) > len(rank)+1 {
+			t.Errorf("no room between %q and %q: got %q", ranks[i-1], rank, between)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+
+	// maxRRuleIterations bounds occurrence expansion so a sparse rule
+	// (e.g. BYDAY that never matches) cannot loop forever
+	maxRRuleIterations = 10000
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RRule is the supported subset of an RFC 5545 recurrence rule:
+// FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY, UNTIL and COUNT
+type RRule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Until    *time.Time
+	Count    int
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10"
+func ParseRRule(rule string) (*RRule, *errors.AppError) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, "recurrence rule cannot be empty")
+	}
+
+	r := &RRule{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, fmt.Sprintf("malformed rule part %q", part))
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq := strings.ToUpper(value)
+			if freq != FreqDaily && freq != FreqWeekly && freq != FreqMonthly {
+				return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, "FREQ must be DAILY, WEEKLY or MONTHLY")
+			}
+			r.Freq = freq
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 || interval > 365 {
+				return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, "INTERVAL must be between 1 and 365")
+			}
+			r.Interval = interval
+		case "BYDAY":
+			seen := make(map[time.Weekday]bool)
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[strings.ToUpper(strings.TrimSpace(day))]
+				if !ok {
+					return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, fmt.Sprintf("unsupported BYDAY value %q", day))
+				}
+				if !seen[weekday] {
+					seen[weekday] = true
+					r.ByDay = append(r.ByDay, weekday)
+				}
+			}
+		case "UNTIL":
+			until, err := parseRRuleTime(value)
+			if err != nil {
+				return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, "UNTIL must be formatted as YYYYMMDD or YYYYMMDDTHHMMSSZ")
+			}
+			r.Until = &until
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, "COUNT must be a positive integer")
+			}
+			r.Count = count
+		default:
+			return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, fmt.Sprintf("unsupported rule part %q", key))
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, "FREQ is required")
+	}
+	if r.Until != nil && r.Count > 0 {
+		return nil, errors.NewValidationError(errors.ErrInvalidRecurrence, "UNTIL and COUNT cannot both be set")
+	}
+
+	sort.Slice(r.ByDay, func(i, j int) bool {
+		return mondayIndex(r.ByDay[i]) < mondayIndex(r.ByDay[j])
+	})
+
+	return r, nil
+}
+
+// String renders the rule back into its canonical RRULE form
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns up to limit occurrences of the rule anchored at dtstart.
+// As in RFC 5545, dtstart is always the first occurrence.
+func (r *RRule) Occurrences(dtstart time.Time, limit int) []time.Time {
+	occurrences := make([]time.Time, 0, limit)
+	r.iterate(dtstart, func(t time.Time) bool {
+		occurrences = append(occurrences, t)
+		return len(occurrences) < limit
+	})
+	return occurrences
+}
+
+// OccurrencesAfter returns up to limit occurrences strictly after the given time
+func (r *RRule) OccurrencesAfter(dtstart, after time.Time, limit int) []time.Time {
+	occurrences := make([]time.Time, 0, limit)
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(after) {
+			occurrences = append(occurrences, t)
+		}
+		return len(occurrences) < limit
+	})
+	return occurrences
+}
+
+// Nth returns the nth (1-based) occurrence, or nil when the rule ends before it
+func (r *RRule) Nth(dtstart time.Time, n int) *time.Time {
+	if n < 1 {
+		return nil
+	}
+	occurrences := r.Occurrences(dtstart, n)
+	if len(occurrences) < n {
+		return nil
+	}
+	return &occurrences[n-1]
+}
+
+// iterate expands occurrences in chronological order until fn returns false
+// or the rule's COUNT/UNTIL bound is reached
+func (r *RRule) iterate(dtstart time.Time, fn func(time.Time) bool) {
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if t.Before(dtstart) {
+			return true
+		}
+		if r.Until != nil && t.After(*r.Until) {
+			return false
+		}
+		emitted++
+		if !fn(t) {
+			return false
+		}
+		return r.Count == 0 || emitted < r.Count
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// dtstart always counts as the first occurrence
+	if !emit(dtstart) {
+		return
+	}
+
+	for i := 1; i < maxRRuleIterations; i++ {
+		switch r.Freq {
+		case FreqDaily:
+			t := dtstart.AddDate(0, 0, i*interval)
+			if len(r.ByDay) > 0 && !r.hasWeekday(t.Weekday()) {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		case FreqWeekly:
+			// Iteration i covers the (i-1)th matching week, starting with dtstart's own week
+			weekStart := dtstart.AddDate(0, 0, -mondayIndex(dtstart.Weekday())+(i-1)*7*interval)
+			days := r.ByDay
+			if len(days) == 0 {
+				days = []time.Weekday{dtstart.Weekday()}
+			}
+			for _, weekday := range days {
+				t := weekStart.AddDate(0, 0, mondayIndex(weekday))
+				if !t.After(dtstart) {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+			}
+		case FreqMonthly:
+			year, month, _ := dtstart.Date()
+			monthStart := time.Date(year, month+time.Month((i-1)*interval), 1, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+			if len(r.ByDay) == 0 {
+				t := monthStart.AddDate(0, 0, dtstart.Day()-1)
+				// Months without the anchor day (e.g. the 31st) are skipped per RFC 5545
+				if t.Month() != monthStart.Month() || !t.After(dtstart) {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+				continue
+			}
+			for t := monthStart; t.Month() == monthStart.Month(); t = t.AddDate(0, 0, 1) {
+				if !r.hasWeekday(t.Weekday()) || !t.After(dtstart) {
+					continue
+				}
+				if !emit(t) {
+					return
+				}
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (r *RRule) hasWeekday(weekday time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// mondayIndex maps a weekday onto an ISO week where Monday is 0
+func mondayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func parseRRuleTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// A date-only UNTIL includes the whole day
+	return t.Add(24*time.Hour - time.Second), nil
+}
+
+// ValidateGenerateOn checks when the next occurrence of a recurring task is created
+func ValidateGenerateOn(generateOn string) *errors.AppError {
+	if generateOn != "COMPLETION" && generateOn != "SCHEDULE" {
+		return errors.NewValidationError(errors.ErrInvalidRecurrence, "generate_on must be COMPLETION or SCHEDULE")
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/errors"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "daily", rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefix and lower case", rule: "RRULE:freq=weekly;byday=th,mo", want: "FREQ=WEEKLY;BYDAY=MO,TH"},
+		{name: "duplicate days", rule: "FREQ=WEEKLY;BYDAY=MO,MO", want: "FREQ=WEEKLY;BYDAY=MO"},
+		{name: "interval and count", rule: "FREQ=MONTHLY;INTERVAL=2;COUNT=5", want: "FREQ=MONTHLY;INTERVAL=2;COUNT=5"},
+		{name: "date-only until covers the day", rule: "FREQ=DAILY;UNTIL=20260131", want: "FREQ=DAILY;UNTIL=20260131T235959Z"},
+		{name: "empty", rule: "", wantErr: true},
+		{name: "missing freq", rule: "COUNT=3", wantErr: true},
+		{name: "yearly", rule: "FREQ=YEARLY", wantErr: true},
+		{name: "zero interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "zero count", rule: "FREQ=DAILY;COUNT=0", wantErr: true},
+		{name: "until and count", rule: "FREQ=DAILY;UNTIL=20260131;COUNT=3", wantErr: true},
+		{name: "bymonthday", rule: "FREQ=MONTHLY;BYMONTHDAY=31", wantErr: true},
+		{name: "malformed part", rule: "FREQ=DAILY;COUNT", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, appErr := ParseRRule(tt.rule)
+			if tt.wantErr {
+				if appErr == nil {
+					t.Fatalf("ParseRRule(%q) = %v, want an error", tt.rule, r)
+				}
+				if appErr.Code != errors.ErrInvalidRecurrence {
+					t.Errorf("error code = %s, want %s", appErr.Code, errors.ErrInvalidRecurrence)
+				}
+				return
+			}
+			if appErr != nil {
+				t.Fatalf("ParseRRule(%q) returned %v", tt.rule, appErr)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   int
+		want    []time.Time
+	}{
+		{
+			name:    "daily with interval",
+			rule:    "FREQ=DAILY;INTERVAL=2",
+			dtstart: date(2026, time.March, 1),
+			limit:   3,
+			want:    []time.Time{date(2026, time.March, 1), date(2026, time.March, 3), date(2026, time.March, 5)},
+		},
+		{
+			name:    "daily on weekdays only",
+			rule:    "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: date(2026, time.March, 6), // Friday
+			limit:   3,
+			want:    []time.Time{date(2026, time.March, 6), date(2026, time.March, 9), date(2026, time.March, 10)},
+		},
+		{
+			name:    "weekly on several days",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TH",
+			dtstart: date(2026, time.March, 4), // Wednesday
+			limit:   4,
+			want:    []time.Time{date(2026, time.March, 4), date(2026, time.March, 5), date(2026, time.March, 9), date(2026, time.March, 12)},
+		},
+		{
+			name:    "every other week",
+			rule:    "FREQ=WEEKLY;INTERVAL=2",
+			dtstart: date(2026, time.March, 2),
+			limit:   3,
+			want:    []time.Time{date(2026, time.March, 2), date(2026, time.March, 16), date(2026, time.March, 30)},
+		},
+		{
+			name:    "monthly on the 31st skips shorter months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: date(2026, time.January, 31),
+			limit:   4,
+			want:    []time.Time{date(2026, time.January, 31), date(2026, time.March, 31), date(2026, time.May, 31), date(2026, time.July, 31)},
+		},
+		{
+			name:    "monthly on the 29th skips February outside leap years",
+			rule:    "FREQ=MONTHLY",
+			dtstart: date(2027, time.January, 29),
+			limit:   3,
+			want:    []time.Time{date(2027, time.January, 29), date(2027, time.March, 29), date(2027, time.April, 29)},
+		},
+		{
+			name:    "monthly on the 29th keeps February in leap years",
+			rule:    "FREQ=MONTHLY",
+			dtstart: date(2028, time.January, 29),
+			limit:   2,
+			want:    []time.Time{date(2028, time.January, 29), date(2028, time.February, 29)},
+		},
+		{
+			name:    "monthly on weekdays across a year end",
+			rule:    "FREQ=MONTHLY;BYDAY=SA",
+			dtstart: date(2026, time.December, 26), // Saturday
+			limit:   3,
+			want:    []time.Time{date(2026, time.December, 26), date(2027, time.January, 2), date(2027, time.January, 9)},
+		},
+		{
+			name:    "count includes dtstart",
+			rule:    "FREQ=DAILY;COUNT=2",
+			dtstart: date(2026, time.March, 1),
+			limit:   10,
+			want:    []time.Time{date(2026, time.March, 1), date(2026, time.March, 2)},
+		},
+		{
+			name:    "count counts occurrences, not days",
+			rule:    "FREQ=DAILY;BYDAY=SA;COUNT=2",
+			dtstart: date(2026, time.March, 2), // Monday
+			limit:   10,
+			want:    []time.Time{date(2026, time.March, 2), date(2026, time.March, 7)},
+		},
+		{
+			name:    "date-only until includes its day",
+			rule:    "FREQ=DAILY;UNTIL=20260303",
+			dtstart: date(2026, time.March, 1),
+			limit:   10,
+			want:    []time.Time{date(2026, time.March, 1), date(2026, time.March, 2), date(2026, time.March, 3)},
+		},
+		{
+			name:    "until before the time of day excludes that day",
+			rule:    "FREQ=DAILY;UNTIL=20260303T080000Z",
+			dtstart: date(2026, time.March, 1),
+			limit:   10,
+			want:    []time.Time{date(2026, time.March, 1), date(2026, time.March, 2)},
+		},
+		{
+			name:    "until before dtstart",
+			rule:    "FREQ=DAILY;UNTIL=20260228",
+			dtstart: date(2026, time.March, 1),
+			limit:   10,
+			want:    []time.Time{},
+		},
+		{
+			name:    "limit stops an endless rule",
+			rule:    "FREQ=MONTHLY",
+			dtstart: date(2026, time.March, 15),
+			limit:   2,
+			want:    []time.Time{date(2026, time.March, 15), date(2026, time.April, 15)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, appErr := ParseRRule(tt.rule)
+			if appErr != nil {
+				t.Fatalf("ParseRRule(%q) returned %v", tt.rule, appErr)
+			}
+			assertTimes(t, r.Occurrences(tt.dtstart, tt.limit), tt.want)
+		})
+	}
+}
+
+func TestRRuleOccurrencesAfter(t *testing.T) {
+	r, appErr := ParseRRule("FREQ=WEEKLY;BYDAY=MO,FR;COUNT=5")
+	if appErr != nil {
+		t.Fatalf("ParseRRule returned %v", appErr)
+	}
+	dtstart := date(2026, time.March, 2) // Monday
+
+	// COUNT bounds the whole series, not what follows after
+	got := r.OccurrencesAfter(dtstart, date(2026, time.March, 9), 10)
+	assertTimes(t, got, []time.Time{date(2026, time.March, 13), date(2026, time.March, 16)})
+}
+
+func TestRRuleNth(t *testing.T) {
+	r, appErr := ParseRRule("FREQ=DAILY;COUNT=3")
+	if appErr != nil {
+		t.Fatalf("ParseRRule returned %v", appErr)
+	}
+	dtstart := date(2026, time.March, 1)
+
+	tests := []struct {
+		n    int
+		want *time.Time
+	}{
+		{n: 0, want: nil},
+		{n: 1, want: &dtstart},
+		{n: 3, want: ptr(date(2026, time.March, 3))},
+		{n: 4, want: nil},
+	}
+
+	for _, tt := range tests {
+		got := r.Nth(dtstart, tt.n)
+		switch {
+		case got == nil && tt.want == nil:
+		case got == nil || tt.want == nil || !got.Equal(*tt.want):
+			t.Errorf("Nth(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func assertTimes(t *testing.T, got, want []time.Time) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences %v, want %d %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("occurrence %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
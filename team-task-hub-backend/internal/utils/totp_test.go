@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors,
+// "12345678901234567890", in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTPVectors(t *testing.T) {
+	// The RFC's 8-digit values, cut to their last 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, tt := range tests {
+		now := time.Unix(tt.unix, 0)
+		step, ok := ValidateTOTP(rfc6238Secret, tt.code, now)
+		if !ok {
+			t.Errorf("ValidateTOTP(%q) at %d was rejected", tt.code, tt.unix)
+			continue
+		}
+		if want := tt.unix / 30; step != want {
+			t.Errorf("ValidateTOTP(%q) at %d returned step %d, want %d", tt.code, tt.unix, step, want)
+		}
+	}
+}
+
+func TestValidateTOTPDrift(t *testing.T) {
+	// 1234567890 is in step 41152263, which has code 005924
+	const code = "005924"
+	const step = int64(41152263)
+	stepStart := time.Unix(step*30, 0)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		ok   bool
+	}{
+		{name: "start of the step", now: stepStart, ok: true},
+		{name: "end of the step", now: stepStart.Add(29 * time.Second), ok: true},
+		{name: "device one step ahead", now: stepStart.Add(-30 * time.Second), ok: true},
+		{name: "device one step behind", now: stepStart.Add(59 * time.Second), ok: true},
+		{name: "device two steps ahead", now: stepStart.Add(-31 * time.Second), ok: false},
+		{name: "device two steps behind", now: stepStart.Add(60 * time.Second), ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ValidateTOTP(rfc6238Secret, code, tt.now)
+			if ok != tt.ok {
+				t.Fatalf("ValidateTOTP at %v = %v, want %v", tt.now, ok, tt.ok)
+			}
+			// A code is tied to its own step wherever in the window it is used
+			if ok && got != step {
+				t.Errorf("ValidateTOTP at %v returned step %d, want %d", tt.now, got, step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPReuse(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	// Using a code again returns the same step, which callers reject because
+	// it isn't after the last step they recorded
+	first, ok := ValidateTOTP(rfc6238Secret, "005924", now)
+	if !ok {
+		t.Fatal("first use was rejected")
+	}
+	second, ok := ValidateTOTP(rfc6238Secret, "005924", now.Add(30*time.Second))
+	if !ok {
+		t.Fatal("second use within the window was rejected")
+	}
+	if second != first {
+		t.Errorf("second use returned step %d, want %d", second, first)
+	}
+
+	// The previous step's code is still accepted, but its step is older than
+	// one already used, so callers reject it too
+	previous := totpCode(mustDecodeSecret(t, rfc6238Secret), first-1)
+	step, ok := ValidateTOTP(rfc6238Secret, previous, now)
+	if !ok || step >= first {
+		t.Errorf("previous code returned step %d, %v; want %d, true", step, ok, first-1)
+	}
+}
+
+func TestValidateTOTPInput(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		ok     bool
+	}{
+		{name: "spaces are ignored", secret: rfc6238Secret, code: "005 924", ok: true},
+		{name: "lower case secret", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: "005924", ok: true},
+		{name: "wrong code", secret: rfc6238Secret, code: "005925", ok: false},
+		{name: "too short", secret: rfc6238Secret, code: "05924", ok: false},
+		{name: "eight digits", secret: rfc6238Secret, code: "89005924", ok: false},
+		{name: "empty", secret: rfc6238Secret, code: "", ok: false},
+		{name: "invalid secret", secret: "not base32!", code: "005924", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ValidateTOTP(tt.secret, tt.code, now); ok != tt.ok {
+				t.Errorf("ValidateTOTP(%q, %q) = %v, want %v", tt.secret, tt.code, ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key := mustDecodeSecret(t, secret); len(key) != 20 {
+		t.Errorf("secret is %d bytes, want 20", len(key))
+	}
+
+	// A code for the new secret validates against it
+	now := time.Now()
+	code := totpCode(mustDecodeSecret(t, secret), now.Unix()/30)
+	if _, ok := ValidateTOTP(secret, code, now); !ok {
+		t.Error("code for a generated secret was rejected")
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("recovery code %q is not formatted xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("recovery code %q was generated twice", code)
+		}
+		seen[code] = true
+	}
+
+	tests := []struct {
+		name  string
+		code  string
+		match bool
+	}{
+		{name: "as given", code: "pnf5n-grez6", match: true},
+		{name: "upper case", code: "PNF5N-GREZ6", match: true},
+		{name: "without the dash", code: "pnf5ngrez6", match: true},
+		{name: "with spaces", code: " pnf5n grez6 ", match: true},
+		{name: "different code", code: "pnf5n-grez7", match: false},
+	}
+
+	want := HashRecoveryCode("pnf5n-grez6")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HashRecoveryCode(tt.code) == want; got != tt.match {
+				t.Errorf("HashRecoveryCode(%q) matches = %v, want %v", tt.code, got, tt.match)
+			}
+		})
+	}
+}
+
+func mustDecodeSecret(t *testing.T, secret string) []byte {
+	t.Helper()
+	key, err := base32NoPadding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret %q is not base32: %v", secret, err)
+	}
+	return key
+}
@@ -54,6 +54,34 @@ func ValidateProjectName(name string) *errors.AppError {
 	return nil
 }
 
+// ValidateTemplateName checks if template name is valid
+func ValidateTemplateName(name string) *errors.AppError {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError(errors.ErrEmptyName, "template name cannot be empty")
+	}
+
+	if len(name) > 255 {
+		return errors.NewValidationError(errors.ErrEmptyName, "template name is too long")
+	}
+
+	return nil
+}
+
+// ValidateSprintName checks if sprint name is valid
+func ValidateSprintName(name string) *errors.AppError {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError(errors.ErrEmptyName, "sprint name cannot be empty")
+	}
+
+	if len(name) > 255 {
+		return errors.NewValidationError(errors.ErrEmptyName, "sprint name is too long")
+	}
+
+	return nil
+}
+
 // ValidateTaskTitle checks if task title is valid
 func ValidateTaskTitle(title string) *errors.AppError {
 	title = strings.TrimSpace(title)
@@ -135,6 +163,20 @@ func ValidateTaskStatusTransition(currentStatus, newStatus string) *errors.AppEr
 	return nil
 }
 
+// ValidateChecklistContent checks if checklist item text is valid
+func ValidateChecklistContent(content string) *errors.AppError {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return errors.NewValidationError(errors.ErrEmptyContent, "checklist item cannot be empty")
+	}
+
+	if len(content) > 500 {
+		return errors.NewValidationError(errors.ErrEmptyContent, "checklist item is too long")
+	}
+
+	return nil
+}
+
 // ValidateCommentContent checks if comment content is valid
 func ValidateCommentContent(content string) *errors.AppError {
 	content = strings.TrimSpace(content)
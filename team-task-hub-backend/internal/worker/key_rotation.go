@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+)
+
+// KeyRotationWorker periodically reloads the JWT signing keys, adding the
+// next key when rotation is due. The first load happens at startup, before
+// the worker is started.
+type KeyRotationWorker struct {
+	signingKeyService service.SigningKeyService
+	interval          time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu          sync.Mutex
+	lastRunAt   time.Time
+	lastSuccess time.Time
+	lastErr     error
+}
+
+func NewKeyRotationWorker(signingKeyService service.SigningKeyService, interval time.Duration) *KeyRotationWorker {
+	return &KeyRotationWorker{signingKeyService: signingKeyService, interval: interval}
+}
+
+// Start runs the worker in the background until Stop is called
+func (w *KeyRotationWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.mu.Lock()
+	w.lastRunAt = time.Now()
+	w.lastSuccess = w.lastRunAt
+	w.mu.Unlock()
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			w.runOnce(ctx)
+		}
+	}()
+}
+
+// Stop signals the worker to exit and waits for the current pass to finish
+func (w *KeyRotationWorker) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+}
+
+// Check reports whether the keys are being kept current: a pass must have
+// succeeded within the last three intervals. A single failed pass is
+// tolerated, since the keys already loaded stay usable.
+func (w *KeyRotationWorker) Check(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.lastRunAt.IsZero() {
+		return fmt.Errorf("key rotation worker has not run yet")
+	}
+	if since := time.Since(w.lastSuccess); since > 3*w.interval {
+		if w.lastErr != nil {
+			return fmt.Errorf("signing keys not refreshed for %s: %w", since.Round(time.Second), w.lastErr)
+		}
+		return fmt.Errorf("signing keys not refreshed for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+func (w *KeyRotationWorker) runOnce(ctx context.Context) {
+	err := w.signingKeyService.RotateKeys(ctx)
+
+	w.mu.Lock()
+	w.lastRunAt = time.Now()
+	w.lastErr = err
+	if err == nil {
+		w.lastSuccess = w.lastRunAt
+	}
+	w.mu.Unlock()
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to refresh signing keys", "worker", "key_rotation", "error", err)
+	}
+}
@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/launchventures/team-task-hub-backend/internal/service"
+)
+
+// RecurrenceWorker periodically generates occurrences for schedule-driven recurring tasks
+type RecurrenceWorker struct {
+	recurrenceService service.RecurrenceService
+	interval          time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu          sync.Mutex
+	lastRunAt   time.Time
+	lastSuccess time.Time
+	lastErr     error
+}
+
+func NewRecurrenceWorker(recurrenceService service.RecurrenceService, interval time.Duration) *RecurrenceWorker {
+	return &RecurrenceWorker{recurrenceService: recurrenceService, interval: interval}
+}
+
+// Start runs the worker in the background until Stop is called
+func (w *RecurrenceWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.runOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop signals the worker to exit and waits for the current pass to finish
+func (w *RecurrenceWorker) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+}
+
+// Check reports whether the worker is keeping up: its last pass must have
+// succeeded and a pass must have succeeded within the last three intervals
+func (w *RecurrenceWorker) Check(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.lastRunAt.IsZero() {
+		return fmt.Errorf("recurrence worker has not run yet")
+	}
+	if w.lastErr != nil {
+		return fmt.Errorf("last recurrence pass failed: %w", w.lastErr)
+	}
+	if since := time.Since(w.lastSuccess); since > 3*w.interval {
+		return fmt.Errorf("no successful recurrence pass for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+func (w *RecurrenceWorker) runOnce(ctx context.Context) {
+	created, err := w.recurrenceService.GenerateScheduledOccurrences(ctx)
+
+	w.mu.Lock()
+	w.lastRunAt = time.Now()
+	w.lastErr = err
+	if err == nil {
+		w.lastSuccess = w.lastRunAt
+	}
+	w.mu.Unlock()
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to generate scheduled occurrences", "worker", "recurrence", "error", err)
+		return
+	}
+	if created > 0 {
+		slog.InfoContext(ctx, "generated scheduled occurrences", "worker", "recurrence", "count", created)
+	}
+}
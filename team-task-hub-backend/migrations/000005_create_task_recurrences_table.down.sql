@@ -0,0 +1,2 @@
+-- Drop task recurrences table
+DROP TABLE IF EXISTS task_recurrences CASCADE;
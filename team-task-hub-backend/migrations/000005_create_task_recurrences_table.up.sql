@@ -0,0 +1,21 @@
+-- Recurrence rules for tasks; task_id always points at the current occurrence
+CREATE TABLE task_recurrences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL UNIQUE,
+    project_id UUID NOT NULL,
+    created_by_id UUID,
+    rrule TEXT NOT NULL,
+    dtstart TIMESTAMP NOT NULL,
+    generate_on VARCHAR(20) NOT NULL DEFAULT 'COMPLETION' CHECK (generate_on IN ('COMPLETION', 'SCHEDULE')),
+    current_occurrence_at TIMESTAMP NOT NULL,
+    next_occurrence_at TIMESTAMP,
+    occurrence_count INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_task_recurrences_schedule ON task_recurrences(current_occurrence_at)
+    WHERE generate_on = 'SCHEDULE' AND next_occurrence_at IS NOT NULL;
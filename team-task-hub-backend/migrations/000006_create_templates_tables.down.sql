@@ -0,0 +1,4 @@
+-- Drop template tables
+DROP TABLE IF EXISTS project_template_tasks CASCADE;
+DROP TABLE IF EXISTS project_templates CASCADE;
+DROP TABLE IF EXISTS task_templates CASCADE;
@@ -0,0 +1,37 @@
+-- Reusable task templates
+CREATE TABLE task_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_by_id UUID,
+    name VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority VARCHAR(50) NOT NULL DEFAULT 'MEDIUM' CHECK (priority IN ('LOW', 'MEDIUM', 'HIGH')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Project templates capture a project's structure and seed tasks
+CREATE TABLE project_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_by_id UUID,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Seed tasks of a project template; due dates are relative to instantiation
+CREATE TABLE project_template_tasks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    template_id UUID NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    priority VARCHAR(50) NOT NULL DEFAULT 'MEDIUM' CHECK (priority IN ('LOW', 'MEDIUM', 'HIGH')),
+    due_offset_days INTEGER CHECK (due_offset_days >= 0),
+    position INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (template_id) REFERENCES project_templates(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_project_template_tasks_template_id ON project_template_tasks(template_id);
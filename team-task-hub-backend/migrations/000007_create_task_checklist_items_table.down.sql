@@ -0,0 +1,3 @@
+-- Drop task checklist items table
+ALTER TABLE task_templates DROP COLUMN IF EXISTS checklist;
+DROP TABLE IF EXISTS task_checklist_items CASCADE;
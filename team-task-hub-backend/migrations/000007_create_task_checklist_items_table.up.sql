@@ -0,0 +1,18 @@
+-- Ordered checklist items inside a task
+CREATE TABLE task_checklist_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL,
+    content VARCHAR(500) NOT NULL,
+    is_done BOOLEAN NOT NULL DEFAULT FALSE,
+    assignee_id UUID,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_task_checklist_items_task_id ON task_checklist_items(task_id, position);
+
+-- Task templates can carry checklist items that are copied onto new tasks
+ALTER TABLE task_templates ADD COLUMN checklist TEXT[] NOT NULL DEFAULT '{}';
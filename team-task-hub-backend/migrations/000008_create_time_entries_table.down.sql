@@ -0,0 +1,4 @@
+-- Drop time entries table and task estimates
+ALTER TABLE tasks DROP COLUMN IF EXISTS remaining_estimate_minutes;
+ALTER TABLE tasks DROP COLUMN IF EXISTS original_estimate_minutes;
+DROP TABLE IF EXISTS time_entries CASCADE;
@@ -0,0 +1,24 @@
+-- Time logged against tasks; a running timer has no ended_at
+CREATE TABLE time_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    duration_seconds INTEGER NOT NULL DEFAULT 0 CHECK (duration_seconds >= 0),
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_time_entries_task_id ON time_entries(task_id);
+CREATE INDEX idx_time_entries_user_started_at ON time_entries(user_id, started_at);
+
+-- At most one running timer per user
+CREATE UNIQUE INDEX idx_time_entries_running_timer ON time_entries(user_id) WHERE ended_at IS NULL;
+
+-- Estimates on tasks, in minutes
+ALTER TABLE tasks ADD COLUMN original_estimate_minutes INTEGER CHECK (original_estimate_minutes >= 0);
+ALTER TABLE tasks ADD COLUMN remaining_estimate_minutes INTEGER CHECK (remaining_estimate_minutes >= 0);
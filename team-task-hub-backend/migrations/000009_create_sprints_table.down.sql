@@ -0,0 +1,5 @@
+-- Drop sprints, task sprint membership and status history
+DROP TABLE IF EXISTS task_status_history CASCADE;
+ALTER TABLE tasks DROP COLUMN IF EXISTS story_points;
+ALTER TABLE tasks DROP COLUMN IF EXISTS sprint_id;
+DROP TABLE IF EXISTS sprints CASCADE;
@@ -0,0 +1,49 @@
+-- Project-scoped iterations that tasks can be planned into
+CREATE TABLE sprints (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL,
+    created_by_id UUID,
+    name VARCHAR(255) NOT NULL,
+    goal TEXT,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'PLANNED' CHECK (status IN ('PLANNED', 'ACTIVE', 'COMPLETED')),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL,
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_sprints_project_id ON sprints(project_id);
+
+-- At most one active sprint per project
+CREATE UNIQUE INDEX idx_sprints_active_project ON sprints(project_id) WHERE status = 'ACTIVE';
+
+-- Sprint membership and story points on tasks
+ALTER TABLE tasks ADD COLUMN sprint_id UUID REFERENCES sprints(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN story_points INTEGER CHECK (story_points >= 0);
+
+CREATE INDEX idx_tasks_sprint_id ON tasks(sprint_id);
+
+-- Every status a task has been in, used for burndown charts and reporting
+CREATE TABLE task_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_status_history_task_changed_at ON task_status_history(task_id, changed_at);
+
+-- Seed history for existing tasks: created as OPEN, moved to their current
+-- status at their last update
+INSERT INTO task_status_history (task_id, from_status, to_status, changed_at)
+SELECT id, NULL, 'OPEN', created_at FROM tasks;
+
+INSERT INTO task_status_history (task_id, from_status, to_status, changed_at)
+SELECT id, 'OPEN', status, updated_at FROM tasks WHERE status <> 'OPEN';
@@ -0,0 +1,3 @@
+-- Drop task ranks
+DROP INDEX IF EXISTS idx_tasks_project_status_rank;
+ALTER TABLE tasks DROP COLUMN IF EXISTS rank;
@@ -0,0 +1,15 @@
+-- Manual ordering of tasks within a board column (project + status).
+-- Ranks are compared byte-wise, hence the "C" collation.
+ALTER TABLE tasks ADD COLUMN rank VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+
+-- Rank existing tasks by creation time within each column. Decimal digits are
+-- valid rank digits; trailing zeros are not allowed in a rank.
+UPDATE tasks t
+SET rank = ranked.rank
+FROM (
+    SELECT id, RTRIM(LPAD((ROW_NUMBER() OVER (PARTITION BY project_id, status ORDER BY created_at, id) * 1000)::TEXT, 12, '0'), '0') AS rank
+    FROM tasks
+) ranked
+WHERE t.id = ranked.id;
+
+CREATE INDEX idx_tasks_project_status_rank ON tasks(project_id, status, rank);
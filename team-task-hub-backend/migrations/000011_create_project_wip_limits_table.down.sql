@@ -0,0 +1,2 @@
+-- Drop project WIP limits table
+DROP TABLE IF EXISTS project_wip_limits CASCADE;
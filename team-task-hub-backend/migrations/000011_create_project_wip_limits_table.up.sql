@@ -0,0 +1,10 @@
+-- Work-in-progress limits per board column, one row per project
+CREATE TABLE project_wip_limits (
+    project_id UUID PRIMARY KEY,
+    status_limits JSONB NOT NULL DEFAULT '{}',
+    assignee_in_progress_limit INTEGER CHECK (assignee_in_progress_limit > 0),
+    enforcement VARCHAR(50) NOT NULL DEFAULT 'REJECT' CHECK (enforcement IN ('REJECT', 'WARN')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+-- Drop login events table
+DROP TABLE IF EXISTS login_events CASCADE;
@@ -0,0 +1,16 @@
+-- Sign-in attempts, used for lockouts and the user's sign-in history
+CREATE TABLE login_events (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    email VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    failure_reason VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_login_events_email_created_at ON login_events(email, created_at);
+CREATE INDEX idx_login_events_ip_created_at ON login_events(ip_address, created_at) WHERE NOT success;
+CREATE INDEX idx_login_events_user_created_at ON login_events(user_id, created_at);
@@ -0,0 +1,17 @@
+-- TOTP two-factor authentication. totp_secret is set on enrollment and
+-- totp_enabled once the user confirms a code; totp_last_step rejects replays.
+ALTER TABLE users
+    ADD COLUMN totp_secret VARCHAR(64),
+    ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN totp_last_step BIGINT;
+
+-- One-time recovery codes, stored as SHA-256 hashes
+CREATE TABLE user_recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,3 @@
+-- Drop OIDC identities and sign-in states
+DROP TABLE IF EXISTS oidc_login_states CASCADE;
+DROP TABLE IF EXISTS user_identities CASCADE;
@@ -0,0 +1,23 @@
+-- Accounts at external OpenID Connect providers, keyed by issuer and subject.
+-- email is the address the provider last reported.
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (issuer, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- In-flight OIDC sign-ins: the PKCE verifier and nonce for each state, used once
+CREATE TABLE oidc_login_states (
+    state VARCHAR(64) PRIMARY KEY,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Drop the JWT signing key ring
+DROP TABLE IF EXISTS jwt_signing_keys CASCADE;
@@ -0,0 +1,13 @@
+-- Key ring for RS256/EdDSA token signing. Each key signs from activates_at
+-- until a newer key activates; superseded keys are deleted once the tokens
+-- they signed have expired. TIMESTAMPTZ because activation times are set by
+-- the application, not the database.
+CREATE TABLE jwt_signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(16) NOT NULL,
+    private_key BYTEA NOT NULL,
+    activates_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_jwt_signing_keys_activates_at ON jwt_signing_keys(activates_at);
@@ -0,0 +1,6 @@
+-- Drop account change tracking
+DROP TABLE IF EXISTS audit_events CASCADE;
+DROP TABLE IF EXISTS email_change_requests CASCADE;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS sessions_revoked_at;
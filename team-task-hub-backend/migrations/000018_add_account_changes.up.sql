@@ -0,0 +1,32 @@
+-- Session tokens issued before sessions_revoked_at are rejected. It is set
+-- when the password or email changes, signing out every other session.
+ALTER TABLE users
+    ADD COLUMN sessions_revoked_at TIMESTAMPTZ;
+
+-- Pending email changes, one per user, confirmed with a token sent to the
+-- new address and stored as a SHA-256 hash
+CREATE TABLE email_change_requests (
+    user_id UUID PRIMARY KEY,
+    new_email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Audit log of changes to accounts. actor_id is who made the change, which
+-- is the user themselves unless an administrator acted on their behalf.
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    actor_id UUID,
+    action VARCHAR(50) NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_audit_events_user_created_at ON audit_events(user_id, created_at);
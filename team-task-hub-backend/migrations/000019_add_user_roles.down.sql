@@ -0,0 +1,4 @@
+-- Drop user roles and deactivation
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deactivated_at,
+    DROP COLUMN IF EXISTS role;
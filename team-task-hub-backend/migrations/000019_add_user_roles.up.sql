@@ -0,0 +1,5 @@
+-- System-wide roles: admins manage other users' accounts. Deactivated users
+-- cannot sign in, and their session and access tokens stop working.
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('member', 'admin')),
+    ADD COLUMN deactivated_at TIMESTAMP;
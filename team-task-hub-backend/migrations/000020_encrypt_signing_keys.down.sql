@@ -0,0 +1,4 @@
+-- Drop the encrypted signing keys, which older builds can't read
+DELETE FROM jwt_signing_keys;
+
+ALTER TABLE jwt_signing_keys RENAME COLUMN encrypted_private_key TO private_key;
@@ -0,0 +1,7 @@
+-- Signing keys are now stored encrypted with jwt.key_encryption_key. The
+-- keys stored so far were in plain text and may be in backups, so they are
+-- retired rather than encrypted: the next start creates a new key, and tokens
+-- they signed stop being accepted.
+DELETE FROM jwt_signing_keys;
+
+ALTER TABLE jwt_signing_keys RENAME COLUMN private_key TO encrypted_private_key;
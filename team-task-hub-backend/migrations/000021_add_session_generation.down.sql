@@ -0,0 +1,9 @@
+-- Drop session generations, revoking the sessions of users who had moved past
+-- the first one
+ALTER TABLE users
+    ADD COLUMN sessions_revoked_at TIMESTAMPTZ;
+
+UPDATE users SET sessions_revoked_at = NOW() WHERE session_generation > 0;
+
+ALTER TABLE users
+    DROP COLUMN session_generation;
@@ -0,0 +1,12 @@
+-- Session tokens carry the session generation they were issued in, and are
+-- rejected once it moves on. Unlike comparing issue times, which have
+-- one-second precision, it also revokes tokens issued in the same second as
+-- the change. Users whose sessions were revoked before start at generation 1,
+-- so their tokens from before this migration, which carry none, are revoked.
+ALTER TABLE users
+    ADD COLUMN session_generation INTEGER NOT NULL DEFAULT 0;
+
+UPDATE users SET session_generation = 1 WHERE sessions_revoked_at IS NOT NULL;
+
+ALTER TABLE users
+    DROP COLUMN sessions_revoked_at;
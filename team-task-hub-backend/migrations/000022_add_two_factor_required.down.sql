@@ -0,0 +1,3 @@
+-- Drop per-user two-factor requirements
+ALTER TABLE users
+    DROP COLUMN two_factor_required;
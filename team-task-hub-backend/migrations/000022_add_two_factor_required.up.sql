@@ -0,0 +1,4 @@
+-- Admins can require two-factor authentication of individual users, on top
+-- of the server-wide setting
+ALTER TABLE users
+    ADD COLUMN two_factor_required BOOLEAN NOT NULL DEFAULT FALSE;
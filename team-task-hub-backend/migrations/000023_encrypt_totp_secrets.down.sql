@@ -0,0 +1,12 @@
+-- Drop encrypted TOTP secrets, which older builds can't read. Users whose
+-- secret was only stored encrypted have two-factor turned off and need to
+-- enroll again.
+DELETE FROM user_recovery_codes
+WHERE user_id IN (SELECT id FROM users WHERE encrypted_totp_secret IS NOT NULL AND totp_secret IS NULL);
+
+UPDATE users
+SET totp_enabled = FALSE, totp_last_step = NULL
+WHERE encrypted_totp_secret IS NOT NULL AND totp_secret IS NULL;
+
+ALTER TABLE users
+    DROP COLUMN encrypted_totp_secret;
@@ -0,0 +1,6 @@
+-- TOTP secrets are now stored encrypted with jwt.key_encryption_key.
+-- Retiring the plain text ones would turn two-factor off for everyone, so the
+-- server encrypts them into the new column on its next start and clears
+-- totp_secret.
+ALTER TABLE users
+    ADD COLUMN encrypted_totp_secret BYTEA;